@@ -0,0 +1,9 @@
+// Package api встраивает OpenAPI-спецификацию, сгенерированную из proto (make proto-openapi).
+package api
+
+import _ "embed"
+
+// OpenAPISpec — api/openapi.swagger.json; отдаётся на /openapi.json.
+//
+//go:embed openapi.swagger.json
+var OpenAPISpec []byte
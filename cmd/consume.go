@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/user-service/internal/application"
+	"github.com/psds-microservice/user-service/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var consumeCmd = &cobra.Command{
+	Use:   "consume",
+	Short: "Run RabbitMQ consumer for session-manager events",
+	RunE:  runConsume,
+}
+
+func runConsume(cmd *cobra.Command, args []string) error {
+	if err := godotenv.Load(".env"); err != nil {
+		_ = godotenv.Load("../.env")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	app, err := application.NewConsume(cfg)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := app.Run(ctx); err != nil {
+		return err
+	}
+	log.Println("bye")
+	return nil
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/joho/godotenv"
 	"github.com/psds-microservice/user-service/internal/command"
@@ -21,21 +24,173 @@ var migrateUpCmd = &cobra.Command{
 	RunE:  runMigrateUp,
 }
 
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back the last N migrations (default 1)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runMigrateDown,
+}
+
+var migrateGotoCmd = &cobra.Command{
+	Use:   "goto V",
+	Short: "Migrate up or down to version V",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMigrateGoto,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"version"},
+	Short:   "Show the applied version, dirty flag and pending migrations",
+	Args:    cobra.NoArgs,
+	RunE:    runMigrateStatus,
+}
+
+var migrateForceCmd = &cobra.Command{
+	Use:   "force V",
+	Short: "Set version V and clear the dirty flag without running SQL (-1 — no migrations)",
+	Long: "Set version V and clear the dirty flag without running SQL. V = -1 marks the database as having no migrations;\n" +
+		"pass it after -- so that it is not parsed as a flag: migrate force -- -1.",
+	Example: "  user-service migrate force 12\n  user-service migrate force -- -1",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runMigrateForce,
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create an empty numbered up/down migration pair in database/migrations",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runMigrateCreate,
+}
+
+var (
+	migrateStatusJSON bool
+	migrateCreateName string
+)
+
 func init() {
-	migrateCmd.AddCommand(migrateUpCmd)
+	migrateStatusCmd.Flags().BoolVar(&migrateStatusJSON, "json", false, "print status as JSON")
+	migrateCreateCmd.Flags().StringVar(&migrateCreateName, "name", "", "migration name (alternative to the NAME argument)")
+	// Без "--" pflag принимает "-1" за флаг: подсказываем правильную форму.
+	migrateForceCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return fmt.Errorf("%w (for version -1 run `migrate force -- -1`)", err)
+	})
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateGotoCmd, migrateStatusCmd, migrateForceCmd, migrateCreateCmd)
 }
 
-func runMigrateUp(cmd *cobra.Command, args []string) error {
+// migrateDatabaseURL загружает .env и конфиг и возвращает URL БД для golang-migrate.
+func migrateDatabaseURL() (string, error) {
 	if err := godotenv.Load(".env"); err != nil {
 		_ = godotenv.Load("../.env")
 	}
 	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("config: %w", err)
+		return "", fmt.Errorf("config: %w", err)
+	}
+	return cfg.DatabaseURL(), nil
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	dbURL, err := migrateDatabaseURL()
+	if err != nil {
+		return err
 	}
-	if err := command.MigrateUp(cfg.DatabaseURL()); err != nil {
+	if err := command.MigrateUp(dbURL); err != nil {
 		return fmt.Errorf("migrate: %w", err)
 	}
 	log.Println("migrate up: ok")
 	return nil
 }
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	steps := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("N must be a positive integer, got %q", args[0])
+		}
+		steps = n
+	}
+	dbURL, err := migrateDatabaseURL()
+	if err != nil {
+		return err
+	}
+	if err := command.MigrateDown(dbURL, steps); err != nil {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	log.Printf("migrate down %d: ok", steps)
+	return nil
+}
+
+func runMigrateGoto(cmd *cobra.Command, args []string) error {
+	v, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("V must be a non-negative integer, got %q", args[0])
+	}
+	dbURL, err := migrateDatabaseURL()
+	if err != nil {
+		return err
+	}
+	if err := command.MigrateGoto(dbURL, uint(v)); err != nil {
+		return fmt.Errorf("migrate goto: %w", err)
+	}
+	log.Printf("migrate goto %d: ok", v)
+	return nil
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	dbURL, err := migrateDatabaseURL()
+	if err != nil {
+		return err
+	}
+	st, err := command.MigrateStatus(dbURL)
+	if err != nil {
+		return fmt.Errorf("migrate status: %w", err)
+	}
+	out := cmd.OutOrStdout()
+	if migrateStatusJSON {
+		if err := json.NewEncoder(out).Encode(st); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(out, "version: %d\ndirty: %v\nlatest: %d\npending: %d\n", st.Version, st.Dirty, st.Latest, st.Pending)
+	}
+	if st.Dirty {
+		// Ненулевой код выхода: скрипты деплоя не должны продолжать на dirty-схеме.
+		return fmt.Errorf("database is dirty at version %d: fix the schema, then run `migrate force %d`", st.Version, st.Version)
+	}
+	return nil
+}
+
+func runMigrateForce(cmd *cobra.Command, args []string) error {
+	v, err := strconv.Atoi(args[0])
+	if err != nil || v < -1 {
+		return fmt.Errorf("V must be an integer >= -1, got %q", args[0])
+	}
+	dbURL, err := migrateDatabaseURL()
+	if err != nil {
+		return err
+	}
+	if err := command.MigrateForce(dbURL, v); err != nil {
+		return fmt.Errorf("migrate force: %w", err)
+	}
+	log.Printf("migrate force %d: ok", v)
+	return nil
+}
+
+func runMigrateCreate(cmd *cobra.Command, args []string) error {
+	name := migrateCreateName
+	if len(args) == 1 {
+		name = args[0]
+	}
+	if name == "" {
+		return errors.New("migration name is required: migrate create NAME")
+	}
+	up, down, err := command.MigrateCreate(name)
+	if err != nil {
+		return fmt.Errorf("migrate create: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), up)
+	fmt.Fprintln(cmd.OutOrStdout(), down)
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestMigrateForce_NegativeVersion(t *testing.T) {
+	var got []string
+	run := migrateForceCmd.RunE
+	migrateForceCmd.RunE = func(_ *cobra.Command, args []string) error {
+		got = args
+		return nil
+	}
+	defer func() {
+		migrateForceCmd.RunE = run
+		rootCmd.SetArgs(nil)
+	}()
+
+	rootCmd.SetArgs([]string{"migrate", "force", "--", "-1"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("migrate force -- -1: %v", err)
+	}
+	if len(got) != 1 || got[0] != "-1" {
+		t.Fatalf("expected args [-1], got %v", got)
+	}
+
+	rootCmd.SetArgs([]string{"migrate", "force", "-1"})
+	rootCmd.SilenceUsage, rootCmd.SilenceErrors = true, true
+	defer func() { rootCmd.SilenceUsage, rootCmd.SilenceErrors = false, false }()
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "migrate force -- -1") {
+		t.Fatalf("migrate force -1: expected a hint to use --, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/psds-microservice/user-service/internal/database"
 	"github.com/spf13/cobra"
 )
 
@@ -8,15 +9,24 @@ var rootCmd = &cobra.Command{
 	Use:   "user-service",
 	Short: "User service: auth, profiles, operators, sessions",
 	RunE:  runAPI, // по умолчанию — запуск API (для обратной совместимости)
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		database.SetMigrationsDir(migrationsDir)
+	},
 }
 
+// migrationsDir — --migrations-dir: миграции с диска вместо встроенных в бинарник (разработка).
+var migrationsDir string
+
 // Execute запускает корневую команду (Cobra CLI).
 func Execute() error {
 	return rootCmd.Execute()
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVar(&migrationsDir, "migrations-dir", "", "read migrations from this directory instead of the embedded ones (development)")
 	rootCmd.AddCommand(apiCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(consumeCmd)
+	rootCmd.AddCommand(usersCmd)
 }
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/psds-microservice/user-service/internal/application"
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/config"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+// Команды администрирования пользователей. Работают напрямую с БД через пакет service:
+// валидация, хеширование паролей, журнал ролей и события outbox — как у API.
+// Пользователь указывается UUID или email.
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage users: create-admin, set-role, block/unblock, reset-password, verify-operator, list",
+}
+
+var usersOutput string
+
+var usersCreateAdminCmd = &cobra.Command{
+	Use:   "create-admin",
+	Short: "Create an admin account (only the first one unless --force)",
+	Args:  cobra.NoArgs,
+	RunE:  runUsersCreateAdmin,
+}
+
+var usersSetRoleCmd = &cobra.Command{
+	Use:   "set-role <user> <client|operator|admin|service>",
+	Short: "Change a user's role and revoke the user's tokens",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runUsersSetRole,
+}
+
+var usersBlockCmd = &cobra.Command{
+	Use:   "block <user>",
+	Short: "Block a user and revoke the user's tokens",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUsersSetStatus(cmd, args[0], constants.UserStatusBlocked)
+	},
+}
+
+var usersUnblockCmd = &cobra.Command{
+	Use:   "unblock <user>",
+	Short: "Set a user's status back to active",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUsersSetStatus(cmd, args[0], constants.UserStatusActive)
+	},
+}
+
+var usersResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password <user>",
+	Short: "Set a new password (generated if --password is empty) and revoke the user's tokens",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUsersResetPassword,
+}
+
+var usersVerifyOperatorCmd = &cobra.Command{
+	Use:   "verify-operator <user>",
+	Short: "Set operator_status of an operator (verified by default)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUsersVerifyOperator,
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users",
+	Args:  cobra.NoArgs,
+	RunE:  runUsersList,
+}
+
+var usersFlags struct {
+	email    string
+	username string
+	password string
+	force    bool
+	reason   string
+	status   string
+	filters  dto.UserFilters
+}
+
+func init() {
+	usersCmd.PersistentFlags().StringVarP(&usersOutput, "output", "o", "table", "output format: table or json")
+
+	f := usersCreateAdminCmd.Flags()
+	f.StringVar(&usersFlags.email, "email", "", "admin email (required)")
+	f.StringVar(&usersFlags.username, "username", "", "admin username (default: email)")
+	f.StringVar(&usersFlags.password, "password", "", "admin password (generated and printed if empty)")
+	f.BoolVar(&usersFlags.force, "force", false, "create even if an admin already exists")
+	_ = usersCreateAdminCmd.MarkFlagRequired("email")
+
+	usersSetRoleCmd.Flags().StringVar(&usersFlags.reason, "reason", "", "reason recorded in the role audit trail")
+	usersResetPasswordCmd.Flags().StringVar(&usersFlags.password, "password", "", "new password (generated and printed if empty)")
+	usersVerifyOperatorCmd.Flags().StringVar(&usersFlags.status, "status", constants.OperatorStatusVerified, "operator status: pending, verified, blocked")
+
+	lf := usersListCmd.Flags()
+	lf.StringVar(&usersFlags.filters.Role, "role", "", "filter by role")
+	lf.StringVar(&usersFlags.filters.Status, "status", "", "filter by status")
+	lf.StringVar(&usersFlags.filters.OperatorStatus, "operator-status", "", "filter by operator status")
+	lf.StringVar(&usersFlags.filters.Search, "search", "", "substring of username or email")
+	lf.StringVar(&usersFlags.filters.Sort, "sort", "", "created_at, -created_at (default), username, -username")
+	lf.IntVar(&usersFlags.filters.Limit, "limit", 20, "page size (max 100)")
+	lf.StringVar(&usersFlags.filters.Cursor, "cursor", "", "next_cursor from the previous page")
+
+	usersCmd.AddCommand(usersCreateAdminCmd, usersSetRoleCmd, usersBlockCmd, usersUnblockCmd,
+		usersResetPasswordCmd, usersVerifyOperatorCmd, usersListCmd)
+}
+
+// newAdminApp загружает конфиг и создаёт сервисы; --output проверяется до подключения к БД.
+func newAdminApp() (*application.Admin, error) {
+	if usersOutput != "table" && usersOutput != "json" {
+		return nil, fmt.Errorf("--output must be table or json, got %q", usersOutput)
+	}
+	if err := godotenv.Load(".env"); err != nil {
+		_ = godotenv.Load("../.env")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return application.NewAdmin(cfg)
+}
+
+// cliContext помечает действия команды в журнале аудита: инициатора-пользователя нет, User-Agent — команда.
+func cliContext(cmd *cobra.Command) context.Context {
+	return audit.WithActor(cmd.Context(), audit.Actor{UserAgent: "cli: " + cmd.CommandPath()})
+}
+
+// resolveUser находит пользователя по UUID или email.
+func resolveUser(ctx context.Context, app *application.Admin, ref string) (*dto.UserResponse, error) {
+	if _, err := uuid.Parse(ref); err == nil {
+		return app.User.GetUser(ctx, ref)
+	}
+	return app.User.GetUserByEmail(ctx, ref)
+}
+
+// generatePassword — случайный пароль для create-admin/reset-password без --password.
+func generatePassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// revokeTokens отзывает токены после смены роли, статуса или пароля; ошибка не отменяет изменение.
+func revokeTokens(ctx context.Context, app *application.Admin, userID string) {
+	if err := app.Token.RevokeAllForUser(ctx, userID); err != nil {
+		log.Printf("users: revoke tokens of %s: %v", userID, err)
+	}
+}
+
+// userResult — вывод команды: пользователь и (однократно) сгенерированный пароль.
+type userResult struct {
+	*dto.UserResponse
+	GeneratedPassword string `json:"generated_password,omitempty"`
+}
+
+func printUser(w io.Writer, res userResult) error {
+	if usersOutput == "json" {
+		return writeJSON(w, res)
+	}
+	if err := printUserTable(w, []*dto.UserResponse{res.UserResponse}); err != nil {
+		return err
+	}
+	if res.GeneratedPassword != "" {
+		fmt.Fprintf(w, "generated password: %s\n", res.GeneratedPassword)
+	}
+	return nil
+}
+
+func printUserPage(w io.Writer, page *dto.UserPage) error {
+	if usersOutput == "json" {
+		return writeJSON(w, page)
+	}
+	if err := printUserTable(w, page.Users); err != nil {
+		return err
+	}
+	if page.NextCursor != "" {
+		fmt.Fprintf(w, "next cursor: %s\n", page.NextCursor)
+	}
+	return nil
+}
+
+func printUserTable(w io.Writer, users []*dto.UserResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEMAIL\tUSERNAME\tROLE\tSTATUS\tOPERATOR_STATUS")
+	for _, u := range users {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", u.ID, u.Email, u.Username, u.Role, u.Status, u.OperatorStatus)
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func runUsersCreateAdmin(cmd *cobra.Command, args []string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	req := &dto.CreateUserRequest{
+		Username: usersFlags.username,
+		Email:    usersFlags.email,
+		Password: usersFlags.password,
+	}
+	var res userResult
+	if req.Password == "" {
+		if req.Password, err = generatePassword(); err != nil {
+			return err
+		}
+		res.GeneratedPassword = req.Password
+	}
+	if err := app.Validate.ValidateCreateUserRequest(req); err != nil {
+		return err
+	}
+	res.UserResponse, err = app.User.CreateAdmin(cliContext(cmd), req, !usersFlags.force)
+	if errors.Is(err, errs.ErrAdminExists) {
+		return fmt.Errorf("create-admin: %w (use --force or set-role to add another admin)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("create-admin: %w", err)
+	}
+	return printUser(cmd.OutOrStdout(), res)
+}
+
+func runUsersSetRole(cmd *cobra.Command, args []string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	ctx := cliContext(cmd)
+	user, err := resolveUser(ctx, app, args[0])
+	if err != nil {
+		return err
+	}
+	req := &dto.SetUserRoleRequest{ID: user.ID, Role: args[1], Reason: usersFlags.reason}
+	if err := app.Validate.ValidateSetUserRoleRequest(req); err != nil {
+		return err
+	}
+	updated, err := app.User.SetUserRole(ctx, req)
+	if err != nil {
+		return fmt.Errorf("set-role: %w", err)
+	}
+	if updated.Role != user.Role {
+		revokeTokens(ctx, app, user.ID)
+	}
+	return printUser(cmd.OutOrStdout(), userResult{UserResponse: updated})
+}
+
+func runUsersSetStatus(cmd *cobra.Command, ref, status string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	ctx := cliContext(cmd)
+	user, err := resolveUser(ctx, app, ref)
+	if err != nil {
+		return err
+	}
+	updated, err := app.User.SetStatus(ctx, user.ID, status)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.Name(), err)
+	}
+	if status != constants.UserStatusActive {
+		revokeTokens(ctx, app, user.ID)
+	}
+	return printUser(cmd.OutOrStdout(), userResult{UserResponse: updated})
+}
+
+func runUsersResetPassword(cmd *cobra.Command, args []string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	ctx := cliContext(cmd)
+	user, err := resolveUser(ctx, app, args[0])
+	if err != nil {
+		return err
+	}
+	res := userResult{UserResponse: user}
+	password := usersFlags.password
+	if password == "" {
+		if password, err = generatePassword(); err != nil {
+			return err
+		}
+		res.GeneratedPassword = password
+	}
+	if err := app.Validate.ValidatePassword(password); err != nil {
+		return err
+	}
+	if err := app.User.SetPassword(ctx, user.ID, password); err != nil {
+		return fmt.Errorf("reset-password: %w", err)
+	}
+	revokeTokens(ctx, app, user.ID)
+	return printUser(cmd.OutOrStdout(), res)
+}
+
+func runUsersVerifyOperator(cmd *cobra.Command, args []string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	ctx := cliContext(cmd)
+	user, err := resolveUser(ctx, app, args[0])
+	if err != nil {
+		return err
+	}
+	updated, err := app.Operator.VerifyOperator(ctx, user.ID, usersFlags.status)
+	if err != nil {
+		return fmt.Errorf("verify-operator: %w", err)
+	}
+	return printUser(cmd.OutOrStdout(), userResult{UserResponse: updated})
+}
+
+func runUsersList(cmd *cobra.Command, args []string) error {
+	app, err := newAdminApp()
+	if err != nil {
+		return err
+	}
+	filters := usersFlags.filters
+	if err := app.Validate.ValidateUserFilters(&filters); err != nil {
+		return err
+	}
+	page, err := app.User.ListUsers(cmd.Context(), &filters)
+	if err != nil {
+		return err
+	}
+	return printUserPage(cmd.OutOrStdout(), page)
+}
@@ -0,0 +1,15 @@
+// Package database встраивает SQL-миграции и сиды в бинарник: запуск не зависит от рабочего каталога
+// и не требует копировать database/ в образ. Работа с БД — internal/database.
+package database
+
+import "embed"
+
+// Migrations — database/migrations/*.sql (golang-migrate, источник iofs).
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// Seeds — database/seeds/*.sql, применяются в лексикографическом порядке.
+//
+//go:embed seeds/*.sql
+var Seeds embed.FS
@@ -0,0 +1,4 @@
+-- auto_unavailable: оператор снят с доступности автоматически (достигнут max_sessions),
+-- а не вручную. При завершении сессии такие операторы возвращаются в доступные.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS auto_unavailable BOOLEAN NOT NULL DEFAULT FALSE;
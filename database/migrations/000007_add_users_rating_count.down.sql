@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_user_sessions_external_role;
+
+ALTER TABLE users DROP COLUMN IF EXISTS rating_sum;
+ALTER TABLE users DROP COLUMN IF EXISTS rating_count;
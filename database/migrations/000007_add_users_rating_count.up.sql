@@ -0,0 +1,7 @@
+-- rating_count и rating_sum: число и сумма оценок консультаций оператора. users.rating (DECIMAL(3,2))
+-- округлён и пересчитывается как rating_sum / rating_count, поэтому ошибка округления не накапливается.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS rating_sum INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_external_role ON user_sessions(session_external_id, participant_role);
@@ -0,0 +1,9 @@
+-- revoked_tokens: отозванные access-токены (logout), общие для всех реплик; истёкшие удаляет периодическая очистка
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+  jti VARCHAR(255) PRIMARY KEY,
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
@@ -0,0 +1,27 @@
+-- outbox_events: доменные события, записываемые в той же транзакции, что и изменение данных;
+-- relay публикует их в RabbitMQ и проставляет published_at.
+-- claimed_until: relay забирает пакет событий короткой транзакцией и публикует без блокировок строк;
+-- до истечения аренды событие не берут другие реплики.
+-- parked_at: событие, не опубликованное за OUTBOX_MAX_ATTEMPTS попыток, выводится из очереди и не блокирует
+-- последующие; вернуть его: UPDATE outbox_events SET parked_at = NULL, attempts = 0 WHERE id = ...
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+  id UUID PRIMARY KEY,
+  aggregate_type VARCHAR(50) NOT NULL,
+  aggregate_id VARCHAR(255) NOT NULL,
+  event_type VARCHAR(100) NOT NULL,
+  schema_version INTEGER NOT NULL DEFAULT 1,
+  payload BYTEA NOT NULL, -- user_service.events.v1.Envelope (protobuf)
+
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  claimed_until TIMESTAMP WITH TIME ZONE,
+  parked_at TIMESTAMP WITH TIME ZONE,
+  published_at TIMESTAMP WITH TIME ZONE,
+
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events(created_at) WHERE published_at IS NULL AND parked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_parked ON outbox_events(parked_at) WHERE parked_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id);
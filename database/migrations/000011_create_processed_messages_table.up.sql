@@ -0,0 +1,10 @@
+-- processed_messages: идентификаторы уже обработанных сообщений брокера (дедупликация consumer)
+
+CREATE TABLE IF NOT EXISTS processed_messages (
+  message_id VARCHAR(255) NOT NULL,
+  consumer VARCHAR(100) NOT NULL,
+  processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (consumer, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_messages_processed_at ON processed_messages(processed_at);
@@ -0,0 +1,6 @@
+-- email_verified_at: момент подтверждения email по ссылке из письма; NULL — не подтверждён.
+-- Существующие пользователи считаются подтверждёнными, чтобы политика EMAIL_VERIFICATION их не ограничила.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET email_verified_at = COALESCE(created_at, CURRENT_TIMESTAMP) WHERE email_verified_at IS NULL;
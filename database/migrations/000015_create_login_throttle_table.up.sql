@@ -0,0 +1,11 @@
+-- login_throttle: неудачные попытки входа по учётной записи (account:<email>) и по адресу клиента (ip:<addr>),
+-- временная блокировка с экспоненциальным ростом
+
+CREATE TABLE IF NOT EXISTS login_throttle (
+  throttle_key VARCHAR(320) PRIMARY KEY,
+  failures INT NOT NULL DEFAULT 0,
+  last_failure_at TIMESTAMP WITH TIME ZONE,
+  locked_until TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_throttle_last_failure_at ON login_throttle(last_failure_at);
@@ -0,0 +1,6 @@
+-- users.status: код использует active/inactive/blocked (constants.UserStatus*), исходный CHECK допускал banned
+-- вместо blocked — блокировка пользователя (users block, UpdateUser status=blocked) отклонялась БД
+
+UPDATE users SET status = 'blocked' WHERE status = 'banned';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'inactive', 'blocked'));
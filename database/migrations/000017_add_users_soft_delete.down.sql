@@ -0,0 +1,18 @@
+-- Удалённые пользователи сохраняются вместе с сессиями, устройствами и сервисами: без deleted_at они
+-- снова видимы, поэтому деактивируются, а email/username получают суффикс с id — иначе они могут совпасть
+-- с новыми учётными записями и UNIQUE не восстановится.
+UPDATE users
+SET email = LEFT(email, 210) || '.deleted-' || id,
+    username = LEFT(username, 55) || '-deleted-' || id,
+    status = 'inactive',
+    is_active = false
+WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_username_active;
+DROP INDEX IF EXISTS idx_users_email_active;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP COLUMN IF EXISTS purged_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Мягкое удаление пользователей: deleted_at скрывает пользователя из всех запросов, история сессий,
+-- устройств и сервисов сохраняется. purged_at — персональные данные обезличены (PurgeUser, очистка по сроку).
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS purged_at TIMESTAMP WITH TIME ZONE;
+
+-- email и username уникальны среди неудалённых: адрес удалённого пользователя можно зарегистрировать снова
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_active ON users(username) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL AND purged_at IS NULL;
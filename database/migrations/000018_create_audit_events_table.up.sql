@@ -0,0 +1,41 @@
+-- audit_events: журнал административных и чувствительных к безопасности действий.
+-- Только добавление: UPDATE и DELETE запрещены триггером. Без внешних ключей — записи переживают
+-- очистку пользователя (PurgeUser); персональные данные в changes не пишутся.
+-- source и reason — источник (регистрация, админ, bootstrap, CLI) и причина назначения роли у записей
+-- user.create и user.role_change: audit_events — единственный журнал ролей.
+
+CREATE TABLE IF NOT EXISTS audit_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  action VARCHAR(64) NOT NULL,
+  actor_id UUID,
+  target_type VARCHAR(32) NOT NULL,
+  target_id UUID,
+  changes JSONB,
+  client_ip VARCHAR(64),
+  user_agent VARCHAR(512),
+  source VARCHAR(20),
+  reason TEXT,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target_id ON audit_events(target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action, created_at);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+  BEFORE UPDATE OR DELETE ON audit_events
+  FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+-- TRUNCATE обходит строковые триггеры
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate
+  BEFORE TRUNCATE ON audit_events
+  FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,4 @@
+-- Версия строки пользователя для оптимистичной блокировки: каждое изменение увеличивает version,
+-- запись по прочитанной копии проходит только при совпадении версии. Наружу отдаётся как ETag.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
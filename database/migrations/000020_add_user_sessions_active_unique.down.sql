@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_user_sessions_active_external;
@@ -0,0 +1,17 @@
+-- Не больше одного активного участия пользователя в сессии session-manager: повторный вход возвращает
+-- действующее. Дубликаты, появившиеся до ограничения, закрываются — остаётся самое раннее участие.
+
+UPDATE user_sessions s
+SET left_at = NOW(),
+    duration_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - s.joined_at))::INTEGER)
+WHERE s.left_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM user_sessions o
+    WHERE o.user_id = s.user_id
+      AND o.session_external_id = s.session_external_id
+      AND o.left_at IS NULL
+      AND (o.joined_at, o.id) < (s.joined_at, s.id)
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_sessions_active_external
+  ON user_sessions(user_id, session_external_id) WHERE left_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_devices_connection_id_active;
+CREATE INDEX IF NOT EXISTS idx_user_devices_connection_id ON user_devices(connection_id);
@@ -0,0 +1,15 @@
+-- connection_id однозначно указывает на устройство: DisconnectDevice и DeviceHeartbeat ищут по нему.
+-- Дубликаты, оставшиеся от переподключений, отключаются — соединение остаётся у последнего обновлённого устройства.
+
+UPDATE user_devices d
+SET connection_id = '', is_connected = FALSE
+WHERE d.connection_id <> ''
+  AND EXISTS (
+    SELECT 1 FROM user_devices o
+    WHERE o.connection_id = d.connection_id
+      AND (o.updated_at, o.id) > (d.updated_at, d.id)
+  );
+
+DROP INDEX IF EXISTS idx_user_devices_connection_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_devices_connection_id_active
+  ON user_devices(connection_id) WHERE connection_id <> '';
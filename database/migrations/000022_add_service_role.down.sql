@@ -0,0 +1,4 @@
+UPDATE users SET role = 'client' WHERE role = 'service';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('client', 'operator', 'admin'));
@@ -0,0 +1,4 @@
+-- Роль service: учётные записи сервисов (session-manager, WS-шлюз) с единственным разрешением session:manage.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('client', 'operator', 'admin', 'service'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_session_tombstones_created_at;
+DROP TABLE IF EXISTS session_tombstones;
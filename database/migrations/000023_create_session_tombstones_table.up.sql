@@ -0,0 +1,12 @@
+-- session_tombstones: последний выход участника (user_id) или закрытие всей сессии (user_id = '') по событиям
+-- session-manager. participant.joined, произошедший не позже выхода, пришёл с опозданием и не открывает участие.
+
+CREATE TABLE IF NOT EXISTS session_tombstones (
+  session_external_id VARCHAR(255) NOT NULL,
+  user_id VARCHAR(36) NOT NULL DEFAULT '',
+  left_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (session_external_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_tombstones_created_at ON session_tombstones(created_at);
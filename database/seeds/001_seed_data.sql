@@ -1,8 +1,8 @@
 -- Миграция 005: Начальные данные (из haqury/user-service db/seeds/001_seed_data.sql)
 
 -- Тестовый пользователь (если не существует)
-INSERT INTO users (username, email, phone, password_hash)
-SELECT 'testuser', 'test@example.com', '+1234567890', crypt('password123', gen_salt('bf', 10))
+INSERT INTO users (username, email, phone, password_hash, email_verified_at)
+SELECT 'testuser', 'test@example.com', '+1234567890', crypt('password123', gen_salt('bf', 10)), CURRENT_TIMESTAMP
 WHERE NOT EXISTS (SELECT 1 FROM users WHERE username = 'testuser');
 
 -- Получаем ID и добавляем сервисы
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/psds-microservice/helpy/db"
+	"github.com/psds-microservice/user-service/internal/config"
+	"github.com/psds-microservice/user-service/internal/service"
+	"github.com/psds-microservice/user-service/internal/validator"
+)
+
+// Admin — сервисы для команд администрирования (user-service users ...): те же проверки,
+// хеширование паролей, журнал ролей и события outbox, что и у API.
+type Admin struct {
+	User     service.UserService
+	Operator service.OperatorService
+	Token    service.TokenService
+	Validate *validator.Validator
+}
+
+// NewAdmin готовит схему (prepareSchema) и создаёт сервисы для CLI. Отзыв токенов пишет в то же хранилище
+// (REVOCATION_STORE), что и api; при REVOCATION_STORE=memory отзываются только refresh-токены.
+func NewAdmin(cfg *config.Config) (*Admin, error) {
+	if err := prepareSchema(cfg); err != nil {
+		return nil, err
+	}
+	conn, err := db.Open(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	jwtCfg, err := newJWTConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	blacklist, _, err := newBlacklist(cfg, conn)
+	if err != nil {
+		return nil, fmt.Errorf("revocation store: %w", err)
+	}
+	return &Admin{
+		User:     service.NewUserService(conn),
+		Operator: service.NewOperatorService(conn),
+		Token:    newTokenService(cfg, conn, jwtCfg, blacklist),
+		Validate: validator.New(),
+	}, nil
+}
@@ -6,51 +6,190 @@ import (
 	"log"
 	"net"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/psds-microservice/helpy/db"
 	"github.com/psds-microservice/helpy/paths"
+	apispec "github.com/psds-microservice/user-service/api"
 	"github.com/psds-microservice/user-service/internal/auth"
 	"github.com/psds-microservice/user-service/internal/config"
 	"github.com/psds-microservice/user-service/internal/database"
 	grpcserver "github.com/psds-microservice/user-service/internal/grpc"
 	"github.com/psds-microservice/user-service/internal/handler"
+	"github.com/psds-microservice/user-service/internal/middleware"
+	"github.com/psds-microservice/user-service/internal/notify"
+	"github.com/psds-microservice/user-service/internal/outbox"
 	"github.com/psds-microservice/user-service/internal/service"
 	"github.com/psds-microservice/user-service/internal/validator"
 	"github.com/psds-microservice/user-service/pkg/gen/user_service"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
 )
 
-// serveOpenAPISpec отдаёт api/openapi.json или api/openapi.swagger.json (из proto: make proto-openapi).
+// serveOpenAPISpec отдаёт встроенную в бинарник спецификацию (api/openapi.swagger.json, make proto-openapi).
 func serveOpenAPISpec() http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
-		// Сначала ищем сгенерированный openapi.swagger.json, затем openapi.json
-		for _, path := range []string{"api/openapi.swagger.json", "api/openapi.json", "openapi.json"} {
-			data, err := os.ReadFile(path)
-			if err == nil {
-				w.Header().Set("Content-Type", "application/json")
-				w.Write(data)
-				return
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(apispec.OpenAPISpec)
+	}
+}
+
+// loopbackAddr — адрес gRPC-сервера для grpc-gateway внутри процесса (0.0.0.0 и пустой хост — 127.0.0.1).
+func loopbackAddr(host, port string) string {
+	if host == "" || host == "0.0.0.0" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// gatewayIPs — адреса, с которых gateway приходит на gRPC при APP_HOST вне loopback: соединение
+// с локальным адресом хоста открывается с него же.
+func gatewayIPs(host string) []net.IP {
+	if host == "" || host == "0.0.0.0" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		log.Printf("resolve APP_HOST %s: %v", host, err)
+		return nil
+	}
+	return ips
+}
+
+// gatewayErrorHandler дополняет стандартный ответ grpc-gateway заголовком Retry-After,
+// если статус содержит RetryInfo (блокировка входа после серии неудач).
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if st, ok := status.FromError(err); ok {
+		for _, d := range st.Details() {
+			if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+				w.Header().Set("Retry-After", strconv.FormatInt(info.GetRetryDelay().GetSeconds(), 10))
 			}
 		}
-		exe, _ := os.Executable()
-		if exe != "" {
-			dir := filepath.Dir(exe)
-			for _, name := range []string{"openapi.swagger.json", "openapi.json"} {
-				data, err := os.ReadFile(filepath.Join(dir, "api", name))
-				if err == nil {
-					w.Header().Set("Content-Type", "application/json")
-					w.Write(data)
-					return
-				}
-			}
+	}
+	if status.Code(err) == codes.Aborted && r.Header.Get("If-Match") != "" {
+		// Конфликт версий по If-Match — 412 Precondition Failed (RFC 9110), без заголовка — 409.
+		w = preconditionFailedWriter{w}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// preconditionFailedWriter отвечает 412 вместо кода, выбранного grpc-gateway.
+type preconditionFailedWriter struct {
+	http.ResponseWriter
+}
+
+func (w preconditionFailedWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(http.StatusPreconditionFailed)
+}
+
+// gatewayETag выставляет заголовок ETag для ответов с пользователем (версия записи для If-Match).
+func gatewayETag(_ context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if u, ok := resp.(*user_service.UserResponse); ok && u.GetEtag() != "" {
+		w.Header().Set("ETag", u.GetEtag())
+	}
+	return nil
+}
+
+// prepareSchema применяет миграции при старте (AUTO_MIGRATE). Без автомиграции проверяет состояние:
+// dirty-схема — ошибка, неприменённые миграции — предупреждение (их применяет отдельный migrate up).
+func prepareSchema(cfg *config.Config) error {
+	if cfg.AutoMigrate {
+		if err := database.MigrateUp(cfg.DatabaseURL()); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		return nil
+	}
+	st, err := database.GetMigrationStatus(cfg.DatabaseURL())
+	if err != nil {
+		return fmt.Errorf("migrate status: %w", err)
+	}
+	if st.Dirty {
+		return fmt.Errorf("migrate: database is dirty at version %d, run `migrate force` after fixing the schema", st.Version)
+	}
+	if st.Pending > 0 {
+		log.Printf("migrate: AUTO_MIGRATE=false, schema at version %d, %d pending migration(s) up to %d", st.Version, st.Pending, st.Latest)
+	}
+	return nil
+}
+
+// newJWTConfig — параметры JWT из конфига; с JWT_KEYS_DIR подпись асимметричными ключами.
+func newJWTConfig(cfg *config.Config) (auth.Config, error) {
+	jwtCfg, err := auth.NewConfig(cfg.JWTSecret, cfg.JWTAccess, cfg.JWTRefresh)
+	if err != nil {
+		log.Printf("jwt config: %v, using defaults", err)
+	}
+	if cfg.JWTKeysDir != "" {
+		keys, err := auth.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKID)
+		if err != nil {
+			return jwtCfg, fmt.Errorf("jwt keys: %w", err)
+		}
+		jwtCfg = jwtCfg.WithKeys(keys, cfg.JWTAcceptHS256)
+		log.Printf("jwt: signing with %s (kid %s), HS256 accepted: %v", keys.Active.Method.Alg(), keys.Active.ID, cfg.JWTAcceptHS256)
+	}
+	return jwtCfg, nil
+}
+
+func newTokenService(cfg *config.Config, conn *gorm.DB, jwtCfg auth.Config, blacklist auth.Blacklist) service.TokenService {
+	return service.NewTokenService(conn, jwtCfg, blacklist, service.AccessPolicy{
+		EmailVerification:    cfg.EmailVerification,
+		MFARequiredForAdmins: cfg.MFARequiredForAdmins,
+	})
+}
+
+// newBlacklist создаёт хранилище отозванных токенов по REVOCATION_STORE с LRU перед ним.
+// Для postgres возвращает и само хранилище — его периодическую очистку запускает Run.
+func newBlacklist(cfg *config.Config, conn *gorm.DB) (auth.Blacklist, *auth.PostgresBlacklist, error) {
+	cacheTTL, err := time.ParseDuration(cfg.RevocationCacheTTL)
+	if err != nil {
+		cacheTTL = 5 * time.Second
+	}
+	switch cfg.RevocationStore {
+	case "memory":
+		return auth.NewMemoryBlacklist(), nil, nil
+	case "redis":
+		rb, err := auth.NewRedisBlacklist(cfg.RedisURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := rb.Ping(ctx); err != nil {
+			return nil, nil, fmt.Errorf("redis: %w", err)
+		}
+		return auth.NewCachedBlacklist(rb, cfg.RevocationCacheSize, cacheTTL), nil, nil
+	default:
+		pb := auth.NewPostgresBlacklist(conn)
+		return auth.NewCachedBlacklist(pb, cfg.RevocationCacheSize, cacheTTL), pb, nil
+	}
+}
+
+// loginLimits — параметры защиты Login от перебора из LOGIN_*.
+func loginLimits(cfg *config.Config) service.LoginLimits {
+	parse := func(v string, def time.Duration) time.Duration {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return def
 		}
-		http.Error(w, "openapi.json not found. Run: make proto-openapi", http.StatusNotFound)
+		return d
+	}
+	return service.LoginLimits{
+		MaxFailures:   cfg.LoginMaxFailures,
+		IPMaxFailures: cfg.LoginIPMaxFailures,
+		BaseLockout:   parse(cfg.LoginLockoutBase, 30*time.Second),
+		MaxLockout:    parse(cfg.LoginLockoutMax, 15*time.Minute),
+		Window:        parse(cfg.LoginFailureWindow, 15*time.Minute),
 	}
 }
 
@@ -60,12 +199,17 @@ type API struct {
 	httpSrv *http.Server
 	grpcSrv *grpc.Server
 	lis     net.Listener
+	purger  *auth.PostgresBlacklist // nil, если отозванные токены хранятся не в Postgres
+	relay   *outbox.Relay           // nil без RABBITMQ_URL: события копятся в outbox_events
+	pub     *outbox.AMQPPublisher
+	users   service.UserService // очистка удалённых пользователей по USER_PURGE_AFTER
+	auth    service.AuthService // очистка устаревших счётчиков login_throttle
 }
 
 // NewAPI создаёт приложение для режима api.
 func NewAPI(cfg *config.Config) (*API, error) {
-	if err := database.MigrateUp(cfg.DatabaseURL()); err != nil {
-		return nil, fmt.Errorf("migrate: %w", err)
+	if err := prepareSchema(cfg); err != nil {
+		return nil, err
 	}
 	conn, err := db.Open(cfg.DSN())
 	if err != nil {
@@ -73,45 +217,90 @@ func NewAPI(cfg *config.Config) (*API, error) {
 	}
 
 	userSvc := service.NewUserService(conn)
-	authSvc := service.NewAuthService(conn)
+	authSvc := service.NewAuthService(conn, loginLimits(cfg))
 	operatorSvc := service.NewOperatorService(conn)
 	presenceSvc := service.NewPresenceService(conn)
 	sessionSvc := service.NewSessionService(conn)
+	deviceSvc := service.NewDeviceService(conn)
+	routeSvc := service.NewRouteService(conn)
 	val := validator.New()
 
-	jwtCfg, err := auth.NewConfig(cfg.JWTSecret, cfg.JWTAccess, cfg.JWTRefresh)
+	jwtCfg, err := newJWTConfig(cfg)
 	if err != nil {
-		log.Printf("jwt config: %v, using defaults", err)
+		return nil, err
+	}
+	blacklist, purger, err := newBlacklist(cfg, conn)
+	if err != nil {
+		return nil, fmt.Errorf("revocation store: %w", err)
+	}
+	tokenSvc := newTokenService(cfg, conn, jwtCfg, blacklist)
+	notifier, err := notify.New(cfg.Notifier, cfg.NotifierFile)
+	if err != nil {
+		return nil, err
+	}
+	resetTTL, err := time.ParseDuration(cfg.PasswordResetTTL)
+	if err != nil {
+		resetTTL = time.Hour
+	}
+	resetSvc := service.NewPasswordResetService(conn, tokenSvc, notifier, resetTTL, cfg.PasswordResetLink)
+	verifyTTL, err := time.ParseDuration(cfg.EmailVerificationTTL)
+	if err != nil {
+		verifyTTL = 24 * time.Hour
+	}
+	verifyCooldown, err := time.ParseDuration(cfg.EmailVerificationCooldown)
+	if err != nil {
+		verifyCooldown = time.Minute
 	}
-	blacklist := auth.NewBlacklist()
+	verifySvc := service.NewEmailVerificationService(conn, notifier, verifyTTL, verifyCooldown, cfg.EmailVerificationLink)
+	mfaSvc := service.NewMFAService(conn, jwtCfg, loginLimits(cfg), cfg.MFAIssuer, cfg.MFARequiredForAdmins)
 
 	grpcAddr := cfg.AppHost + ":" + cfg.GRPCPort
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		return nil, fmt.Errorf("grpc listen %s: %w (порт занят — остановите другой процесс или задайте GRPC_PORT в .env)", grpcAddr, err)
 	}
-	grpcSrv := grpc.NewServer()
 	gwImpl := grpcserver.NewServer(grpcserver.Deps{
 		User:      userSvc,
 		Auth:      authSvc,
 		Operator:  operatorSvc,
 		Presence:  presenceSvc,
 		Session:   sessionSvc,
+		Device:    deviceSvc,
+		Route:     routeSvc,
+		Token:     tokenSvc,
 		JWTConfig: jwtCfg,
 		Blacklist: blacklist,
 		Validate:  val,
+
+		PasswordReset:     resetSvc,
+		EmailVerification: verifySvc,
+		MFA:               mfaSvc,
+		Audit:             service.NewAuditService(conn),
+
+		TrustedProxyHops: cfg.TrustedProxyHops,
+		GatewayIPs:       gatewayIPs(cfg.AppHost),
 	})
+	grpcSrv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.UnaryAuth(jwtCfg, blacklist, grpcserver.Policy()),
+		gwImpl.UnaryAuditActor(),
+	))
 	user_service.RegisterUserServiceServer(grpcSrv, gwImpl)
 	reflection.Register(grpcSrv)
 
-	gatewayMux := runtime.NewServeMux()
-	if err := user_service.RegisterUserServiceHandlerServer(context.Background(), gatewayMux, gwImpl); err != nil {
+	// Gateway ходит в gRPC через loopback, чтобы HTTP-запросы проходили те же интерсепторы (авторизация).
+	gatewayMux := runtime.NewServeMux(
+		runtime.WithErrorHandler(gatewayErrorHandler),
+		runtime.WithForwardResponseOption(gatewayETag),
+	)
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := user_service.RegisterUserServiceHandlerFromEndpoint(context.Background(), gatewayMux, loopbackAddr(cfg.AppHost, cfg.GRPCPort), dialOpts); err != nil {
 		return nil, fmt.Errorf("register grpc-gateway: %w", err)
 	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc(paths.PathHealth, handler.Health)
 	mux.HandleFunc(paths.PathReady, handler.Ready)
+	mux.HandleFunc(handler.PathJWKS, handler.JWKS(jwtCfg.Keys))
 	mux.HandleFunc(paths.PathSwagger+"/openapi.json", serveOpenAPISpec())
 	mux.Handle(paths.PathSwagger+"/", httpSwagger.Handler(
 		httpSwagger.URL("openapi.json"),
@@ -130,11 +319,29 @@ func NewAPI(cfg *config.Config) (*API, error) {
 		IdleTimeout:       60 * time.Second,
 	}
 
+	var relay *outbox.Relay
+	var pub *outbox.AMQPPublisher
+	if cfg.RabbitMQURL != "" {
+		interval, err := time.ParseDuration(cfg.OutboxPollInterval)
+		if err != nil {
+			interval = time.Second
+		}
+		pub = outbox.NewAMQPPublisher(cfg.RabbitMQURL, cfg.EventsExchange)
+		relay = outbox.NewRelay(conn, pub, cfg.OutboxBatchSize, cfg.OutboxMaxAttempts, interval)
+	} else {
+		log.Printf("outbox: RABBITMQ_URL is not set, events stay in outbox_events until relay is enabled")
+	}
+
 	return &API{
 		cfg:     cfg,
 		httpSrv: httpSrv,
 		grpcSrv: grpcSrv,
 		lis:     lis,
+		purger:  purger,
+		relay:   relay,
+		pub:     pub,
+		users:   userSvc,
+		auth:    authSvc,
 	}, nil
 }
 
@@ -153,6 +360,7 @@ func (a *API) Run(ctx context.Context) error {
 	log.Printf("  Swagger spec:  %s/swagger/openapi.json", httpBase)
 	log.Printf("  Health:        %s/health", httpBase)
 	log.Printf("  Ready:         %s/ready", httpBase)
+	log.Printf("  JWKS:          %s/.well-known/jwks.json", httpBase)
 	log.Printf("  API v1:        %s/api/v1/", httpBase)
 	log.Printf("gRPC server listening on %s", grpcAddr)
 	log.Printf("  gRPC endpoint: %s (reflection enabled)", grpcAddr)
@@ -168,6 +376,28 @@ func (a *API) Run(ctx context.Context) error {
 		}
 	}()
 
+	if a.purger != nil {
+		interval, err := time.ParseDuration(a.cfg.RevocationPurgeInterval)
+		if err != nil || interval <= 0 {
+			interval = 10 * time.Minute
+		}
+		go a.purger.RunPurge(ctx, interval)
+	}
+
+	if retention, err := time.ParseDuration(a.cfg.UserPurgeAfter); err == nil && retention > 0 {
+		interval, err := time.ParseDuration(a.cfg.UserPurgeInterval)
+		if err != nil || interval <= 0 {
+			interval = time.Hour
+		}
+		go service.RunPurgeDeleted(ctx, a.users, retention, interval)
+	}
+	// Счётчик старше окна всё равно сбрасывается при следующей неудаче; очистка раз в окно.
+	go service.RunPurgeThrottle(ctx, a.auth, loginLimits(a.cfg).Window)
+
+	if a.relay != nil {
+		go a.relay.Run(ctx)
+	}
+
 	<-ctx.Done()
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -175,5 +405,8 @@ func (a *API) Run(ctx context.Context) error {
 		log.Printf("http shutdown: %v", err)
 	}
 	a.grpcSrv.GracefulStop()
+	if a.pub != nil {
+		a.pub.Close()
+	}
 	return nil
 }
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/psds-microservice/helpy/db"
+	"github.com/psds-microservice/user-service/internal/config"
+	"github.com/psds-microservice/user-service/internal/consumer"
+	"github.com/psds-microservice/user-service/internal/service"
+)
+
+// Consume приложение: consumer событий session-manager (режим consume), отдельно от api.
+type Consume struct {
+	consumer  *consumer.Consumer
+	events    service.SessionEventService
+	retention time.Duration // 0 — processed_messages не очищается
+}
+
+// NewConsume создаёт приложение для режима consume.
+func NewConsume(cfg *config.Config) (*Consume, error) {
+	if cfg.RabbitMQURL == "" {
+		return nil, errors.New("RABBITMQ_URL is required for consume")
+	}
+	if err := prepareSchema(cfg); err != nil {
+		return nil, err
+	}
+	conn, err := db.Open(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	events := service.NewSessionEventService(conn)
+	retryDelay, err := time.ParseDuration(cfg.ConsumerRetryDelay)
+	if err != nil || retryDelay <= 0 {
+		retryDelay = time.Second
+	}
+	c := consumer.New(consumer.Config{
+		URL:        cfg.RabbitMQURL,
+		Exchange:   cfg.SessionEventsExchange,
+		Queue:      cfg.SessionEventsQueue,
+		Prefetch:   cfg.ConsumerPrefetch,
+		RetryDelay: retryDelay,
+	}, events)
+	retention, err := time.ParseDuration(cfg.ProcessedRetention)
+	if err != nil || retention < 0 {
+		retention = 0
+	}
+	return &Consume{consumer: c, events: events, retention: retention}, nil
+}
+
+// Run обрабатывает сообщения до отмены ctx.
+func (a *Consume) Run(ctx context.Context) error {
+	if a.retention > 0 {
+		go service.RunPurgeProcessed(ctx, a.events, a.retention, time.Hour)
+	}
+	return a.consumer.Run(ctx)
+}
@@ -0,0 +1,127 @@
+// Package audit — журнал аудита (audit_events): кто, когда, откуда и над чем выполнил
+// административное или чувствительное к безопасности действие.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// Действия журнала (audit_events.action).
+const (
+	ActionUserCreate     = "user.create"
+	ActionUserUpdate     = "user.update"
+	ActionUserStatus     = "user.status_change"
+	ActionUserPassword   = "user.password_change"
+	ActionUserRole       = "user.role_change"
+	ActionUserDelete     = "user.delete"
+	ActionUserRestore    = "user.restore"
+	ActionUserPurge      = "user.purge"
+	ActionEmailVerify    = "user.email_verify"
+	ActionOperatorVerify = "operator.verify"
+	ActionLogin          = "auth.login"
+	ActionLoginFailed    = "auth.login_failed"
+	ActionPasswordReset  = "auth.password_reset"
+	ActionMFAEnable      = "auth.mfa_enable"
+	ActionMFADisable     = "auth.mfa_disable"
+)
+
+// TargetUser — тип объекта действия (audit_events.target_type).
+const TargetUser = "user"
+
+// maxUserAgent — длина audit_events.user_agent.
+const maxUserAgent = 512
+
+// Actor — инициатор запроса: пользователь из JWT, адрес клиента и User-Agent.
+type Actor struct {
+	UserID    string
+	ClientIP  string
+	UserAgent string
+}
+
+type actorKey struct{}
+
+// WithActor кладёт инициатора в контекст; его читает Record.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFromContext возвращает инициатора из контекста (пустой — вызов вне gRPC и CLI).
+func ActorFromContext(ctx context.Context) Actor {
+	a, _ := ctx.Value(actorKey{}).(Actor)
+	return a
+}
+
+// Change — старое и новое значение поля. Redacted — значение не пишется в журнал
+// (персональные данные и секреты), фиксируется только факт изменения.
+type Change struct {
+	Old      interface{} `json:"old,omitempty"`
+	New      interface{} `json:"new,omitempty"`
+	Redacted bool        `json:"redacted,omitempty"`
+}
+
+// Changes — изменённые поля объекта по имени колонки.
+type Changes map[string]Change
+
+// Entry — действие для записи в журнал. ActorID переопределяет инициатора из контекста
+// (вход: инициатор — сам пользователь). Source и Reason — источник и причина назначения роли
+// (constants.RoleSource*) для user.create и user.role_change.
+type Entry struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	ActorID    string
+	Changes    Changes
+	Source     string
+	Reason     string
+}
+
+// Record пишет запись в audit_events. tx — транзакция действия: запись появляется тогда и только тогда,
+// когда действие закоммичено.
+func Record(ctx context.Context, tx *gorm.DB, e Entry) error {
+	actor := ActorFromContext(ctx)
+	if e.ActorID == "" {
+		e.ActorID = actor.UserID
+	}
+	if e.TargetType == "" {
+		e.TargetType = TargetUser
+	}
+	ev := &model.AuditEvent{
+		ID:         uuid.New().String(),
+		Action:     e.Action,
+		ActorID:    optional(e.ActorID),
+		TargetType: e.TargetType,
+		TargetID:   optional(e.TargetID),
+		ClientIP:   actor.ClientIP,
+		UserAgent:  actor.UserAgent,
+		Source:     e.Source,
+		Reason:     e.Reason,
+		CreatedAt:  time.Now(),
+	}
+	if len(ev.UserAgent) > maxUserAgent {
+		ev.UserAgent = strings.ToValidUTF8(ev.UserAgent[:maxUserAgent], "")
+	}
+	if len(e.Changes) > 0 {
+		raw, err := json.Marshal(e.Changes)
+		if err != nil {
+			return err
+		}
+		ev.Changes = datatypes.JSON(raw)
+	}
+	return tx.Create(ev).Error
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -1,31 +1,154 @@
 package auth
 
 import (
+	"container/list"
+	"context"
 	"sync"
 	"time"
 )
 
-// Blacklist — инвалидированные JWT (logout). In-memory; для production лучше Redis.
-type Blacklist struct {
+// Blacklist — хранилище отозванных JWT (jti до истечения токена). Реализации:
+// MemoryBlacklist (один процесс, тесты), PostgresBlacklist и RedisBlacklist (общие для всех реплик).
+type Blacklist interface {
+	// Add помечает токен как недействительный до expireAt.
+	Add(ctx context.Context, jti string, expireAt time.Time) error
+	// Contains возвращает true если jti отозван и ещё не истёк.
+	Contains(ctx context.Context, jti string) (bool, error)
+}
+
+// FamilyKey — ключ Blacklist для отзыва всех access-токенов семейства (claims.sid) сразу:
+// logout, повторное использование refresh-токена, сброс пароля.
+func FamilyKey(familyID string) string {
+	return "fid:" + familyID
+}
+
+// IsRevoked проверяет по Blacklist сам токен (jti) и его семейство (sid).
+func IsRevoked(ctx context.Context, bl Blacklist, claims *Claims) (bool, error) {
+	if bl == nil {
+		return false, nil
+	}
+	if revoked, err := bl.Contains(ctx, claims.ID); err != nil || revoked {
+		return revoked, err
+	}
+	if claims.SessionID == "" {
+		return false, nil
+	}
+	return bl.Contains(ctx, FamilyKey(claims.SessionID))
+}
+
+// MemoryBlacklist — in-memory Blacklist в пределах процесса; истёкшие записи удаляются при Add.
+type MemoryBlacklist struct {
 	mu   sync.RWMutex
 	byID map[string]time.Time // jti -> expire at
 }
 
-func NewBlacklist() *Blacklist {
-	return &Blacklist{byID: make(map[string]time.Time)}
+func NewMemoryBlacklist() *MemoryBlacklist {
+	return &MemoryBlacklist{byID: make(map[string]time.Time)}
 }
 
-// Add помечает токен как недействительный до expireAt.
-func (b *Blacklist) Add(jti string, expireAt time.Time) {
+func (b *MemoryBlacklist) Add(_ context.Context, jti string, expireAt time.Time) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	now := time.Now()
+	for id, exp := range b.byID {
+		if !now.Before(exp) {
+			delete(b.byID, id)
+		}
+	}
 	b.byID[jti] = expireAt
+	return nil
 }
 
-// Contains возвращает true если jti в чёрном списке и ещё не истёк.
-func (b *Blacklist) Contains(jti string) bool {
+func (b *MemoryBlacklist) Contains(_ context.Context, jti string) (bool, error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 	exp, ok := b.byID[jti]
-	return ok && time.Now().Before(exp)
+	return ok && time.Now().Before(exp), nil
+}
+
+// CachedBlacklist — небольшой LRU перед общим хранилищем, чтобы не ходить в него на каждый вызов.
+// Отзыв необратим, поэтому положительный ответ кэшируется до истечения токена; отрицательный —
+// не дольше negativeTTL: столько токен, отозванный на другой реплике, ещё может приниматься здесь.
+type CachedBlacklist struct {
+	store       Blacklist
+	size        int
+	negativeTTL time.Duration
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	jti      string
+	revoked  bool
+	validTil time.Time
+}
+
+func NewCachedBlacklist(store Blacklist, size int, negativeTTL time.Duration) *CachedBlacklist {
+	if size <= 0 {
+		size = 1024
+	}
+	return &CachedBlacklist{
+		store:       store,
+		size:        size,
+		negativeTTL: negativeTTL,
+		order:       list.New(),
+		items:       make(map[string]*list.Element),
+	}
+}
+
+func (c *CachedBlacklist) Add(ctx context.Context, jti string, expireAt time.Time) error {
+	if err := c.store.Add(ctx, jti, expireAt); err != nil {
+		return err
+	}
+	c.put(jti, true, expireAt)
+	return nil
+}
+
+func (c *CachedBlacklist) Contains(ctx context.Context, jti string) (bool, error) {
+	if revoked, ok := c.get(jti); ok {
+		return revoked, nil
+	}
+	revoked, err := c.store.Contains(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+	// Для отозванного токена точный exp здесь неизвестен: держим запись negativeTTL, как и для отрицательной.
+	c.put(jti, revoked, time.Now().Add(c.negativeTTL))
+	return revoked, nil
+}
+
+func (c *CachedBlacklist) get(jti string) (revoked, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, found := c.items[jti]
+	if !found {
+		return false, false
+	}
+	e := el.Value.(*cacheEntry)
+	if !time.Now().Before(e.validTil) {
+		c.order.Remove(el)
+		delete(c.items, jti)
+		return false, false
+	}
+	c.order.MoveToFront(el)
+	return e.revoked, true
+}
+
+func (c *CachedBlacklist) put(jti string, revoked bool, validTil time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, found := c.items[jti]; found {
+		e := el.Value.(*cacheEntry)
+		e.revoked, e.validTil = revoked, validTil
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[jti] = c.order.PushFront(&cacheEntry{jti: jti, revoked: revoked, validTil: validTil})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).jti)
+	}
 }
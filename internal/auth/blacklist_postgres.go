@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// PostgresBlacklist — Blacklist в таблице revoked_tokens (миграция 000009).
+type PostgresBlacklist struct {
+	db *gorm.DB
+}
+
+func NewPostgresBlacklist(db *gorm.DB) *PostgresBlacklist {
+	return &PostgresBlacklist{db: db}
+}
+
+func (b *PostgresBlacklist) Add(ctx context.Context, jti string, expireAt time.Time) error {
+	return b.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "jti"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+	}).Create(&model.RevokedToken{JTI: jti, ExpiresAt: expireAt}).Error
+}
+
+func (b *PostgresBlacklist) Contains(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := b.db.WithContext(ctx).Model(&model.RevokedToken{}).
+		Where("jti = ? AND expires_at > ?", jti, time.Now()).Count(&count).Error
+	return count > 0, err
+}
+
+// Purge удаляет истёкшие записи и возвращает их число.
+func (b *PostgresBlacklist) Purge(ctx context.Context) (int64, error) {
+	res := b.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&model.RevokedToken{})
+	return res.RowsAffected, res.Error
+}
+
+// RunPurge вызывает Purge каждые interval до отмены ctx.
+func (b *PostgresBlacklist) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := b.Purge(ctx); err != nil {
+				log.Printf("revoked tokens purge: %v", err)
+			} else if n > 0 {
+				log.Printf("revoked tokens purge: removed %d", n)
+			}
+		}
+	}
+}
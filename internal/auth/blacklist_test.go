@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingBlacklist считает обращения к хранилищу за CachedBlacklist.
+type countingBlacklist struct {
+	*MemoryBlacklist
+	calls int
+}
+
+func (c *countingBlacklist) Contains(ctx context.Context, jti string) (bool, error) {
+	c.calls++
+	return c.MemoryBlacklist.Contains(ctx, jti)
+}
+
+func TestCachedBlacklist(t *testing.T) {
+	ctx := context.Background()
+	store := &countingBlacklist{MemoryBlacklist: NewMemoryBlacklist()}
+	cache := NewCachedBlacklist(store, 2, time.Minute)
+
+	if err := cache.Add(ctx, "revoked", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if ok, _ := cache.Contains(ctx, "revoked"); !ok {
+			t.Fatal("revoked token must be reported as revoked")
+		}
+		if ok, _ := cache.Contains(ctx, "active"); ok {
+			t.Fatal("active token must not be reported as revoked")
+		}
+	}
+	if store.calls != 1 {
+		t.Fatalf("expected 1 store lookup, got %d", store.calls)
+	}
+
+	// Третий jti вытесняет самый старый ("revoked"), но в хранилище он по-прежнему отозван.
+	_, _ = cache.Contains(ctx, "other")
+	if ok, _ := cache.Contains(ctx, "revoked"); !ok || store.calls != 3 {
+		t.Fatalf("evicted entry must be re-read from store: revoked=%v calls=%d", ok, store.calls)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/psds-microservice/user-service/pkg/constants"
 )
 
@@ -18,19 +19,50 @@ type Claims struct {
 	OperatorStatus string   `json:"operator_status,omitempty"`
 	IsAvailable    bool     `json:"is_available"`
 	Permissions    []string `json:"permissions"`
+	SessionID      string   `json:"sid,omitempty"`     // family_id refresh-токенов
+	Limited        bool     `json:"limited,omitempty"` // учётная запись не готова (email, обязательная 2FA): без разрешений
+	Type           string   `json:"typ"`               // tokenTypeAccess
 }
 
-// RefreshClaims — только sub (user_id) и exp для refresh токена.
+// Назначение токена (claim typ): ValidateAccess принимает только access — refresh-токен и MFA-челлендж,
+// подписанные тем же ключом и с тем же user_id, как access-токен не проходят.
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+	tokenTypeMFA     = "mfa"
+)
+
+// MFAClaims — токен MFA-челленджа между проверкой пароля и второго фактора (aud "mfa").
+type MFAClaims struct {
+	jwt.RegisteredClaims
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id,omitempty"`
+	Type     string `json:"typ"`
+}
+
+// mfaAudience отличает MFA-челлендж от access-токена: ValidateAccess его не принимает.
+const mfaAudience = "mfa"
+
+// MFAChallengeTTL — время на ввод второго фактора после пароля.
+const MFAChallengeTTL = 5 * time.Minute
+
+// RefreshClaims — user_id, семейство ротации и jti (запись в refresh_tokens) для refresh токена.
 type RefreshClaims struct {
 	jwt.RegisteredClaims
-	UserID string `json:"user_id"`
+	UserID   string `json:"user_id"`
+	FamilyID string `json:"fid"`
+	Type     string `json:"typ"`
 }
 
 // Config для генерации/проверки JWT.
+// Без Keys токены подписываются HS256 (Secret). С Keys подпись — активным ключом (RS256/EdDSA, заголовок kid),
+// а HS256-токены принимаются только при AcceptHS256 (переходный период).
 type Config struct {
-	Secret     []byte
-	AccessTTL  time.Duration
-	RefreshTTL time.Duration
+	Secret      []byte
+	AccessTTL   time.Duration
+	RefreshTTL  time.Duration
+	Keys        *KeySet
+	AcceptHS256 bool
 }
 
 // NewConfig создаёт конфиг из строк (secret, accessTTL, refreshTTL).
@@ -50,15 +82,55 @@ func NewConfig(secret, accessTTL, refreshTTL string) (Config, error) {
 	}, nil
 }
 
-// GeneratePair выдаёт access и refresh токены.
-func (c Config) GeneratePair(userID, email, role, operatorStatus string, isAvailable bool) (access, refresh string, err error) {
+// WithKeys включает асимметричную подпись ключами ks.
+func (c Config) WithKeys(ks *KeySet, acceptHS256 bool) Config {
+	c.Keys = ks
+	c.AcceptHS256 = acceptHS256
+	return c
+}
+
+// validMethods — допустимые alg; "none" и прочие отклоняются до выбора ключа.
+var validMethods = []string{"HS256", "RS256", "EdDSA"}
+
+// sign подписывает claims активным ключом или HS256, если набор ключей не задан.
+func (c Config) sign(claims jwt.Claims) (string, error) {
+	if c.Keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(c.Secret)
+	}
+	key := c.Keys.Active
+	tok := jwt.NewWithClaims(key.Method, claims)
+	tok.Header["kid"] = key.ID
+	return tok.SignedString(key.Private)
+}
+
+// keyFunc выбирает ключ проверки по alg и kid токена.
+func (c Config) keyFunc(t *jwt.Token) (interface{}, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if c.Keys != nil && !c.AcceptHS256 {
+			return nil, errors.New("HS256 tokens are no longer accepted")
+		}
+		return c.Secret, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	key := c.Keys.Key(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if key.Method.Alg() != t.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.Public, nil
+}
+
+// GenerateAccess выдаёт access токен. familyID — семейство refresh-токенов (claim sid), по нему Logout
+// отзывает текущую сессию входа.
+func (c Config) GenerateAccess(userID, email, role, operatorStatus string, isAvailable bool, familyID string) (string, error) {
 	now := time.Now()
 	perms := constants.PermissionsByRole[role]
 	if perms == nil {
 		perms = constants.PermissionsByRole[constants.RoleClient]
 	}
-
-	accessClaims := &Claims{
+	claims := &Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(c.AccessTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -70,63 +142,124 @@ func (c Config) GeneratePair(userID, email, role, operatorStatus string, isAvail
 		OperatorStatus: operatorStatus,
 		IsAvailable:    isAvailable,
 		Permissions:    perms,
+		SessionID:      familyID,
+		Type:           tokenTypeAccess,
 	}
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	access, err = accessToken.SignedString(c.Secret)
-	if err != nil {
-		return "", "", err
+	return c.sign(claims)
+}
+
+// GenerateLimitedAccess выдаёт access токен пользователю, чья учётная запись не готова (не подтверждён
+// email, не настроена обязательная 2FA): claim limited, без разрешений роли. Интерсептор пропускает такой токен только в методы с Rule.AllowLimited.
+func (c Config) GenerateLimitedAccess(userID, email, role, familyID string) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(c.AccessTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        fmt.Sprintf("at-%s-%d", userID, now.UnixNano()),
+		},
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		Permissions: []string{},
+		SessionID:   familyID,
+		Limited:     true,
+		Type:        tokenTypeAccess,
 	}
+	return c.sign(claims)
+}
 
-	refreshClaims := &RefreshClaims{
+// GenerateRefresh выдаёт refresh токен семейства familyID. jti — случайный UUID; в БД хранится только его хеш.
+func (c Config) GenerateRefresh(userID, familyID string) (token, jti string, expiresAt time.Time, err error) {
+	now := time.Now()
+	jti = uuid.New().String()
+	expiresAt = now.Add(c.RefreshTTL)
+	claims := &RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(c.RefreshTTL)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ID:        fmt.Sprintf("rt-%s-%d", userID, now.UnixNano()),
+			ID:        jti,
 		},
-		UserID: userID,
+		UserID:   userID,
+		FamilyID: familyID,
+		Type:     tokenTypeRefresh,
 	}
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refresh, err = refreshToken.SignedString(c.Secret)
+	token, err = c.sign(claims)
 	if err != nil {
-		return "", "", err
+		return "", "", time.Time{}, err
 	}
-	return access, refresh, nil
+	return token, jti, expiresAt, nil
 }
 
-// ValidateAccess проверяет access токен и возвращает claims.
+// ValidateAccess проверяет access токен и возвращает claims. Токен без typ "access" (refresh,
+// MFA-челлендж, выпущенный до появления typ) отклоняется.
 func (c Config) ValidateAccess(tokenString string) (*Claims, error) {
-	tok, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return c.Secret, nil
-	})
+	tok, err := jwt.ParseWithClaims(tokenString, &Claims{}, c.keyFunc, jwt.WithValidMethods(validMethods))
 	if err != nil {
 		return nil, err
 	}
 	claims, ok := tok.Claims.(*Claims)
-	if !ok || !tok.Valid {
+	if !ok || !tok.Valid || claims.Type != tokenTypeAccess {
 		return nil, errors.New("invalid token")
 	}
+	for _, aud := range claims.Audience {
+		if aud == mfaAudience {
+			return nil, errors.New("invalid token")
+		}
+	}
 	return claims, nil
 }
 
-// ValidateRefresh проверяет refresh токен и возвращает userID.
-func (c Config) ValidateRefresh(tokenString string) (userID string, err error) {
-	tok, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return c.Secret, nil
-	})
+// ValidateRefresh проверяет подпись и срок refresh токена. Отзыв и ротацию проверяет service.TokenService.
+// Пустой typ допускается для refresh-токенов, выпущенных до его появления (их отличает fid).
+func (c Config) ValidateRefresh(tokenString string) (*RefreshClaims, error) {
+	tok, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, c.keyFunc, jwt.WithValidMethods(validMethods))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	claims, ok := tok.Claims.(*RefreshClaims)
-	if !ok || !tok.Valid {
-		return "", errors.New("invalid refresh token")
+	if !ok || !tok.Valid || claims.ID == "" || claims.FamilyID == "" || (claims.Type != "" && claims.Type != tokenTypeRefresh) {
+		return nil, errors.New("invalid refresh token")
+	}
+	return claims, nil
+}
+
+// GenerateMFAChallenge выдаёт токен MFA-челленджа на MFAChallengeTTL. jti одноразовый:
+// вызывающий сохраняет его и гасит после проверки второго фактора.
+func (c Config) GenerateMFAChallenge(userID, deviceID string) (token, jti string, expiresAt time.Time, err error) {
+	now := time.Now()
+	jti = uuid.New().String()
+	expiresAt = now.Add(MFAChallengeTTL)
+	claims := &MFAClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{mfaAudience},
+		},
+		UserID:   userID,
+		DeviceID: deviceID,
+		Type:     tokenTypeMFA,
+	}
+	token, err = c.sign(claims)
+	if err != nil {
+		return "", "", time.Time{}, err
 	}
-	return claims.UserID, nil
+	return token, jti, expiresAt, nil
+}
+
+// ValidateMFAChallenge проверяет подпись, срок и aud токена MFA-челленджа.
+func (c Config) ValidateMFAChallenge(tokenString string) (*MFAClaims, error) {
+	tok, err := jwt.ParseWithClaims(tokenString, &MFAClaims{}, c.keyFunc,
+		jwt.WithValidMethods(validMethods), jwt.WithAudience(mfaAudience))
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := tok.Claims.(*MFAClaims)
+	if !ok || !tok.Valid || claims.ID == "" || claims.UserID == "" || claims.Type != tokenTypeMFA {
+		return nil, errors.New("invalid mfa token")
+	}
+	return claims, nil
 }
 
 // HasPermission проверяет наличие разрешения в claims.
@@ -0,0 +1,41 @@
+package auth
+
+import "testing"
+
+func TestValidateAccess_RejectsOtherTokenTypes(t *testing.T) {
+	cfg, _ := NewConfig("test-secret", "15m", "24h")
+
+	access, err := cfg.GenerateAccess("u1", "u1@example.com", "client", "", false, "family")
+	if err != nil {
+		t.Fatalf("GenerateAccess: %v", err)
+	}
+	if _, err := cfg.ValidateAccess(access); err != nil {
+		t.Fatalf("ValidateAccess(access): %v", err)
+	}
+	if _, err := cfg.ValidateRefresh(access); err == nil {
+		t.Fatal("access token accepted as refresh token")
+	}
+
+	// Refresh-токен содержит user_id: без проверки typ он разбирался как access без sid и limited.
+	refresh, _, _, err := cfg.GenerateRefresh("u1", "family")
+	if err != nil {
+		t.Fatalf("GenerateRefresh: %v", err)
+	}
+	if _, err := cfg.ValidateAccess(refresh); err == nil {
+		t.Fatal("refresh token accepted as access token")
+	}
+	if _, err := cfg.ValidateRefresh(refresh); err != nil {
+		t.Fatalf("ValidateRefresh(refresh): %v", err)
+	}
+
+	challenge, _, _, err := cfg.GenerateMFAChallenge("u1", "")
+	if err != nil {
+		t.Fatalf("GenerateMFAChallenge: %v", err)
+	}
+	if _, err := cfg.ValidateAccess(challenge); err == nil {
+		t.Fatal("mfa challenge accepted as access token")
+	}
+	if _, err := cfg.ValidateMFAChallenge(access); err == nil {
+		t.Fatal("access token accepted as mfa challenge")
+	}
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey — асимметричный ключ JWT с идентификатором kid. Private == nil — ключ только для проверки
+// (выведенный из ротации, но токены им ещё могут быть подписаны).
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// KeySet — ключи из каталога PEM: активный для подписи и все известные для проверки.
+type KeySet struct {
+	Active *SigningKey
+	byID   map[string]*SigningKey
+}
+
+// LoadKeySet читает *.pem из dir; kid — имя файла без расширения. Поддерживаются приватные ключи
+// RSA (PKCS#1/PKCS#8) и Ed25519 (PKCS#8), а также публичные ключи (PKIX) для проверки.
+// activeKID пустой — активным становится приватный ключ с наибольшим kid (удобно при именах-датах).
+func LoadKeySet(dir, activeKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	ks := &KeySet{byID: make(map[string]*SigningKey)}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		kid := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
+		key, err := parsePEMKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(p), err)
+		}
+		ks.byID[kid] = key
+		if key.Private != nil && activeKID == "" {
+			ks.Active = key
+		}
+	}
+	if activeKID != "" {
+		ks.Active = ks.byID[activeKID]
+	}
+	if ks.Active == nil || ks.Active.Private == nil {
+		return nil, fmt.Errorf("no private signing key in %s (active kid %q)", dir, activeKID)
+	}
+	return ks, nil
+}
+
+// Key возвращает ключ по kid или nil.
+func (ks *KeySet) Key(kid string) *SigningKey {
+	if ks == nil {
+		return nil
+	}
+	return ks.byID[kid]
+}
+
+func parsePEMKey(kid string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(kid, k)
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(kid, k)
+	case "PUBLIC KEY":
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(kid, k)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func newSigningKey(kid string, k interface{}) (*SigningKey, error) {
+	switch k := k.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (RSA or Ed25519 expected)", k)
+	}
+}
+
+// JWK — публичный ключ в формате RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS — ответ /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные части всех ключей набора (и активного, и оставленных для проверки).
+func (ks *KeySet) JWKS() JWKS {
+	out := JWKS{Keys: []JWK{}}
+	if ks == nil {
+		return out
+	}
+	kids := make([]string, 0, len(ks.byID))
+	for kid := range ks.byID {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	enc := base64.RawURLEncoding
+	for _, kid := range kids {
+		k := ks.byID[kid]
+		jwk := JWK{Use: "sig", Alg: k.Method.Alg(), Kid: kid}
+		switch pub := k.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = enc.EncodeToString(pub.N.Bytes())
+			jwk.E = enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = enc.EncodeToString(pub)
+		default:
+			continue
+		}
+		out.Keys = append(out.Keys, jwk)
+	}
+	return out
+}
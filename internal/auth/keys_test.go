@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePEM(t *testing.T, dir, name, typ string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestKeySet_RotationAndHS256Migration(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa: %v", err)
+	}
+	writePEM(t, dir, "2026-01.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("pkcs8: %v", err)
+	}
+	writePEM(t, dir, "2026-02.pem", "PRIVATE KEY", der)
+
+	base, _ := NewConfig("legacy-secret", "15m", "24h")
+	legacy, err := base.GenerateAccess("u1", "u1@example.com", "client", "", false, "")
+	if err != nil {
+		t.Fatalf("HS256 token: %v", err)
+	}
+
+	// До ротации подписывает RSA-ключ 2026-01.
+	old, err := LoadKeySet(dir, "2026-01")
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	rsToken, err := base.WithKeys(old, true).GenerateAccess("u1", "u1@example.com", "client", "", false, "")
+	if err != nil {
+		t.Fatalf("RS256 token: %v", err)
+	}
+
+	// После ротации активен наибольший kid (Ed25519), старый токен по-прежнему проверяется.
+	ks, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	if ks.Active.ID != "2026-02" || ks.Active.Method.Alg() != "EdDSA" {
+		t.Fatalf("unexpected active key %s/%s", ks.Active.ID, ks.Active.Method.Alg())
+	}
+	cfg := base.WithKeys(ks, true)
+	edToken, err := cfg.GenerateAccess("u1", "u1@example.com", "client", "", false, "")
+	if err != nil {
+		t.Fatalf("EdDSA token: %v", err)
+	}
+	for name, tok := range map[string]string{"rs256": rsToken, "eddsa": edToken, "hs256": legacy} {
+		if _, err := cfg.ValidateAccess(tok); err != nil {
+			t.Errorf("%s token rejected: %v", name, err)
+		}
+	}
+	if _, err := base.WithKeys(ks, false).ValidateAccess(legacy); err == nil {
+		t.Error("HS256 token must be rejected when JWT_ACCEPT_HS256=false")
+	}
+
+	jwks := ks.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kty != "RSA" || jwks.Keys[1].Kty != "OKP" {
+		t.Fatalf("unexpected JWKS: %+v", jwks)
+	}
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTP_RFC6238VectorAndReplay(t *testing.T) {
+	// RFC 6238, приложение B: ключ "12345678901234567890", SHA1, T=59 -> 94287082 (6 младших цифр).
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	at := time.Unix(59, 0)
+	code, err := TOTPCode(secret, at)
+	if err != nil || code != "287082" {
+		t.Fatalf("TOTPCode = %q, %v; want 287082", code, err)
+	}
+	step, ok := ValidateTOTP(secret, code, at, 0)
+	if !ok || step != 1 {
+		t.Fatalf("ValidateTOTP = %d, %v; want step 1", step, ok)
+	}
+	if _, ok := ValidateTOTP(secret, code, at, step); ok {
+		t.Fatal("code must not be accepted twice")
+	}
+	if _, ok := ValidateTOTP(secret, code, at.Add(2*time.Minute), 0); ok {
+		t.Fatal("code outside the skew window must be rejected")
+	}
+
+	uri := TOTPURI("PSDS", "admin@example.com", secret)
+	if !strings.HasPrefix(uri, "otpauth://totp/PSDS:admin@example.com?") || !strings.Contains(uri, "secret="+secret) {
+		t.Errorf("unexpected otpauth uri: %s", uri)
+	}
+}
@@ -8,3 +8,28 @@ import (
 func MigrateUp(databaseURL string) error {
 	return database.MigrateUp(databaseURL)
 }
+
+// MigrateDown откатывает steps последних миграций.
+func MigrateDown(databaseURL string, steps int) error {
+	return database.MigrateDown(databaseURL, steps)
+}
+
+// MigrateGoto переводит схему на указанную версию.
+func MigrateGoto(databaseURL string, version uint) error {
+	return database.MigrateGoto(databaseURL, version)
+}
+
+// MigrateForce устанавливает версию без выполнения SQL и снимает dirty.
+func MigrateForce(databaseURL string, version int) error {
+	return database.MigrateForce(databaseURL, version)
+}
+
+// MigrateStatus возвращает текущую версию схемы, dirty и число неприменённых миграций.
+func MigrateStatus(databaseURL string) (*database.MigrationStatus, error) {
+	return database.GetMigrationStatus(databaseURL)
+}
+
+// MigrateCreate создаёт пару файлов новой миграции со следующим номером.
+func MigrateCreate(name string) (up, down string, err error) {
+	return database.CreateMigration(name)
+}
@@ -1,11 +1,175 @@
 package consumer
 
-// Consumer — обработчики очередей RabbitMQ.
-// Добавьте обработчики по мере подключения очередей, например:
-//   RegisterHandlers(conn *amqp.Connection, handlers map[string]Handler)
-//   HandleUserEvents(ctx context.Context, msgs <-chan amqp.Delivery)
-type Consumer struct{}
-
-func New() *Consumer {
-	return &Consumer{}
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/service"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// Config — топология очередей consumer событий session-manager.
+type Config struct {
+	URL        string // amqp://...
+	Exchange   string // topic exchange session-manager, например psds.session.events
+	Queue      string // очередь user-service; DLQ — Queue + ".dlq" через exchange Queue + ".dlx"
+	Prefetch   int
+	RetryDelay time.Duration // пауза перед возвратом сообщения в очередь при временной ошибке
+}
+
+// Consumer — обработчик событий session-manager (session.started, participant.joined,
+// participant.left, session.closed) с дедупликацией по message_id и dead-letter очередью.
+type Consumer struct {
+	cfg    Config
+	events service.SessionEventService
+}
+
+func New(cfg Config, events service.SessionEventService) *Consumer {
+	if cfg.Prefetch <= 0 {
+		cfg.Prefetch = 16
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = time.Second
+	}
+	return &Consumer{cfg: cfg, events: events}
+}
+
+// errPoison — сообщение, которое не будет обработано ни при какой повторной доставке.
+var errPoison = errors.New("poison message")
+
+// Run подключается к брокеру и обрабатывает сообщения до отмены ctx, переподключаясь при обрыве.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Printf("consumer: %v; reconnecting in 5s", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (c *Consumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.cfg.URL)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("channel: %w", err)
+	}
+	if err := c.declare(ch); err != nil {
+		return err
+	}
+	if err := ch.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("qos: %w", err)
+	}
+	deliveries, err := ch.Consume(c.cfg.Queue, "user-service", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+	log.Printf("consumer: listening on %s (exchange %s)", c.cfg.Queue, c.cfg.Exchange)
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case amqpErr := <-closed:
+			return fmt.Errorf("connection closed: %v", amqpErr)
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			c.dispatch(ctx, d)
+		}
+	}
+}
+
+// declare объявляет очередь с dead-letter exchange и привязывает её к событиям session-manager.
+func (c *Consumer) declare(ch *amqp.Channel) error {
+	dlx := c.cfg.Queue + ".dlx"
+	dlq := c.cfg.Queue + ".dlq"
+	if err := ch.ExchangeDeclare(c.cfg.Exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare exchange: %w", err)
+	}
+	if err := ch.ExchangeDeclare(dlx, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare dlx: %w", err)
+	}
+	if _, err := ch.QueueDeclare(dlq, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declare dlq: %w", err)
+	}
+	if err := ch.QueueBind(dlq, "", dlx, false, nil); err != nil {
+		return fmt.Errorf("bind dlq: %w", err)
+	}
+	if _, err := ch.QueueDeclare(c.cfg.Queue, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange": dlx,
+	}); err != nil {
+		return fmt.Errorf("declare queue: %w", err)
+	}
+	for _, key := range constants.SessionEvents {
+		if err := ch.QueueBind(c.cfg.Queue, key, c.cfg.Exchange, false, nil); err != nil {
+			return fmt.Errorf("bind %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// dispatch подтверждает успешные и дублирующиеся сообщения, отправляет poison в DLQ (nack без requeue)
+// и возвращает в очередь сообщения с временной ошибкой.
+func (c *Consumer) dispatch(ctx context.Context, d amqp.Delivery) {
+	err := c.Handle(ctx, d.RoutingKey, d.MessageId, d.Body)
+	switch {
+	case err == nil:
+		_ = d.Ack(false)
+	case errors.Is(err, errPoison):
+		log.Printf("consumer: %s %s -> DLQ: %v", d.RoutingKey, d.MessageId, err)
+		_ = d.Nack(false, false)
+	default:
+		log.Printf("consumer: %s %s: %v; requeue", d.RoutingKey, d.MessageId, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(c.cfg.RetryDelay):
+		}
+		_ = d.Nack(false, true)
+	}
+}
+
+// Handle разбирает и применяет одно сообщение. Ошибка, обёрнутая в errPoison, — постоянная.
+// Без message_id ключом дедупликации служит SHA-256 от routing key и тела.
+func (c *Consumer) Handle(ctx context.Context, routingKey, messageID string, body []byte) error {
+	var ev dto.SessionEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return fmt.Errorf("%w: decode: %v", errPoison, err)
+	}
+	ev.Type = routingKey
+	if messageID == "" {
+		sum := sha256.Sum256(append([]byte(routingKey+"\n"), body...))
+		messageID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	applied, err := c.events.Apply(ctx, messageID, &ev)
+	if errors.Is(err, errs.ErrInvalidSessionEvent) || errors.Is(err, errs.ErrUserNotFound) {
+		return fmt.Errorf("%w: %v", errPoison, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !applied {
+		log.Printf("consumer: %s %s already processed", routingKey, messageID)
+	}
+	return nil
 }
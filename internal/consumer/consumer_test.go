@@ -0,0 +1,128 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// fakeEvents — SessionEventService, возвращающий заданный результат и запоминающий вызовы.
+type fakeEvents struct {
+	applied   bool
+	err       error
+	messageID string
+	event     *dto.SessionEvent
+}
+
+func (f *fakeEvents) Apply(_ context.Context, messageID string, ev *dto.SessionEvent) (bool, error) {
+	f.messageID, f.event = messageID, ev
+	return f.applied, f.err
+}
+
+func (f *fakeEvents) PurgeProcessed(context.Context, time.Time) (int64, error) { return 0, nil }
+
+// ackRecorder — amqp.Acknowledger, запоминающий решение по сообщению.
+type ackRecorder struct {
+	acked, nacked, requeued bool
+}
+
+func (a *ackRecorder) Ack(uint64, bool) error { a.acked = true; return nil }
+
+func (a *ackRecorder) Nack(_ uint64, _ bool, requeue bool) error {
+	a.nacked, a.requeued = true, requeue
+	return nil
+}
+
+func (a *ackRecorder) Reject(_ uint64, requeue bool) error {
+	a.nacked, a.requeued = true, requeue
+	return nil
+}
+
+func TestHandle_ClassifiesErrors(t *testing.T) {
+	body := []byte(`{"session_id":"room-1","user_id":"4f0c1a52-8d7e-4a6b-9d2a-2f1f5d3c9b10"}`)
+	cases := []struct {
+		name   string
+		body   []byte
+		err    error
+		poison bool
+		ok     bool
+	}{
+		{"applied", body, nil, false, true},
+		{"duplicate", body, nil, false, true},
+		{"malformed json", []byte(`{`), nil, true, false},
+		{"invalid event", body, errs.ErrInvalidSessionEvent, true, false},
+		{"unknown user", body, errs.ErrUserNotFound, true, false},
+		{"database down", body, errors.New("connection refused"), false, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := &fakeEvents{applied: tc.name == "applied", err: tc.err}
+			c := New(Config{}, events)
+			err := c.Handle(context.Background(), constants.SessionEventParticipantJoined, "msg-1", tc.body)
+			if tc.ok != (err == nil) {
+				t.Fatalf("unexpected result: %v", err)
+			}
+			if got := errors.Is(err, errPoison); got != tc.poison {
+				t.Fatalf("poison = %v, want %v (err: %v)", got, tc.poison, err)
+			}
+		})
+	}
+}
+
+func TestHandle_DerivesMessageIDFromBody(t *testing.T) {
+	events := &fakeEvents{applied: true}
+	c := New(Config{}, events)
+	body := []byte(`{"session_id":"room-1"}`)
+	if err := c.Handle(context.Background(), constants.SessionEventClosed, "", body); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	first := events.messageID
+	if !strings.HasPrefix(first, "sha256:") || events.event.Type != constants.SessionEventClosed {
+		t.Fatalf("unexpected message id %q or type %q", first, events.event.Type)
+	}
+	if err := c.Handle(context.Background(), constants.SessionEventClosed, "", body); err != nil || events.messageID != first {
+		t.Fatalf("same body must give the same id: %q vs %q (%v)", events.messageID, first, err)
+	}
+	if err := c.Handle(context.Background(), constants.SessionEventStarted, "", body); err != nil || events.messageID == first {
+		t.Fatalf("routing key must be part of the id, got %q (%v)", events.messageID, err)
+	}
+}
+
+func TestDispatch_AckDeadLetterAndRequeue(t *testing.T) {
+	body := []byte(`{"session_id":"room-1"}`)
+	cases := []struct {
+		name     string
+		body     []byte
+		err      error
+		acked    bool
+		requeued bool
+	}{
+		{"ack", body, nil, true, false},
+		{"poison to DLQ", []byte(`not json`), nil, false, false},
+		{"transient requeue", body, errors.New("deadlock detected"), false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(Config{RetryDelay: time.Millisecond}, &fakeEvents{applied: true, err: tc.err})
+			ack := &ackRecorder{}
+			c.dispatch(context.Background(), amqp.Delivery{
+				Acknowledger: ack,
+				RoutingKey:   constants.SessionEventClosed,
+				MessageId:    "msg-1",
+				Body:         tc.body,
+			})
+			if ack.acked != tc.acked || ack.nacked == tc.acked || ack.requeued != tc.requeued {
+				t.Fatalf("acked=%v nacked=%v requeued=%v, want acked=%v requeued=%v",
+					ack.acked, ack.nacked, ack.requeued, tc.acked, tc.requeued)
+			}
+		})
+	}
+}
@@ -1,42 +1,90 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	dbfiles "github.com/psds-microservice/user-service/database"
 )
 
-// MigrateUp runs all pending migrations. It looks for database/migrations in cwd or parent (when run from bin/).
-func MigrateUp(databaseURL string) error {
-	cwd, _ := os.Getwd()
-	dirs := []string{
-		filepath.Join(cwd, "database", "migrations"),
-		filepath.Join(cwd, "..", "database", "migrations"),
+// MigrationStatus — состояние схемы: применённая версия (0 — миграций не было), dirty — миграция
+// оборвалась на середине (нужен force), Latest — последняя версия в database/migrations.
+type MigrationStatus struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest"`
+	Pending int  `json:"pending"`
+}
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// migrationsDir — каталог миграций на диске вместо встроенных (--migrations-dir, для разработки).
+var migrationsDir string
+
+// SetMigrationsDir переключает миграции на каталог dir; "" — встроенные в бинарник.
+func SetMigrationsDir(dir string) {
+	migrationsDir = dir
+}
+
+// migrationsFS — источник миграций: каталог --migrations-dir или встроенный database/migrations.
+func migrationsFS() (fs.FS, error) {
+	if migrationsDir == "" {
+		return fs.Sub(dbfiles.Migrations, "migrations")
 	}
-	var absDir string
-	for _, d := range dirs {
-		if _, err := os.Stat(d); err == nil {
-			absDir, _ = filepath.Abs(d)
-			break
-		}
+	if st, err := os.Stat(migrationsDir); err != nil || !st.IsDir() {
+		return nil, fmt.Errorf("migrations dir %q not found", migrationsDir)
 	}
-	if absDir == "" {
-		return fmt.Errorf("migrations dir not found (tried cwd and parent)")
+	return os.DirFS(migrationsDir), nil
+}
+
+func newMigrate(databaseURL string) (*migrate.Migrate, fs.FS, error) {
+	fsys, err := migrationsFS()
+	if err != nil {
+		return nil, nil, err
 	}
-	sourceURL := "file://" + filepath.ToSlash(absDir)
-	m, err := migrate.New(sourceURL, databaseURL)
+	src, err := iofs.New(fsys, ".")
 	if err != nil {
-		return fmt.Errorf("migrate new: %w", err)
+		return nil, nil, fmt.Errorf("migrations source: %w", err)
 	}
-	defer m.Close()
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("migrate new: %w", err)
+	}
+	return m, fsys, nil
+}
+
+// dirtyHint дополняет ErrDirty подсказкой: после ручного исправления схемы — migrate force V.
+func dirtyHint(err error) error {
+	var dirty migrate.ErrDirty
+	if errors.As(err, &dirty) {
+		return fmt.Errorf("database is dirty at version %d: fix the schema manually, then run `migrate force <version>`", dirty.Version)
+	}
+	return err
+}
+
+// MigrateUp runs all pending migrations (embedded database/migrations unless SetMigrationsDir was called).
+func MigrateUp(databaseURL string) error {
+	m, _, err := newMigrate(databaseURL)
+	if err != nil {
 		return err
 	}
+	defer m.Close()
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		return dirtyHint(err)
+	}
 	if err == migrate.ErrNoChange {
 		log.Println("migrate: no pending migrations")
 	} else {
@@ -44,3 +92,144 @@ func MigrateUp(databaseURL string) error {
 	}
 	return nil
 }
+
+// MigrateDown откатывает steps последних миграций.
+func MigrateDown(databaseURL string, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	m, _, err := newMigrate(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if err := m.Steps(-steps); err != nil && err != migrate.ErrNoChange {
+		return dirtyHint(err)
+	}
+	return nil
+}
+
+// MigrateGoto переводит схему на версию version (вверх или вниз).
+func MigrateGoto(databaseURL string, version uint) error {
+	m, _, err := newMigrate(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		return dirtyHint(err)
+	}
+	return nil
+}
+
+// MigrateForce записывает версию и снимает dirty без выполнения SQL (после ручного исправления схемы).
+// version -1 — схема без миграций.
+func MigrateForce(databaseURL string, version int) error {
+	m, _, err := newMigrate(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Force(version)
+}
+
+// GetMigrationStatus возвращает применённую версию, признак dirty и число неприменённых миграций.
+func GetMigrationStatus(databaseURL string) (*MigrationStatus, error) {
+	m, fsys, err := newMigrate(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+	st := &MigrationStatus{}
+	st.Version, st.Dirty, err = m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, err
+	}
+	versions, err := migrationVersions(fsys)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v > st.Version {
+			st.Pending++
+		}
+	}
+	if len(versions) > 0 {
+		st.Latest = versions[len(versions)-1]
+	}
+	return st, nil
+}
+
+// migrationVersions — отсортированные версии *.up.sql в fsys.
+func migrationVersions(fsys fs.FS) ([]uint, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var out []uint
+	for _, e := range entries {
+		mm := migrationFileRe.FindStringSubmatch(e.Name())
+		if mm == nil || mm[3] != "up" {
+			continue
+		}
+		v, err := strconv.ParseUint(mm[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		out = append(out, uint(v))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+var migrationNameRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sourceMigrationsDir — database/migrations в исходниках для migrate create: --migrations-dir,
+// иначе cwd или родитель (запуск из bin/).
+func sourceMigrationsDir() (string, error) {
+	if migrationsDir != "" {
+		return filepath.Abs(migrationsDir)
+	}
+	cwd, _ := os.Getwd()
+	for _, d := range []string{
+		filepath.Join(cwd, "database", "migrations"),
+		filepath.Join(cwd, "..", "database", "migrations"),
+	} {
+		if _, err := os.Stat(d); err == nil {
+			return filepath.Abs(d)
+		}
+	}
+	return "", fmt.Errorf("migrations dir not found (tried cwd and parent; use --migrations-dir)")
+}
+
+// CreateMigration создаёт пустую пару NNNNNN_name.up.sql / .down.sql со следующим номером в исходниках.
+// Во встроенные миграции файлы попадут после пересборки.
+func CreateMigration(name string) (up, down string, err error) {
+	slug := strings.Trim(migrationNameRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return "", "", fmt.Errorf("migration name %q has no letters or digits", name)
+	}
+	dir, err := sourceMigrationsDir()
+	if err != nil {
+		return "", "", err
+	}
+	versions, err := migrationVersions(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	next := uint(1)
+	if len(versions) > 0 {
+		next = versions[len(versions)-1] + 1
+	}
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", next, slug))
+	up, down = base+".up.sql", base+".down.sql"
+	header := fmt.Sprintf("-- %06d_%s\n", next, slug)
+	if err := os.WriteFile(up, []byte(header), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte(header), 0o644); err != nil {
+		_ = os.Remove(up)
+		return "", "", err
+	}
+	return up, down, nil
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+)
+
+// versionDriver — драйвер golang-migrate без SQL: хранит только версию и dirty между подключениями.
+type versionDriver struct {
+	mu      sync.Mutex
+	version int
+	dirty   bool
+}
+
+func (d *versionDriver) Open(string) (migratedb.Driver, error) { return d, nil }
+func (d *versionDriver) Close() error                          { return nil }
+func (d *versionDriver) Lock() error                           { return nil }
+func (d *versionDriver) Unlock() error                         { return nil }
+func (d *versionDriver) Run(io.Reader) error                   { return nil }
+func (d *versionDriver) Drop() error                           { return d.SetVersion(migratedb.NilVersion, false) }
+
+func (d *versionDriver) SetVersion(version int, dirty bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.version, d.dirty = version, dirty
+	return nil
+}
+
+func (d *versionDriver) Version() (int, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.version, d.dirty, nil
+}
+
+var testDriver = &versionDriver{version: migratedb.NilVersion}
+
+func init() {
+	migratedb.Register("versiontest", testDriver)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	fsys, err := migrationsFS()
+	if err != nil {
+		t.Fatalf("migrationsFS: %v", err)
+	}
+	versions, err := migrationVersions(fsys)
+	if err != nil {
+		t.Fatalf("migrationVersions: %v", err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	for i, v := range versions {
+		if v != uint(i+1) {
+			t.Fatalf("migration versions must be contiguous from 1, got %v", versions)
+		}
+	}
+	SetMigrationsDir(t.TempDir() + "/missing")
+	defer SetMigrationsDir("")
+	if _, err := migrationsFS(); err == nil {
+		t.Error("expected error for a missing --migrations-dir")
+	}
+}
+
+func TestMigrateForce_ClearsDirtyAndResetsToNoMigrations(t *testing.T) {
+	const url = "versiontest://"
+	if err := MigrateUp(url); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	st, err := GetMigrationStatus(url)
+	if err != nil {
+		t.Fatalf("GetMigrationStatus: %v", err)
+	}
+	if st.Version != st.Latest || st.Pending != 0 {
+		t.Fatalf("after up: %+v", st)
+	}
+	latest := st.Latest
+
+	// Упавшая миграция оставляет dirty; force V снимает его без выполнения SQL.
+	_ = testDriver.SetVersion(int(latest), true)
+	if err := MigrateUp(url); err == nil {
+		t.Fatal("MigrateUp on a dirty database must fail")
+	}
+	if err := MigrateForce(url, int(latest)-1); err != nil {
+		t.Fatalf("MigrateForce(%d): %v", latest-1, err)
+	}
+	if st, err = GetMigrationStatus(url); err != nil || st.Dirty || st.Version != latest-1 || st.Pending != 1 {
+		t.Fatalf("after force %d: %+v, %v", latest-1, st, err)
+	}
+
+	if err := MigrateForce(url, -1); err != nil {
+		t.Fatalf("MigrateForce(-1): %v", err)
+	}
+	if st, err = GetMigrationStatus(url); err != nil || st.Dirty || st.Version != 0 || st.Pending != int(latest) {
+		t.Fatalf("after force -1: %+v, %v", st, err)
+	}
+}
@@ -2,34 +2,19 @@ package database
 
 import (
 	"fmt"
+	"io/fs"
 	"log"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
 	"gorm.io/gorm"
+
+	dbfiles "github.com/psds-microservice/user-service/database"
 )
 
-// RunSeeds runs all *.sql files from database/seeds in lexicographic order.
-// It looks for database/seeds in cwd or parent (when run from bin/).
+// RunSeeds runs all *.sql files embedded from database/seeds in lexicographic order.
 func RunSeeds(db *gorm.DB) error {
-	cwd, _ := os.Getwd()
-	dirs := []string{
-		filepath.Join(cwd, "database", "seeds"),
-		filepath.Join(cwd, "..", "database", "seeds"),
-	}
-	var seedsDir string
-	for _, d := range dirs {
-		if _, err := os.Stat(d); err == nil {
-			seedsDir, _ = filepath.Abs(d)
-			break
-		}
-	}
-	if seedsDir == "" {
-		return fmt.Errorf("seeds dir not found (tried database/seeds)")
-	}
-	entries, err := os.ReadDir(seedsDir)
+	entries, err := fs.ReadDir(dbfiles.Seeds, "seeds")
 	if err != nil {
 		return err
 	}
@@ -41,8 +26,7 @@ func RunSeeds(db *gorm.DB) error {
 	}
 	sort.Strings(files)
 	for _, f := range files {
-		path := filepath.Join(seedsDir, f)
-		body, err := os.ReadFile(path)
+		body, err := fs.ReadFile(dbfiles.Seeds, "seeds/"+f)
 		if err != nil {
 			return fmt.Errorf("seed %s: %w", f, err)
 		}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditFilters — фильтры журнала аудита (GET /api/v1/audit-events).
+type AuditFilters struct {
+	ActorID  string
+	TargetID string
+	Action   string
+	From     *time.Time // включительно
+	To       *time.Time // не включительно
+	Limit    int
+	Cursor   string // AuditPage.NextCursor
+}
+
+// AuditEvent — запись журнала аудита. Changes — поля с old/new (или redacted); Source и Reason —
+// у назначений роли.
+type AuditEvent struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	ClientIP   string          `json:"client_ip,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditPage — страница журнала аудита.
+type AuditPage struct {
+	Events     []*AuditEvent `json:"events"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
@@ -6,12 +6,14 @@ type RegisterRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	Role     string `json:"role"` // client, operator, admin
+	DeviceID string `json:"device_id,omitempty"`
 }
 
 // LoginRequest — POST /api/v1/auth/login.
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	DeviceID string `json:"device_id,omitempty"`
 }
 
 // TokenResponse — access + refresh (login, refresh).
@@ -26,3 +28,25 @@ type TokenResponse struct {
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
+
+// PasswordResetRequest — POST /api/v1/auth/password/reset.
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// PasswordResetConfirm — POST /api/v1/auth/password/reset/confirm.
+type PasswordResetConfirm struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ResendVerificationRequest — POST /api/v1/auth/email/verify/resend без access-токена.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// MFAEnrollment — секрет TOTP для приложения-аутентификатора (POST /api/v1/users/me/mfa/enroll).
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
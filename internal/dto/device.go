@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// UserDeviceResponse — устройство пользователя.
+type UserDeviceResponse struct {
+	ID                string     `json:"id"`
+	UserID            string     `json:"user_id"`
+	DeviceID          string     `json:"device_id"`
+	DeviceType        string     `json:"device_type"`
+	UserAgent         string     `json:"user_agent,omitempty"`
+	IPAddress         string     `json:"ip_address,omitempty"`
+	ConnectionID      string     `json:"connection_id,omitempty"`
+	IsConnected       bool       `json:"is_connected"`
+	LastHeartbeat     *time.Time `json:"last_heartbeat,omitempty"`
+	SupportsWebRTC    bool       `json:"supports_webrtc"`
+	SupportsWebSocket bool       `json:"supports_websocket"`
+	BandwidthLimit    *int       `json:"bandwidth_limit,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// RegisterDeviceRequest — POST /api/v1/users/me/devices (upsert по user_id + device_id).
+type RegisterDeviceRequest struct {
+	DeviceID          string `json:"device_id"`
+	DeviceType        string `json:"device_type"`
+	UserAgent         string `json:"user_agent"`
+	IPAddress         string `json:"ip_address"`
+	SupportsWebRTC    bool   `json:"supports_webrtc"`
+	SupportsWebSocket bool   `json:"supports_websocket"`
+	BandwidthLimit    int    `json:"bandwidth_limit"` // 0 — без ограничения
+}
+
+// ConnectDeviceRequest — POST /api/v1/devices/connect.
+type ConnectDeviceRequest struct {
+	UserID       string `json:"user_id"`
+	DeviceID     string `json:"device_id"`
+	ConnectionID string `json:"connection_id"`
+	IPAddress    string `json:"ip_address"`
+	UserAgent    string `json:"user_agent"`
+}
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ServiceSchedule — окно работы сервиса (user_services.schedule).
+type ServiceSchedule struct {
+	Always    bool     `json:"always"`
+	Weekdays  []string `json:"weekdays,omitempty"`   // monday..sunday
+	StartTime string   `json:"start_time,omitempty"` // HH:MM
+	EndTime   string   `json:"end_time,omitempty"`   // HH:MM
+}
+
+// UserServiceRouteResponse — запись user_services.
+type UserServiceRouteResponse struct {
+	ID                 string           `json:"id"`
+	UserID             string           `json:"user_id"`
+	ServiceName        string           `json:"service_name"`
+	ServiceType        string           `json:"service_type"`
+	BaseURL            string           `json:"base_url"`
+	APIEndpoint        string           `json:"api_endpoint"`
+	Port               int              `json:"port"`
+	UseSSL             bool             `json:"use_ssl"`
+	SSLCertificatePath string           `json:"ssl_certificate_path,omitempty"`
+	RoutingKey         string           `json:"routing_key,omitempty"`
+	QueueName          string           `json:"queue_name,omitempty"`
+	TopicName          string           `json:"topic_name,omitempty"`
+	MaxBitrate         int              `json:"max_bitrate"`
+	MaxConnections     int              `json:"max_connections"`
+	Priority           int              `json:"priority"`
+	IsActive           bool             `json:"is_active"`
+	EnabledButtons     []string         `json:"enabled_buttons"`
+	Schedule           *ServiceSchedule `json:"schedule,omitempty"`
+	Parameters         json.RawMessage  `json:"parameters,omitempty"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// Поля записи user_services для UpdateMask (имена как в proto).
+const (
+	RouteFieldServiceName        = "service_name"
+	RouteFieldServiceType        = "service_type"
+	RouteFieldBaseURL            = "base_url"
+	RouteFieldAPIEndpoint        = "api_endpoint"
+	RouteFieldPort               = "port"
+	RouteFieldUseSSL             = "use_ssl"
+	RouteFieldSSLCertificatePath = "ssl_certificate_path"
+	RouteFieldRoutingKey         = "routing_key"
+	RouteFieldQueueName          = "queue_name"
+	RouteFieldTopicName          = "topic_name"
+	RouteFieldMaxBitrate         = "max_bitrate"
+	RouteFieldMaxConnections     = "max_connections"
+	RouteFieldPriority           = "priority"
+	RouteFieldIsActive           = "is_active"
+	RouteFieldEnabledButtons     = "enabled_buttons"
+	RouteFieldSchedule           = "schedule"
+	RouteFieldParameters         = "parameters"
+)
+
+// RouteFields — все изменяемые поля user_services; создание заполняет каждое из них.
+var RouteFields = []string{
+	RouteFieldServiceName, RouteFieldServiceType, RouteFieldBaseURL, RouteFieldAPIEndpoint, RouteFieldPort,
+	RouteFieldUseSSL, RouteFieldSSLCertificatePath, RouteFieldRoutingKey, RouteFieldQueueName, RouteFieldTopicName,
+	RouteFieldMaxBitrate, RouteFieldMaxConnections, RouteFieldPriority, RouteFieldIsActive, RouteFieldEnabledButtons,
+	RouteFieldSchedule, RouteFieldParameters,
+}
+
+// UserServiceRouteRequest — POST /api/v1/users/{user_id}/services, PUT/PATCH /api/v1/users/{user_id}/services/{id}.
+// Обновление меняет только поля из UpdateMask (RouteField*); поле из маски с пустым значением
+// получает значение по умолчанию.
+type UserServiceRouteRequest struct {
+	ID                 string           `json:"id,omitempty"`
+	UserID             string           `json:"user_id"`
+	ServiceName        string           `json:"service_name"`
+	ServiceType        string           `json:"service_type"`
+	BaseURL            string           `json:"base_url"`
+	APIEndpoint        string           `json:"api_endpoint"`
+	Port               int              `json:"port"`
+	UseSSL             bool             `json:"use_ssl"`
+	SSLCertificatePath string           `json:"ssl_certificate_path"`
+	RoutingKey         string           `json:"routing_key"`
+	QueueName          string           `json:"queue_name"`
+	TopicName          string           `json:"topic_name"`
+	MaxBitrate         int              `json:"max_bitrate"`
+	MaxConnections     int              `json:"max_connections"`
+	Priority           int              `json:"priority"`
+	IsActive           *bool            `json:"is_active"` // nil — true
+	EnabledButtons     []string         `json:"enabled_buttons"`
+	Schedule           *ServiceSchedule `json:"schedule"`
+	Parameters         json.RawMessage  `json:"parameters"`
+
+	UpdateMask []string `json:"update_mask"`
+}
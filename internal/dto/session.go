@@ -22,3 +22,10 @@ type CreateSessionRequest struct {
 	SessionExternalID string `json:"session_external_id"`
 	ParticipantRole   string `json:"participant_role"` // host, operator, viewer
 }
+
+// RateConsultationRequest — POST /api/v1/users/me/sessions/{session_id}/rating.
+type RateConsultationRequest struct {
+	SessionID string `json:"session_id"`
+	Rating    int    `json:"rating"` // 1..5
+	Feedback  string `json:"feedback"`
+}
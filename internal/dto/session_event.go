@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// SessionEvent — событие session-manager (JSON-тело сообщения RabbitMQ). Type берётся из routing key.
+//   - session.started: session_id, session_type, user_id инициатора (становится host);
+//   - participant.joined: session_id, user_id, role (host, operator, viewer);
+//   - participant.left: session_id, user_id;
+//   - session.closed: session_id.
+type SessionEvent struct {
+	Type        string    `json:"-"`
+	SessionID   string    `json:"session_id"`
+	SessionType string    `json:"session_type,omitempty"`
+	UserID      string    `json:"user_id,omitempty"`
+	Role        string    `json:"role,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at,omitempty"`
+}
@@ -8,10 +8,46 @@ type CreateUserRequest struct {
 	Email    string `json:"email"`
 	Phone    string `json:"phone"`
 	Password string `json:"password"`
-	Role     string `json:"role"` // client, operator, admin
+	Role     string `json:"role"` // client, operator, admin, service
+	// ActorID — admin, создающий пользователя; "" — публичная регистрация (роли admin и service запрещены).
+	ActorID string `json:"-"`
 }
 
-// UpdateUserRequest — запрос на обновление пользователя.
+// SetUserRoleRequest — смена роли (POST /api/v1/users/{id}/role, CLI).
+type SetUserRoleRequest struct {
+	ID      string `json:"id"`
+	Role    string `json:"role"`
+	Reason  string `json:"reason,omitempty"`
+	ActorID string `json:"-"` // "" — вызов из CLI
+}
+
+// Поля пользователя, изменяемые UpdateUser/UpdateMe (пути update_mask).
+const (
+	UserFieldUsername       = "username"
+	UserFieldEmail          = "email"
+	UserFieldPhone          = "phone"
+	UserFieldPassword       = "password"
+	UserFieldStatus         = "status"
+	UserFieldFullName       = "full_name"
+	UserFieldAvatarURL      = "avatar_url"
+	UserFieldTimezone       = "timezone"
+	UserFieldLanguage       = "language"
+	UserFieldCompany        = "company"
+	UserFieldSpecialization = "specialization"
+)
+
+// SelfUpdatableFields — поля, которые пользователь меняет сам (UpdateMe).
+var SelfUpdatableFields = []string{
+	UserFieldUsername, UserFieldEmail, UserFieldPhone, UserFieldPassword, UserFieldFullName,
+	UserFieldAvatarURL, UserFieldTimezone, UserFieldLanguage, UserFieldCompany, UserFieldSpecialization,
+}
+
+// AdminUpdatableFields — поля, которые admin меняет у любого пользователя (UpdateUser): всё из
+// SelfUpdatableFields и status. Роль — только SetUserRole.
+var AdminUpdatableFields = append([]string{UserFieldStatus}, SelfUpdatableFields...)
+
+// UpdateUserRequest — запрос на обновление пользователя. Меняются только поля из UpdateMask
+// (UserField*); поле из маски с пустым значением очищается.
 type UpdateUserRequest struct {
 	ID             string `json:"id"`
 	Username       string `json:"username"`
@@ -25,6 +61,14 @@ type UpdateUserRequest struct {
 	Language       string `json:"language"`
 	Company        string `json:"company"`
 	Specialization string `json:"specialization"`
+
+	UpdateMask []string `json:"update_mask"`
+	// ExpectedVersion — версия из ETag (If-Match); 0 — без проверки. Не совпала — errs.ErrVersionConflict.
+	ExpectedVersion int64 `json:"-"`
+	// RequireCurrentPassword — смена password только при верном CurrentPassword (UpdateMe);
+	// иначе errs.ErrInvalidCurrentPassword.
+	RequireCurrentPassword bool   `json:"-"`
+	CurrentPassword        string `json:"-"`
 }
 
 // UserResponse — ответ с данными пользователя.
@@ -32,6 +76,8 @@ type UserResponse struct {
 	ID             string     `json:"id"`
 	Username       string     `json:"username"`
 	Email          string     `json:"email"`
+	EmailVerified  bool       `json:"email_verified"`
+	MFAEnabled     bool       `json:"mfa_enabled"`
 	Phone          string     `json:"phone"`
 	Status         string     `json:"status"`
 	Role           string     `json:"role"`
@@ -53,13 +99,26 @@ type UserResponse struct {
 	LastLogin      *time.Time `json:"last_login,omitempty"`
 	LastActivity   *time.Time `json:"last_activity,omitempty"`
 	LastSeenAt     *time.Time `json:"last_seen_at,omitempty"`
+	Version        int64      `json:"version"` // users.version, основа ETag
 }
 
-// UserFilters — фильтры для списка пользователей.
+// UserFilters — фильтры для списка пользователей (GET /api/v1/users).
 type UserFilters struct {
-	Limit  int
-	Offset int
-	Status string
-	Role   string
-	Search string
+	Limit          int
+	Cursor         string // непрозрачный курсор keyset-пагинации (UserPage.NextCursor)
+	Sort           string // created_at, -created_at (по умолчанию), username, -username
+	Status         string
+	Role           string
+	Search         string
+	OperatorStatus string
+	IsOnline       *bool
+	IsAvailable    *bool
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+}
+
+// UserPage — страница списка пользователей.
+type UserPage struct {
+	Users      []*UserResponse `json:"users"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
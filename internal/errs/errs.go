@@ -1,16 +1,66 @@
 package errs
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Доменные сентинель-ошибки для маппинга в gRPC/HTTP коды.
 var (
 	ErrUserAlreadyExists              = errors.New("user already exists")
 	ErrInvalidUserID                  = errors.New("invalid user id")
+	ErrInvalidCursor                  = errors.New("invalid cursor")
+	ErrInvalidUpdateMask              = errors.New("invalid update mask")
+	ErrVersionConflict                = errors.New("user was modified concurrently, reload and retry")
+	ErrInvalidSort                    = errors.New("invalid sort")
 	ErrUserNotFound                   = errors.New("user not found")
 	ErrInvalidCredentials             = errors.New("invalid credentials")
+	ErrInvalidCurrentPassword         = errors.New("current password is incorrect")
+	ErrUserBlocked                    = errors.New("user is blocked")
+	ErrUserInactive                   = errors.New("user is inactive")
+	ErrTooManyLoginAttempts           = errors.New("too many login attempts")
+	ErrInvalidRefreshToken            = errors.New("invalid refresh token")
+	ErrRefreshTokenReused             = errors.New("refresh token reuse detected")
+	ErrInvalidResetToken              = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken       = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified               = errors.New("email is not verified")
+	ErrEmailAlreadyVerified           = errors.New("email is already verified")
+	ErrVerificationCooldown           = errors.New("verification email was sent recently, try again later")
+	ErrInvalidMFAToken                = errors.New("invalid or expired mfa token")
+	ErrInvalidMFACode                 = errors.New("invalid mfa code")
+	ErrMFAAlreadyEnabled              = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled                 = errors.New("two-factor authentication is not enrolled")
+	ErrMFARequired                    = errors.New("two-factor authentication is mandatory for this role")
+	ErrInvalidRole                    = errors.New("role must be one of: client, operator, admin, service")
+	ErrRoleNotAllowed                 = errors.New("role cannot be assigned by this caller")
+	ErrLastAdmin                      = errors.New("cannot remove the role of the last admin")
+	ErrAdminExists                    = errors.New("an admin already exists")
 	ErrNotOperator                    = errors.New("user is not an operator")
 	ErrInvalidOperatorStatus          = errors.New("invalid operator status")
+	ErrInvalidUserStatus              = errors.New("status must be one of: active, inactive, blocked")
 	ErrClientStreamingLimit           = errors.New("client may have only one active streaming session")
 	ErrOperatorNotVerifiedOrAvailable = errors.New("operator must be verified and available")
 	ErrMaxSessionsReached             = errors.New("max_sessions reached")
+	ErrSessionNotFound                = errors.New("session not found")
+	ErrInvalidSessionEvent            = errors.New("invalid session event")
+	ErrSessionNotFinished             = errors.New("session is not finished yet")
+	ErrSessionAlreadyRated            = errors.New("session already rated")
+	ErrNotConsultationClient          = errors.New("only the client of a consultation may rate it")
+	ErrConsultationOperatorNotFound   = errors.New("consultation has no operator to rate")
+	ErrDeviceNotFound                 = errors.New("device not found")
+	ErrServiceRouteNotFound           = errors.New("user service not found")
+	ErrServiceRouteExists             = errors.New("user service with this name already exists")
+	ErrNoActiveServiceRoute           = errors.New("no active service for button")
 )
+
+// LockedError — вход временно заблокирован после серии неудач; errors.Is(err, ErrTooManyLoginAttempts).
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Unwrap() error { return ErrTooManyLoginAttempts }
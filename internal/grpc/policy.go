@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"github.com/psds-microservice/user-service/internal/middleware"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	us "github.com/psds-microservice/user-service/pkg/gen/user_service"
+)
+
+// Policy — правила доступа ко всем RPC UserService (применяются и к gRPC, и к grpc-gateway).
+// Новый RPC без записи здесь будет отклонён интерсептором с PermissionDenied.
+func Policy() middleware.Policy {
+	public := middleware.Rule{Public: true}
+	authenticated := middleware.Rule{}
+	// limited — доступно и с ограниченным токеном (не подтверждён email, admin без обязательной 2FA).
+	limited := middleware.Rule{AllowLimited: true}
+	userManage := middleware.Rule{Permission: constants.PermUserManage}
+	sessionManage := middleware.Rule{Permission: constants.PermSessionManage}
+	selfOr := func(field, perm string) middleware.Rule {
+		return middleware.Rule{SelfField: field, Permission: perm}
+	}
+
+	return middleware.Policy{
+		us.UserService_Login_FullMethodName:    public,
+		us.UserService_Register_FullMethodName: public,
+		us.UserService_Refresh_FullMethodName:  public,
+		us.UserService_Logout_FullMethodName:   public,
+
+		us.UserService_RequestPasswordReset_FullMethodName: public,
+		us.UserService_ConfirmPasswordReset_FullMethodName: public,
+		us.UserService_VerifyEmail_FullMethodName:          public,
+		us.UserService_ResendVerification_FullMethodName:   public,
+		us.UserService_LoginVerifyMFA_FullMethodName:       public,
+
+		us.UserService_GetMe_FullMethodName:    limited,
+		us.UserService_UpdateMe_FullMethodName: limited,
+
+		us.UserService_EnrollMFA_FullMethodName:  limited,
+		us.UserService_ConfirmMFA_FullMethodName: limited,
+		us.UserService_DisableMFA_FullMethodName: authenticated,
+
+		us.UserService_CreateUser_FullMethodName:  userManage,
+		us.UserService_ListUsers_FullMethodName:   userManage,
+		us.UserService_GetUser_FullMethodName:     {SelfField: "id", Permission: constants.PermUserManage, AllowLimited: true},
+		us.UserService_GetUserCard_FullMethodName: authenticated,
+		us.UserService_UpdateUser_FullMethodName:  userManage,
+		us.UserService_DeleteUser_FullMethodName:  userManage,
+
+		us.UserService_SetUserRole_FullMethodName: userManage,
+		us.UserService_RestoreUser_FullMethodName: userManage,
+		us.UserService_PurgeUser_FullMethodName:   userManage,
+
+		us.UserService_ListAuditEvents_FullMethodName: {Permission: constants.PermAuditRead},
+
+		us.UserService_GetUserSessions_FullMethodName:         selfOr("id", constants.PermUserManage),
+		us.UserService_GetActiveSessions_FullMethodName:       selfOr("id", constants.PermUserManage),
+		us.UserService_CreateSession_FullMethodName:           selfOr("id", constants.PermSessionManage),
+		us.UserService_EndSession_FullMethodName:              selfOr("id", constants.PermSessionManage),
+		us.UserService_EndSessionsByExternalID_FullMethodName: sessionManage,
+		us.UserService_ValidateUserSession_FullMethodName:     selfOr("user_id", constants.PermSessionManage),
+		us.UserService_RateConsultation_FullMethodName:        authenticated,
+		us.UserService_UpdateUserPresence_FullMethodName:      selfOr("user_id", constants.PermSessionManage),
+
+		us.UserService_RegisterDevice_FullMethodName:   authenticated,
+		us.UserService_ListMyDevices_FullMethodName:    authenticated,
+		us.UserService_RemoveDevice_FullMethodName:     authenticated,
+		us.UserService_ConnectDevice_FullMethodName:    selfOr("user_id", constants.PermSessionManage),
+		us.UserService_DisconnectDevice_FullMethodName: sessionManage,
+		us.UserService_DeviceHeartbeat_FullMethodName:  sessionManage,
+
+		us.UserService_ListUserServiceRoutes_FullMethodName:   selfOr("user_id", constants.PermUserManage),
+		us.UserService_CreateUserServiceRoute_FullMethodName:  userManage,
+		us.UserService_UpdateUserServiceRoute_FullMethodName:  userManage,
+		us.UserService_DeleteUserServiceRoute_FullMethodName:  userManage,
+		us.UserService_ResolveUserServiceRoute_FullMethodName: selfOr("user_id", constants.PermUserManage),
+
+		us.UserService_GetAvailableOperators_FullMethodName:      authenticated,
+		us.UserService_UpdateOperatorAvailability_FullMethodName: {Permission: constants.PermConsultationJoin},
+		us.UserService_UpdateOperatorStatus_FullMethodName:       {Permission: constants.PermOperatorVerify},
+		us.UserService_VerifyOperator_FullMethodName:             {Permission: constants.PermOperatorVerify},
+		us.UserService_GetOperatorStats_FullMethodName:           {Permission: constants.PermOperatorStats},
+	}
+}
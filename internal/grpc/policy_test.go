@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/middleware"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy_CoversAllMethods(t *testing.T) {
+	policy := Policy()
+	desc := user_service.UserService_ServiceDesc
+	for _, m := range desc.Methods {
+		full := "/" + desc.ServiceName + "/" + m.MethodName
+		if _, ok := policy[full]; !ok {
+			t.Errorf("no policy rule for %s", full)
+		}
+	}
+}
+
+func TestPolicy_UnaryAuthDecisions(t *testing.T) {
+	cfg, err := auth.NewConfig("test-secret", "15m", "24h")
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	interceptor := middleware.UnaryAuth(cfg, auth.NewMemoryBlacklist(), Policy())
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	token := func(userID, role string) context.Context {
+		access, err := cfg.GenerateAccess(userID, userID+"@example.com", role, "", false, "")
+		if err != nil {
+			t.Fatalf("GenerateAccess: %v", err)
+		}
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+access))
+	}
+	limited := func(userID, role string) context.Context {
+		access, err := cfg.GenerateLimitedAccess(userID, userID+"@example.com", role, "")
+		if err != nil {
+			t.Fatalf("GenerateLimitedAccess: %v", err)
+		}
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+access))
+	}
+	const self = "11111111-1111-1111-1111-111111111111"
+	const other = "22222222-2222-2222-2222-222222222222"
+
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    interface{}
+		want   codes.Code
+	}{
+		{"public without token", context.Background(), user_service.UserService_Login_FullMethodName, &user_service.LoginRequest{}, codes.OK},
+		{"anonymous read", context.Background(), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: self}, codes.Unauthenticated},
+		{"self read", token(self, constants.RoleClient), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: self}, codes.OK},
+		{"foreign read", token(self, constants.RoleClient), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: other}, codes.PermissionDenied},
+		{"admin read", token(self, constants.RoleAdmin), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: other}, codes.OK},
+		{"operator verify", token(self, constants.RoleOperator), user_service.UserService_VerifyOperator_FullMethodName, &user_service.VerifyOperatorRequest{Id: other}, codes.PermissionDenied},
+		{"client delete", token(self, constants.RoleClient), user_service.UserService_DeleteUser_FullMethodName, &user_service.DeleteUserRequest{Id: self}, codes.PermissionDenied},
+		{"limited self read", limited(self, constants.RoleClient), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: self}, codes.OK},
+		{"limited me", limited(self, constants.RoleClient), user_service.UserService_GetMe_FullMethodName, &user_service.GetMeRequest{}, codes.OK},
+		{"limited admin read", limited(self, constants.RoleAdmin), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: other}, codes.PermissionDenied},
+		{"limited devices", limited(self, constants.RoleClient), user_service.UserService_ListMyDevices_FullMethodName, &user_service.ListMyDevicesRequest{}, codes.PermissionDenied},
+		{"service ends sessions", token(self, constants.RoleService), user_service.UserService_EndSessionsByExternalID_FullMethodName, &user_service.EndSessionsByExternalIDRequest{}, codes.OK},
+		{"service heartbeat", token(self, constants.RoleService), user_service.UserService_DeviceHeartbeat_FullMethodName, &user_service.DeviceHeartbeatRequest{}, codes.OK},
+		{"service foreign read", token(self, constants.RoleService), user_service.UserService_GetUser_FullMethodName, &user_service.GetUserRequest{Id: other}, codes.PermissionDenied},
+		{"unknown method", token(self, constants.RoleAdmin), "/user_service.UserService/Unknown", nil, codes.PermissionDenied},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := interceptor(tc.ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, ok)
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("got %v, want %v (err: %v)", got, tc.want, err)
+			}
+		})
+	}
+}
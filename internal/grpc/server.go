@@ -3,17 +3,25 @@ package grpc
 import (
 	"context"
 	"errors"
+	"math"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/psds-microservice/user-service/internal/auth"
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/middleware"
 	"github.com/psds-microservice/user-service/internal/service"
 	"github.com/psds-microservice/user-service/internal/validator"
+	"github.com/psds-microservice/user-service/pkg/constants"
 	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -24,10 +32,25 @@ type Deps struct {
 	Operator service.OperatorService
 	Presence service.PresenceService
 	Session  service.SessionService
+	Device   service.DeviceService
+	Route    service.RouteService
+	Token    service.TokenService
+
+	PasswordReset     service.PasswordResetService
+	EmailVerification service.EmailVerificationService
+	MFA               service.MFAService
+	Audit             service.AuditService
 
 	JWTConfig auth.Config
-	Blacklist *auth.Blacklist
+	Blacklist auth.Blacklist
 	Validate  *validator.Validator
+
+	// TrustedProxyHops — число доверенных прокси перед HTTP-сервером (балансировщик): столько последних
+	// адресов x-forwarded-for не считаются адресом клиента.
+	TrustedProxyHops int
+	// GatewayIPs — адреса, с которых grpc-gateway этого процесса вызывает gRPC, кроме loopback
+	// (APP_HOST, если сервер слушает не 0.0.0.0): вызовы с них тоже несут x-forwarded-for.
+	GatewayIPs []net.IP
 }
 
 // Server implements user_service.UserServiceServer.
@@ -41,26 +64,29 @@ func NewServer(deps Deps) *Server {
 	return &Server{Deps: deps}
 }
 
-func (s *Server) userIDFromContext(ctx context.Context) string {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return ""
+// claimsFromContext возвращает claims валидного, не отозванного access-токена или nil.
+// Обычно claims уже положил middleware.UnaryAuth; разбор metadata — для вызовов в обход интерсептора.
+func (s *Server) claimsFromContext(ctx context.Context) *auth.Claims {
+	if claims := middleware.GetClaims(ctx); claims != nil {
+		return claims
 	}
-	vals := md.Get("authorization")
-	if len(vals) == 0 {
-		vals = md.Get("Authorization")
+	token := s.bearerFromContext(ctx)
+	if token == "" {
+		return nil
 	}
-	for _, v := range vals {
-		if strings.HasPrefix(v, "Bearer ") {
-			claims, err := s.JWTConfig.ValidateAccess(strings.TrimPrefix(v, "Bearer "))
-			if err != nil {
-				return ""
-			}
-			if s.Blacklist != nil && s.Blacklist.Contains(claims.ID) {
-				return ""
-			}
-			return claims.UserID
-		}
+	claims, err := s.JWTConfig.ValidateAccess(token)
+	if err != nil {
+		return nil
+	}
+	if revoked, err := auth.IsRevoked(ctx, s.Blacklist, claims); err != nil || revoked {
+		return nil
+	}
+	return claims
+}
+
+func (s *Server) userIDFromContext(ctx context.Context) string {
+	if claims := s.claimsFromContext(ctx); claims != nil {
+		return claims.UserID
 	}
 	return ""
 }
@@ -81,25 +107,125 @@ func (s *Server) bearerFromContext(ctx context.Context) string {
 	return ""
 }
 
+// clientIP — адрес клиента для ограничения перебора паролей. Прямой gRPC-вызов — адрес peer.
+// Через grpc-gateway (peer — loopback или GatewayIPs) — из x-forwarded-for, который gateway дополняет
+// адресом своего TCP-клиента; адреса левее TrustedProxyHops доверенных прокси клиент может подделать.
+func (s *Server) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if !s.fromGateway(net.ParseIP(host)) {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, v := range md.Get("x-forwarded-for") {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				hops = append(hops, h)
+			}
+		}
+	}
+	if len(hops) == 0 {
+		return host
+	}
+	i := len(hops) - 1 - s.TrustedProxyHops
+	if i < 0 {
+		i = 0
+	}
+	return hops[i]
+}
+
+func (s *Server) fromGateway(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, g := range s.GatewayIPs {
+		if g.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedStatus — ResourceExhausted с RetryInfo; gateway переводит его в 429 и заголовок Retry-After.
+func lockedStatus(locked *errs.LockedError) error {
+	secs := int64(math.Ceil(locked.RetryAfter.Seconds()))
+	st := status.New(codes.ResourceExhausted, locked.Error())
+	if withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(secs) * time.Second)}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
 func (s *Server) mapError(err error) error {
 	if err == nil {
 		return nil
 	}
+	var locked *errs.LockedError
+	if errors.As(err, &locked) {
+		return lockedStatus(locked)
+	}
 	switch {
 	case errors.Is(err, errs.ErrInvalidUserID),
-		errors.Is(err, errs.ErrInvalidOperatorStatus):
+		errors.Is(err, errs.ErrInvalidOperatorStatus),
+		errors.Is(err, errs.ErrInvalidCursor),
+		errors.Is(err, errs.ErrInvalidUpdateMask),
+		errors.Is(err, errs.ErrInvalidRole),
+		errors.Is(err, errs.ErrInvalidUserStatus),
+		errors.Is(err, errs.ErrInvalidSort),
+		errors.Is(err, errs.ErrInvalidResetToken),
+		errors.Is(err, errs.ErrInvalidVerificationToken):
 		return status.Error(codes.InvalidArgument, err.Error())
-	case errors.Is(err, errs.ErrUserNotFound):
+	case errors.Is(err, errs.ErrUserNotFound),
+		errors.Is(err, errs.ErrSessionNotFound),
+		errors.Is(err, errs.ErrDeviceNotFound),
+		errors.Is(err, errs.ErrServiceRouteNotFound),
+		errors.Is(err, errs.ErrNoActiveServiceRoute):
 		return status.Error(codes.NotFound, err.Error())
 	case errors.Is(err, errs.ErrInvalidCredentials):
 		return status.Error(codes.Unauthenticated, "invalid credentials")
-	case errors.Is(err, errs.ErrUserAlreadyExists):
+	case errors.Is(err, errs.ErrInvalidRefreshToken),
+		errors.Is(err, errs.ErrRefreshTokenReused),
+		errors.Is(err, errs.ErrInvalidMFAToken),
+		errors.Is(err, errs.ErrInvalidMFACode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errs.ErrUserAlreadyExists),
+		errors.Is(err, errs.ErrAdminExists),
+		errors.Is(err, errs.ErrSessionAlreadyRated),
+		errors.Is(err, errs.ErrServiceRouteExists):
 		return status.Error(codes.AlreadyExists, err.Error())
 	case errors.Is(err, errs.ErrNotOperator),
 		errors.Is(err, errs.ErrOperatorNotVerifiedOrAvailable),
 		errors.Is(err, errs.ErrClientStreamingLimit),
-		errors.Is(err, errs.ErrMaxSessionsReached):
+		errors.Is(err, errs.ErrMaxSessionsReached),
+		errors.Is(err, errs.ErrSessionNotFinished),
+		errors.Is(err, errs.ErrConsultationOperatorNotFound),
+		errors.Is(err, errs.ErrEmailNotVerified),
+		errors.Is(err, errs.ErrEmailAlreadyVerified),
+		errors.Is(err, errs.ErrMFAAlreadyEnabled),
+		errors.Is(err, errs.ErrMFANotEnrolled),
+		errors.Is(err, errs.ErrMFARequired),
+		errors.Is(err, errs.ErrLastAdmin):
 		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errs.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, errs.ErrVerificationCooldown):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, errs.ErrNotConsultationClient),
+		errors.Is(err, errs.ErrRoleNotAllowed),
+		errors.Is(err, errs.ErrInvalidCurrentPassword),
+		errors.Is(err, errs.ErrUserBlocked),
+		errors.Is(err, errs.ErrUserInactive):
+		return status.Error(codes.PermissionDenied, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal error")
 	}
@@ -115,6 +241,35 @@ func toProtoUserResponse(r *dto.UserResponse) *user_service.UserResponse {
 		Email:    r.Email,
 		Phone:    r.Phone,
 		Status:   r.Status,
+
+		EmailVerified: r.EmailVerified,
+		MfaEnabled:    r.MFAEnabled,
+		Etag:          etag(r.Version),
+		Role:          r.Role,
+		IsActive:      r.IsActive,
+		MaxSessions:   int32(r.MaxSessions),
+		TotalSessions: int32(r.TotalSessions),
+		Profile: &user_service.UserProfile{
+			FullName:       r.FullName,
+			AvatarUrl:      r.AvatarURL,
+			Timezone:       r.Timezone,
+			Language:       r.Language,
+			Company:        r.Company,
+			Specialization: r.Specialization,
+		},
+		Presence: &user_service.UserPresence{
+			IsOnline:     r.IsOnline,
+			LastSeenAt:   optionalTimestamp(r.LastSeenAt),
+			LastActivity: optionalTimestamp(r.LastActivity),
+			LastLogin:    optionalTimestamp(r.LastLogin),
+		},
+	}
+	if r.Role == constants.RoleOperator {
+		out.Operator = &user_service.OperatorInfo{
+			Status:      r.OperatorStatus,
+			IsAvailable: r.IsAvailable,
+			Rating:      r.Rating,
+		}
 	}
 	if !r.CreatedAt.IsZero() {
 		out.CreatedAt = timestamppb.New(r.CreatedAt)
@@ -125,6 +280,53 @@ func toProtoUserResponse(r *dto.UserResponse) *user_service.UserResponse {
 	return out
 }
 
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// userCard — публичная карточка пользователя: без контактов (email, телефон) и служебных полей
+// учётной записи (подтверждение email, 2FA, вход, ETag).
+func userCard(u *user_service.UserResponse) *user_service.UserResponse {
+	if u == nil {
+		return nil
+	}
+	u.Email = ""
+	u.Phone = ""
+	u.EmailVerified = false
+	u.MfaEnabled = false
+	u.Etag = ""
+	if u.Presence != nil {
+		u.Presence.LastActivity = nil
+		u.Presence.LastLogin = nil
+	}
+	return u
+}
+
+// seesPrivateFields — видит ли вызывающий полную запись пользователя userID: сам пользователь
+// или полный (не ограниченный) токен с user:manage.
+func (s *Server) seesPrivateFields(ctx context.Context, userID string) bool {
+	claims := s.claimsFromContext(ctx)
+	if claims == nil {
+		return false
+	}
+	if claims.UserID == userID {
+		return true
+	}
+	return !claims.Limited && middleware.RoleHasPermission(claims.Role, constants.PermUserManage)
+}
+
+func toProtoAuthResponse(r *dto.TokenResponse) *user_service.AuthResponse {
+	return &user_service.AuthResponse{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		ExpiresIn:    int32(r.ExpiresIn),
+		User:         toProtoUserResponse(r.User),
+	}
+}
+
 func toProtoSessionResponse(r *dto.UserSessionResponse) *user_service.UserSessionResponse {
 	if r == nil {
 		return nil
@@ -135,6 +337,7 @@ func toProtoSessionResponse(r *dto.UserSessionResponse) *user_service.UserSessio
 		SessionType:       r.SessionType,
 		SessionExternalId: r.SessionExternalID,
 		ParticipantRole:   r.ParticipantRole,
+		DurationSeconds:   int32(r.DurationSeconds),
 	}
 	if !r.JoinedAt.IsZero() {
 		out.JoinedAt = timestamppb.New(r.JoinedAt)
@@ -142,5 +345,40 @@ func toProtoSessionResponse(r *dto.UserSessionResponse) *user_service.UserSessio
 	if r.LeftAt != nil {
 		out.LeftAt = timestamppb.New(*r.LeftAt)
 	}
+	if r.ConsultationRating != nil {
+		out.ConsultationRating = int32(*r.ConsultationRating)
+		out.ConsultationFeedback = r.ConsultationFeedback
+	}
+	return out
+}
+
+func toProtoDeviceResponse(r *dto.UserDeviceResponse) *user_service.DeviceResponse {
+	if r == nil {
+		return nil
+	}
+	out := &user_service.DeviceResponse{
+		Id:                r.ID,
+		UserId:            r.UserID,
+		DeviceId:          r.DeviceID,
+		DeviceType:        r.DeviceType,
+		UserAgent:         r.UserAgent,
+		IpAddress:         r.IPAddress,
+		ConnectionId:      r.ConnectionID,
+		IsConnected:       r.IsConnected,
+		SupportsWebrtc:    r.SupportsWebRTC,
+		SupportsWebsocket: r.SupportsWebSocket,
+	}
+	if r.BandwidthLimit != nil {
+		out.BandwidthLimit = int32(*r.BandwidthLimit)
+	}
+	if r.LastHeartbeat != nil {
+		out.LastHeartbeat = timestamppb.New(*r.LastHeartbeat)
+	}
+	if !r.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(r.CreatedAt)
+	}
+	if !r.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(r.UpdatedAt)
+	}
 	return out
 }
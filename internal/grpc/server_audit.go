@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UnaryAuditActor кладёт в контекст инициатора для журнала аудита: пользователя из claims, адрес клиента
+// и User-Agent. Ставится после middleware.UnaryAuth, чтобы claims уже были в контексте.
+func (s *Server) UnaryAuditActor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = audit.WithActor(ctx, audit.Actor{
+			UserID:    s.userIDFromContext(ctx),
+			ClientIP:  s.clientIP(ctx),
+			UserAgent: userAgent(ctx),
+		})
+		return handler(ctx, req)
+	}
+}
+
+// userAgent — User-Agent HTTP-клиента (grpc-gateway передаёт его как grpcgateway-user-agent) или gRPC-клиента.
+func userAgent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func (s *Server) ListAuditEvents(ctx context.Context, req *user_service.ListAuditEventsRequest) (*user_service.ListAuditEventsResponse, error) {
+	filters := &dto.AuditFilters{
+		ActorID:  req.GetActorId(),
+		TargetID: req.GetTargetId(),
+		Action:   req.GetAction(),
+		Limit:    int(req.GetLimit()),
+		Cursor:   req.GetCursor(),
+	}
+	if req.GetFrom() != nil {
+		t := req.GetFrom().AsTime()
+		filters.From = &t
+	}
+	if req.GetTo() != nil {
+		t := req.GetTo().AsTime()
+		filters.To = &t
+	}
+	if err := s.Validate.ValidateAuditFilters(filters); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.Audit.ListAuditEvents(ctx, filters)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := &user_service.ListAuditEventsResponse{
+		Events:     make([]*user_service.AuditEvent, len(page.Events)),
+		NextCursor: page.NextCursor,
+	}
+	for i, ev := range page.Events {
+		out.Events[i] = toProtoAuditEvent(ev)
+	}
+	return out, nil
+}
+
+func toProtoAuditEvent(ev *dto.AuditEvent) *user_service.AuditEvent {
+	out := &user_service.AuditEvent{
+		Id:         ev.ID,
+		Action:     ev.Action,
+		ActorId:    ev.ActorID,
+		TargetType: ev.TargetType,
+		TargetId:   ev.TargetID,
+		ClientIp:   ev.ClientIP,
+		UserAgent:  ev.UserAgent,
+		Source:     ev.Source,
+		Reason:     ev.Reason,
+		CreatedAt:  timestamppb.New(ev.CreatedAt),
+	}
+	if len(ev.Changes) > 0 {
+		var m map[string]interface{}
+		if err := json.Unmarshal(ev.Changes, &m); err == nil {
+			out.Changes, _ = structpb.NewStruct(m)
+		}
+	}
+	return out
+}
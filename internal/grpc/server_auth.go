@@ -2,30 +2,56 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
 	"github.com/psds-microservice/user-service/pkg/gen/user_service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func (s *Server) Login(ctx context.Context, req *user_service.LoginRequest) (*user_service.AuthResponse, error) {
-	loginReq := &dto.LoginRequest{Email: req.GetEmail(), Password: req.GetPassword()}
+	loginReq := &dto.LoginRequest{Email: req.GetEmail(), Password: req.GetPassword(), DeviceID: req.GetDeviceId()}
 	if err := s.Validate.ValidateLoginRequest(loginReq); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	user, err := s.Auth.Login(ctx, loginReq.Email, loginReq.Password)
+	user, err := s.Auth.Login(ctx, loginReq.Email, loginReq.Password, s.clientIP(ctx))
 	if err != nil {
 		return nil, s.mapError(err)
 	}
-	access, refresh, err := s.JWTConfig.GeneratePair(user.ID, user.Email, user.Role, user.OperatorStatus, user.IsAvailable)
+	if user.MFAEnabled {
+		mfaToken, err := s.MFA.Challenge(ctx, user.ID, loginReq.DeviceID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to generate mfa token")
+		}
+		return &user_service.AuthResponse{MfaRequired: true, MfaToken: mfaToken}, nil
+	}
+	return s.issueTokens(ctx, user, loginReq.DeviceID)
+}
+
+// LoginVerifyMFA завершает вход пользователя с 2FA: одноразовый челлендж из Login + второй фактор.
+func (s *Server) LoginVerifyMFA(ctx context.Context, req *user_service.LoginVerifyMFARequest) (*user_service.AuthResponse, error) {
+	if strings.TrimSpace(req.GetMfaToken()) == "" || strings.TrimSpace(req.GetCode()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: mfa_token and code are required")
+	}
+	user, deviceID, err := s.MFA.VerifyChallenge(ctx, req.GetMfaToken(), req.GetCode(), s.clientIP(ctx))
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return s.issueTokens(ctx, user, deviceID)
+}
+
+func (s *Server) issueTokens(ctx context.Context, user *dto.UserResponse, deviceID string) (*user_service.AuthResponse, error) {
+	pair, err := s.Token.Issue(ctx, user, deviceID)
+	if errors.Is(err, errs.ErrEmailNotVerified) {
+		return nil, s.mapError(err)
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate tokens")
 	}
-	return &user_service.AuthResponse{
-		AccessToken: access, RefreshToken: refresh, ExpiresIn: 900,
-		User: toProtoUserResponse(user),
-	}, nil
+	return toProtoAuthResponse(pair), nil
 }
 
 func (s *Server) Refresh(ctx context.Context, req *user_service.RefreshRequest) (*user_service.AuthResponse, error) {
@@ -33,29 +59,82 @@ func (s *Server) Refresh(ctx context.Context, req *user_service.RefreshRequest)
 	if err := s.Validate.ValidateRefreshRequest(refreshReq); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	userID, err := s.JWTConfig.ValidateRefresh(refreshReq.RefreshToken)
+	pair, err := s.Token.Rotate(ctx, refreshReq.RefreshToken)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		return nil, s.mapError(err)
 	}
-	user, err := s.User.GetUser(ctx, userID)
-	if err != nil || user == nil {
+	return toProtoAuthResponse(pair), nil
+}
+
+func (s *Server) Logout(ctx context.Context, req *user_service.LogoutRequest) (*user_service.LogoutResponse, error) {
+	if claims := s.claimsFromContext(ctx); claims != nil {
+		if s.Blacklist != nil && claims.ExpiresAt != nil {
+			if err := s.Blacklist.Add(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+				return nil, status.Error(codes.Unavailable, "failed to revoke token")
+			}
+		}
+		if claims.SessionID != "" {
+			if err := s.Token.RevokeFamily(ctx, claims.SessionID); err != nil {
+				return nil, s.mapError(err)
+			}
+		}
+	}
+	if rt := req.GetRefreshToken(); rt != "" {
+		if err := s.Token.RevokeByToken(ctx, rt); err != nil {
+			return nil, s.mapError(err)
+		}
+	}
+	return &user_service.LogoutResponse{}, nil
+}
+
+func (s *Server) RequestPasswordReset(ctx context.Context, req *user_service.RequestPasswordResetRequest) (*user_service.RequestPasswordResetResponse, error) {
+	resetReq := &dto.PasswordResetRequest{Email: req.GetEmail()}
+	if err := s.Validate.ValidatePasswordResetRequest(resetReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.PasswordReset.RequestReset(ctx, resetReq.Email); err != nil {
 		return nil, s.mapError(err)
 	}
-	access, refresh, err := s.JWTConfig.GeneratePair(user.ID, user.Email, user.Role, user.OperatorStatus, user.IsAvailable)
+	return &user_service.RequestPasswordResetResponse{}, nil
+}
+
+func (s *Server) ConfirmPasswordReset(ctx context.Context, req *user_service.ConfirmPasswordResetRequest) (*user_service.ConfirmPasswordResetResponse, error) {
+	confirm := &dto.PasswordResetConfirm{Token: req.GetToken(), NewPassword: req.GetNewPassword()}
+	if err := s.Validate.ValidatePasswordResetConfirm(confirm); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.PasswordReset.ConfirmReset(ctx, confirm.Token, confirm.NewPassword); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.ConfirmPasswordResetResponse{}, nil
+}
+
+func (s *Server) VerifyEmail(ctx context.Context, req *user_service.VerifyEmailRequest) (*user_service.UserResponse, error) {
+	if strings.TrimSpace(req.GetToken()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: token is required")
+	}
+	user, err := s.EmailVerification.Verify(ctx, req.GetToken())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to generate tokens")
+		return nil, s.mapError(err)
 	}
-	return &user_service.AuthResponse{
-		AccessToken: access, RefreshToken: refresh, ExpiresIn: 900,
-		User: toProtoUserResponse(user),
-	}, nil
+	return toProtoUserResponse(user), nil
 }
 
-func (s *Server) Logout(ctx context.Context, req *user_service.LogoutRequest) (*user_service.LogoutResponse, error) {
-	if token := s.bearerFromContext(ctx); token != "" {
-		if claims, err := s.JWTConfig.ValidateAccess(token); err == nil && s.Blacklist != nil && claims.ExpiresAt != nil {
-			s.Blacklist.Add(claims.ID, claims.ExpiresAt.Time)
+// ResendVerification: с access-токеном письмо уходит вызывающему (ошибки cooldown и «уже подтверждён»
+// возвращаются), без токена — по email с одинаковым ответом для любого адреса.
+func (s *Server) ResendVerification(ctx context.Context, req *user_service.ResendVerificationRequest) (*user_service.ResendVerificationResponse, error) {
+	if userID := s.userIDFromContext(ctx); userID != "" {
+		if err := s.EmailVerification.ResendForUser(ctx, userID); err != nil {
+			return nil, s.mapError(err)
 		}
+		return &user_service.ResendVerificationResponse{}, nil
 	}
-	return &user_service.LogoutResponse{}, nil
+	resendReq := &dto.ResendVerificationRequest{Email: req.GetEmail()}
+	if err := s.Validate.ValidateResendVerificationRequest(resendReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.EmailVerification.ResendForEmail(ctx, resendReq.Email); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.ResendVerificationResponse{}, nil
 }
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) RegisterDevice(ctx context.Context, req *user_service.RegisterDeviceRequest) (*user_service.DeviceResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	regReq := &dto.RegisterDeviceRequest{
+		DeviceID:          req.GetDeviceId(),
+		DeviceType:        req.GetDeviceType(),
+		UserAgent:         req.GetUserAgent(),
+		IPAddress:         req.GetIpAddress(),
+		SupportsWebRTC:    req.GetSupportsWebrtc(),
+		SupportsWebSocket: req.GetSupportsWebsocket(),
+		BandwidthLimit:    int(req.GetBandwidthLimit()),
+	}
+	if err := s.Validate.ValidateRegisterDeviceRequest(regReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	device, err := s.Device.RegisterDevice(ctx, userID, regReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoDeviceResponse(device), nil
+}
+
+func (s *Server) ListMyDevices(ctx context.Context, req *user_service.ListMyDevicesRequest) (*user_service.ListDevicesResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	list, err := s.Device.ListDevices(ctx, userID)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := &user_service.ListDevicesResponse{
+		Devices: make([]*user_service.DeviceResponse, len(list)),
+	}
+	for i := range list {
+		out.Devices[i] = toProtoDeviceResponse(list[i])
+	}
+	return out, nil
+}
+
+func (s *Server) RemoveDevice(ctx context.Context, req *user_service.RemoveDeviceRequest) (*user_service.RemoveDeviceResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if strings.TrimSpace(req.GetDeviceId()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: device_id is required")
+	}
+	if err := s.Device.RemoveDevice(ctx, userID, req.GetDeviceId()); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.RemoveDeviceResponse{Success: true}, nil
+}
+
+func (s *Server) ConnectDevice(ctx context.Context, req *user_service.ConnectDeviceRequest) (*user_service.DeviceResponse, error) {
+	connReq := &dto.ConnectDeviceRequest{
+		UserID:       req.GetUserId(),
+		DeviceID:     req.GetDeviceId(),
+		ConnectionID: req.GetConnectionId(),
+		IPAddress:    req.GetIpAddress(),
+		UserAgent:    req.GetUserAgent(),
+	}
+	if err := s.Validate.ValidateConnectDeviceRequest(connReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	device, err := s.Device.ConnectDevice(ctx, connReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoDeviceResponse(device), nil
+}
+
+func (s *Server) DisconnectDevice(ctx context.Context, req *user_service.DisconnectDeviceRequest) (*user_service.DeviceResponse, error) {
+	if strings.TrimSpace(req.GetConnectionId()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: connection_id is required")
+	}
+	device, err := s.Device.DisconnectDevice(ctx, req.GetConnectionId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoDeviceResponse(device), nil
+}
+
+func (s *Server) DeviceHeartbeat(ctx context.Context, req *user_service.DeviceHeartbeatRequest) (*user_service.DeviceResponse, error) {
+	if strings.TrimSpace(req.GetConnectionId()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: connection_id is required")
+	}
+	device, err := s.Device.Heartbeat(ctx, req.GetConnectionId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoDeviceResponse(device), nil
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) EnrollMFA(ctx context.Context, req *user_service.EnrollMFARequest) (*user_service.EnrollMFAResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	enrollment, err := s.MFA.Enroll(ctx, userID)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.EnrollMFAResponse{Secret: enrollment.Secret, OtpauthUri: enrollment.URI}, nil
+}
+
+func (s *Server) ConfirmMFA(ctx context.Context, req *user_service.ConfirmMFARequest) (*user_service.ConfirmMFAResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if strings.TrimSpace(req.GetCode()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: code is required")
+	}
+	recovery, err := s.MFA.Confirm(ctx, userID, req.GetCode())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.ConfirmMFAResponse{RecoveryCodes: recovery}, nil
+}
+
+func (s *Server) DisableMFA(ctx context.Context, req *user_service.DisableMFARequest) (*user_service.DisableMFAResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if strings.TrimSpace(req.GetCode()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: code is required")
+	}
+	if err := s.MFA.Disable(ctx, userID, req.GetCode()); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.DisableMFAResponse{}, nil
+}
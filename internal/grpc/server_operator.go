@@ -21,9 +21,13 @@ func (s *Server) GetAvailableOperators(ctx context.Context, req *user_service.Ge
 	if err != nil {
 		return nil, s.mapError(err)
 	}
+	// Список видят все аутентифицированные пользователи: контакты операторов — только admin.
 	operators := make([]*user_service.UserResponse, len(list))
 	for i := range list {
 		operators[i] = toProtoUserResponse(list[i])
+		if !s.seesPrivateFields(ctx, list[i].ID) {
+			operators[i] = userCard(operators[i])
+		}
 	}
 	return &user_service.GetAvailableOperatorsResponse{Operators: operators, Total: total}, nil
 }
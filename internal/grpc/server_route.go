@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/pkg/gen/user_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) ListUserServiceRoutes(ctx context.Context, req *user_service.ListUserServiceRoutesRequest) (*user_service.ListUserServiceRoutesResponse, error) {
+	list, err := s.Route.ListRoutes(ctx, req.GetUserId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := &user_service.ListUserServiceRoutesResponse{
+		Services: make([]*user_service.UserServiceRoute, len(list)),
+	}
+	for i := range list {
+		out.Services[i] = toProtoServiceRoute(list[i])
+	}
+	return out, nil
+}
+
+func (s *Server) CreateUserServiceRoute(ctx context.Context, req *user_service.UserServiceRouteRequest) (*user_service.UserServiceRoute, error) {
+	routeReq := fromProtoServiceRouteRequest(req)
+	if err := s.Validate.ValidateServiceRouteRequest(routeReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := s.Route.CreateRoute(ctx, routeReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoServiceRoute(resp), nil
+}
+
+func (s *Server) UpdateUserServiceRoute(ctx context.Context, req *user_service.UserServiceRouteRequest) (*user_service.UserServiceRoute, error) {
+	routeReq := fromProtoServiceRouteRequest(req)
+	if strings.TrimSpace(routeReq.ID) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: id is required")
+	}
+	if err := s.Validate.ValidateServiceRouteRequest(routeReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := s.Route.UpdateRoute(ctx, routeReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoServiceRoute(resp), nil
+}
+
+func (s *Server) DeleteUserServiceRoute(ctx context.Context, req *user_service.DeleteUserServiceRouteRequest) (*user_service.DeleteUserServiceRouteResponse, error) {
+	if err := s.Route.DeleteRoute(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.DeleteUserServiceRouteResponse{Success: true}, nil
+}
+
+func (s *Server) ResolveUserServiceRoute(ctx context.Context, req *user_service.ResolveUserServiceRouteRequest) (*user_service.UserServiceRoute, error) {
+	if strings.TrimSpace(req.GetButton()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: button is required")
+	}
+	resp, err := s.Route.ResolveRoute(ctx, req.GetUserId(), req.GetButton(), time.Now())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoServiceRoute(resp), nil
+}
+
+// fromProtoServiceRouteRequest переводит запрос в dto. Без update_mask маской становятся переданные
+// непустые поля (AIP-134): PUT от старых клиентов не сбрасывает непереданные поля к значениям по умолчанию.
+func fromProtoServiceRouteRequest(req *user_service.UserServiceRouteRequest) *dto.UserServiceRouteRequest {
+	out := &dto.UserServiceRouteRequest{
+		ID:                 req.GetId(),
+		UserID:             req.GetUserId(),
+		ServiceName:        req.GetServiceName(),
+		ServiceType:        req.GetServiceType(),
+		BaseURL:            req.GetBaseUrl(),
+		APIEndpoint:        req.GetApiEndpoint(),
+		Port:               int(req.GetPort()),
+		UseSSL:             req.GetUseSsl(),
+		SSLCertificatePath: req.GetSslCertificatePath(),
+		RoutingKey:         req.GetRoutingKey(),
+		QueueName:          req.GetQueueName(),
+		TopicName:          req.GetTopicName(),
+		MaxBitrate:         int(req.GetMaxBitrate()),
+		MaxConnections:     int(req.GetMaxConnections()),
+		Priority:           int(req.GetPriority()),
+		IsActive:           req.IsActive,
+		EnabledButtons:     req.GetEnabledButtons(),
+	}
+	if sched := req.GetSchedule(); sched != nil {
+		out.Schedule = &dto.ServiceSchedule{
+			Always:    sched.GetAlways(),
+			Weekdays:  sched.GetWeekdays(),
+			StartTime: sched.GetStartTime(),
+			EndTime:   sched.GetEndTime(),
+		}
+	}
+	if p := req.GetParameters(); p != "" {
+		out.Parameters = json.RawMessage(p)
+	}
+	if paths := req.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		for _, p := range paths {
+			out.UpdateMask = append(out.UpdateMask, strings.TrimSpace(p))
+		}
+		return out
+	}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{dto.RouteFieldServiceName, out.ServiceName != ""},
+		{dto.RouteFieldServiceType, out.ServiceType != ""},
+		{dto.RouteFieldBaseURL, out.BaseURL != ""},
+		{dto.RouteFieldAPIEndpoint, out.APIEndpoint != ""},
+		{dto.RouteFieldPort, out.Port != 0},
+		{dto.RouteFieldUseSSL, out.UseSSL},
+		{dto.RouteFieldSSLCertificatePath, out.SSLCertificatePath != ""},
+		{dto.RouteFieldRoutingKey, out.RoutingKey != ""},
+		{dto.RouteFieldQueueName, out.QueueName != ""},
+		{dto.RouteFieldTopicName, out.TopicName != ""},
+		{dto.RouteFieldMaxBitrate, out.MaxBitrate != 0},
+		{dto.RouteFieldMaxConnections, out.MaxConnections != 0},
+		{dto.RouteFieldPriority, out.Priority != 0},
+		{dto.RouteFieldIsActive, out.IsActive != nil},
+		{dto.RouteFieldEnabledButtons, len(out.EnabledButtons) > 0},
+		{dto.RouteFieldSchedule, out.Schedule != nil},
+		{dto.RouteFieldParameters, len(out.Parameters) > 0},
+	} {
+		if f.set {
+			out.UpdateMask = append(out.UpdateMask, f.name)
+		}
+	}
+	return out
+}
+
+func toProtoServiceRoute(r *dto.UserServiceRouteResponse) *user_service.UserServiceRoute {
+	if r == nil {
+		return nil
+	}
+	out := &user_service.UserServiceRoute{
+		Id:                 r.ID,
+		UserId:             r.UserID,
+		ServiceName:        r.ServiceName,
+		ServiceType:        r.ServiceType,
+		BaseUrl:            r.BaseURL,
+		ApiEndpoint:        r.APIEndpoint,
+		Port:               int32(r.Port),
+		UseSsl:             r.UseSSL,
+		SslCertificatePath: r.SSLCertificatePath,
+		RoutingKey:         r.RoutingKey,
+		QueueName:          r.QueueName,
+		TopicName:          r.TopicName,
+		MaxBitrate:         int32(r.MaxBitrate),
+		MaxConnections:     int32(r.MaxConnections),
+		Priority:           int32(r.Priority),
+		IsActive:           r.IsActive,
+		EnabledButtons:     r.EnabledButtons,
+		Parameters:         string(r.Parameters),
+	}
+	if r.Schedule != nil {
+		out.Schedule = &user_service.ServiceSchedule{
+			Always:    r.Schedule.Always,
+			Weekdays:  r.Schedule.Weekdays,
+			StartTime: r.Schedule.StartTime,
+			EndTime:   r.Schedule.EndTime,
+		}
+	}
+	if !r.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(r.CreatedAt)
+	}
+	if !r.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(r.UpdatedAt)
+	}
+	return out
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"strings"
 
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/pkg/gen/user_service"
@@ -66,9 +67,51 @@ func (s *Server) ValidateUserSession(ctx context.Context, req *user_service.Vali
 	if err := s.Validate.ValidateSessionValidateRequest(req.GetUserId(), req.GetSessionExternalId()); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	allowed, err := s.Session.ValidateUserSession(ctx, req.GetUserId(), req.GetSessionExternalId(), req.GetParticipantRole())
+	allowed, err := s.Session.ValidateUserSession(ctx, req.GetUserId(), req.GetSessionExternalId(), req.GetSessionType(), req.GetParticipantRole())
 	if err != nil {
 		return nil, s.mapError(err)
 	}
 	return &user_service.ValidateUserSessionResponse{Allowed: allowed}, nil
 }
+
+func (s *Server) EndSession(ctx context.Context, req *user_service.EndSessionRequest) (*user_service.UserSessionResponse, error) {
+	if err := s.Validate.ValidateEndSessionRequest(req.GetId(), req.GetSessionId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	session, err := s.Session.EndSession(ctx, req.GetId(), req.GetSessionId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoSessionResponse(session), nil
+}
+
+func (s *Server) EndSessionsByExternalID(ctx context.Context, req *user_service.EndSessionsByExternalIDRequest) (*user_service.EndSessionsByExternalIDResponse, error) {
+	if strings.TrimSpace(req.GetSessionExternalId()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "validation: session_external_id is required")
+	}
+	ended, err := s.Session.EndSessionsByExternalID(ctx, req.GetSessionExternalId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.EndSessionsByExternalIDResponse{Ended: ended}, nil
+}
+
+func (s *Server) RateConsultation(ctx context.Context, req *user_service.RateConsultationRequest) (*user_service.UserSessionResponse, error) {
+	userID := s.userIDFromContext(ctx)
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	rateReq := &dto.RateConsultationRequest{
+		SessionID: req.GetSessionId(),
+		Rating:    int(req.GetRating()),
+		Feedback:  req.GetFeedback(),
+	}
+	if err := s.Validate.ValidateRateConsultationRequest(rateReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	session, err := s.Session.RateConsultation(ctx, userID, rateReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoSessionResponse(session), nil
+}
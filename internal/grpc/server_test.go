@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestToProtoUserResponse_OperatorAndCard(t *testing.T) {
+	seen := time.Now()
+	r := &dto.UserResponse{
+		ID:             "6f1c3c5e-0000-4000-8000-000000000001",
+		Email:          "op@example.com",
+		Phone:          "+100",
+		EmailVerified:  true,
+		Role:           constants.RoleOperator,
+		OperatorStatus: constants.OperatorStatusVerified,
+		IsAvailable:    true,
+		Rating:         4.5,
+		MaxSessions:    3,
+		FullName:       "Olga",
+		Specialization: "billing",
+		IsOnline:       true,
+		LastSeenAt:     &seen,
+		LastLogin:      &seen,
+		Version:        2,
+	}
+	out := toProtoUserResponse(r)
+	if out.GetRole() != constants.RoleOperator || out.GetMaxSessions() != 3 || out.GetEtag() != `"2"` {
+		t.Fatalf("unexpected top-level fields: %+v", out)
+	}
+	if op := out.GetOperator(); op.GetStatus() != constants.OperatorStatusVerified || !op.GetIsAvailable() || op.GetRating() != 4.5 {
+		t.Errorf("unexpected operator info: %+v", op)
+	}
+	if out.GetProfile().GetFullName() != "Olga" || out.GetPresence().GetLastSeenAt() == nil || !out.GetPresence().GetIsOnline() {
+		t.Errorf("unexpected profile/presence: %+v %+v", out.GetProfile(), out.GetPresence())
+	}
+
+	card := userCard(out)
+	if card.GetEmail() != "" || card.GetPhone() != "" || card.GetEmailVerified() || card.GetEtag() != "" || card.GetPresence().GetLastLogin() != nil {
+		t.Errorf("card leaks private fields: %+v", card)
+	}
+	if card.GetProfile().GetSpecialization() != "billing" || card.GetOperator().GetRating() != 4.5 || card.GetPresence().GetLastSeenAt() == nil {
+		t.Errorf("card lost public fields: %+v", card)
+	}
+
+	if client := toProtoUserResponse(&dto.UserResponse{Role: constants.RoleClient}); client.GetOperator() != nil {
+		t.Errorf("operator info set for client: %+v", client.GetOperator())
+	}
+}
+
+func TestClientIP_TrustsForwardedOnlyFromGateway(t *testing.T) {
+	s := NewServer(Deps{GatewayIPs: []net.IP{net.ParseIP("10.0.0.5")}})
+	call := func(peerAddr string, xff ...string) string {
+		addr, err := net.ResolveTCPAddr("tcp", peerAddr)
+		if err != nil {
+			t.Fatalf("resolve %s: %v", peerAddr, err)
+		}
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", strings.Join(xff, ", ")))
+		return s.clientIP(ctx)
+	}
+	if got := call("127.0.0.1:5000", "203.0.113.7"); got != "203.0.113.7" {
+		t.Errorf("loopback gateway: got %q", got)
+	}
+	// APP_HOST вне loopback: gateway подключается с адреса хоста, клиенты не сливаются в один счётчик.
+	if got := call("10.0.0.5:5000", "203.0.113.7"); got != "203.0.113.7" {
+		t.Errorf("gateway on APP_HOST: got %q", got)
+	}
+	if got := call("198.51.100.1:5000", "203.0.113.7"); got != "198.51.100.1" {
+		t.Errorf("direct gRPC client must not spoof x-forwarded-for: got %q", got)
+	}
+}
@@ -2,10 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
 
 	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/pkg/constants"
 	"github.com/psds-microservice/user-service/pkg/gen/user_service"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -15,6 +22,8 @@ func (s *Server) CreateUser(ctx context.Context, req *user_service.CreateUserReq
 		Email:    req.GetEmail(),
 		Phone:    req.GetPhone(),
 		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+		ActorID:  s.userIDFromContext(ctx),
 	}
 	if err := s.Validate.ValidateCreateUserRequest(createReq); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -26,6 +35,44 @@ func (s *Server) CreateUser(ctx context.Context, req *user_service.CreateUserReq
 	return toProtoUserResponse(resp), nil
 }
 
+// ListUsers — доступ проверяет политика (user:manage), см. policy.go.
+func (s *Server) ListUsers(ctx context.Context, req *user_service.ListUsersRequest) (*user_service.ListUsersResponse, error) {
+	filters := &dto.UserFilters{
+		Limit:          int(req.GetLimit()),
+		Cursor:         req.GetCursor(),
+		Sort:           req.GetSort(),
+		Status:         req.GetStatus(),
+		Role:           req.GetRole(),
+		Search:         req.GetSearch(),
+		OperatorStatus: req.GetOperatorStatus(),
+		IsOnline:       req.IsOnline,
+		IsAvailable:    req.IsAvailable,
+	}
+	if req.GetCreatedAfter() != nil {
+		t := req.GetCreatedAfter().AsTime()
+		filters.CreatedAfter = &t
+	}
+	if req.GetCreatedBefore() != nil {
+		t := req.GetCreatedBefore().AsTime()
+		filters.CreatedBefore = &t
+	}
+	if err := s.Validate.ValidateUserFilters(filters); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.User.ListUsers(ctx, filters)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := &user_service.ListUsersResponse{
+		Users:      make([]*user_service.UserResponse, len(page.Users)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Users {
+		out.Users[i] = toProtoUserResponse(page.Users[i])
+	}
+	return out, nil
+}
+
 func (s *Server) GetUser(ctx context.Context, req *user_service.GetUserRequest) (*user_service.UserResponse, error) {
 	resp, err := s.User.GetUser(ctx, req.GetId())
 	if err != nil {
@@ -34,32 +81,186 @@ func (s *Server) GetUser(ctx context.Context, req *user_service.GetUserRequest)
 	return toProtoUserResponse(resp), nil
 }
 
+// GetUserCard отдаёт полную запись самому пользователю и admin, остальным — userCard.
+func (s *Server) GetUserCard(ctx context.Context, req *user_service.GetUserRequest) (*user_service.UserResponse, error) {
+	resp, err := s.User.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	out := toProtoUserResponse(resp)
+	if !s.seesPrivateFields(ctx, resp.ID) {
+		out = userCard(out)
+	}
+	return out, nil
+}
+
 func (s *Server) UpdateUser(ctx context.Context, req *user_service.UpdateUserRequest) (*user_service.UserResponse, error) {
-	updateReq := &dto.UpdateUserRequest{
-		ID:       req.GetId(),
-		Username: req.GetUsername(),
-		Email:    req.GetEmail(),
-		Phone:    req.GetPhone(),
-		Password: req.GetPassword(),
-		Status:   req.GetStatus(),
+	updateReq := updateRequestFromProto(req.GetId(), req)
+	version, err := expectedVersion(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.Validate.ValidateUpdateUserRequest(updateReq); err != nil {
+	updateReq.ExpectedVersion = version
+	if err := s.Validate.ValidateUpdateUserRequest(updateReq, dto.AdminUpdatableFields); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	resp, err := s.User.UpdateUser(ctx, updateReq)
 	if err != nil {
 		return nil, s.mapError(err)
 	}
+	s.revokeAfterUpdate(ctx, updateReq, resp)
 	return toProtoUserResponse(resp), nil
 }
 
+// revokeAfterUpdate отзывает токены пользователя, если обновление сменило пароль или лишило его
+// доступа (status blocked или inactive); ошибка отзыва не отменяет изменение.
+func (s *Server) revokeAfterUpdate(ctx context.Context, req *dto.UpdateUserRequest, resp *dto.UserResponse) {
+	for _, field := range req.UpdateMask {
+		if field == dto.UserFieldPassword || (field == dto.UserFieldStatus && resp.Status != constants.UserStatusActive) {
+			if err := s.Token.RevokeAllForUser(ctx, resp.ID); err != nil {
+				log.Printf("update user: revoke tokens of %s: %v", resp.ID, err)
+			}
+			return
+		}
+	}
+}
+
+// etag — ETag записи пользователя (строгий, по users.version).
+func etag(version int64) string {
+	if version == 0 {
+		return ""
+	}
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// expectedVersion — версия, которую клиент видел: поле etag или заголовок If-Match
+// (grpc-gateway передаёт его как grpcgateway-if-match). 0 — без проверки (пусто или "*").
+func expectedVersion(ctx context.Context, req *user_service.UpdateUserRequest) (int64, error) {
+	tag := req.GetEtag()
+	if tag == "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, key := range []string{"grpcgateway-if-match", "if-match"} {
+			if v := md.Get(key); len(v) > 0 {
+				tag = v[0]
+				break
+			}
+		}
+	}
+	tag = strings.TrimSpace(tag)
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid etag")
+	}
+	return version, nil
+}
+
+// updateRequestFromProto переводит запрос в dto. Без update_mask маской становятся непустые поля (AIP-134):
+// PUT от старых клиентов не стирает непереданные поля профиля.
+func updateRequestFromProto(id string, req *user_service.UpdateUserRequest) *dto.UpdateUserRequest {
+	out := &dto.UpdateUserRequest{
+		ID:             id,
+		Username:       req.GetUsername(),
+		Email:          req.GetEmail(),
+		Phone:          req.GetPhone(),
+		Password:       req.GetPassword(),
+		Status:         req.GetStatus(),
+		FullName:       req.GetFullName(),
+		AvatarURL:      req.GetAvatarUrl(),
+		Timezone:       req.GetTimezone(),
+		Language:       req.GetLanguage(),
+		Company:        req.GetCompany(),
+		Specialization: req.GetSpecialization(),
+	}
+	if paths := req.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		for _, p := range paths {
+			out.UpdateMask = append(out.UpdateMask, strings.TrimSpace(p))
+		}
+		return out
+	}
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{dto.UserFieldUsername, out.Username},
+		{dto.UserFieldEmail, out.Email},
+		{dto.UserFieldPhone, out.Phone},
+		{dto.UserFieldPassword, out.Password},
+		{dto.UserFieldStatus, out.Status},
+		{dto.UserFieldFullName, out.FullName},
+		{dto.UserFieldAvatarURL, out.AvatarURL},
+		{dto.UserFieldTimezone, out.Timezone},
+		{dto.UserFieldLanguage, out.Language},
+		{dto.UserFieldCompany, out.Company},
+		{dto.UserFieldSpecialization, out.Specialization},
+	} {
+		if f.value != "" {
+			out.UpdateMask = append(out.UpdateMask, f.name)
+		}
+	}
+	return out
+}
+
+// DeleteUser мягко удаляет пользователя и отзывает его токены.
 func (s *Server) DeleteUser(ctx context.Context, req *user_service.DeleteUserRequest) (*user_service.DeleteUserResponse, error) {
 	if err := s.User.DeleteUser(ctx, req.GetId()); err != nil {
 		return nil, s.mapError(err)
 	}
+	if err := s.Token.RevokeAllForUser(ctx, req.GetId()); err != nil {
+		log.Printf("delete user: revoke tokens of %s: %v", req.GetId(), err)
+	}
 	return &user_service.DeleteUserResponse{Success: true}, nil
 }
 
+func (s *Server) RestoreUser(ctx context.Context, req *user_service.RestoreUserRequest) (*user_service.UserResponse, error) {
+	resp, err := s.User.RestoreUser(ctx, req.GetId())
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return toProtoUserResponse(resp), nil
+}
+
+// PurgeUser обезличивает пользователя. Токены отзываются до очистки: семейства access-токенов
+// берутся из refresh_tokens, которые очистка удаляет.
+func (s *Server) PurgeUser(ctx context.Context, req *user_service.PurgeUserRequest) (*user_service.PurgeUserResponse, error) {
+	if err := s.Token.RevokeAllForUser(ctx, req.GetId()); err != nil && !errors.Is(err, errs.ErrInvalidUserID) {
+		log.Printf("purge user: revoke tokens of %s: %v", req.GetId(), err)
+	}
+	if err := s.User.PurgeUser(ctx, req.GetId()); err != nil {
+		return nil, s.mapError(err)
+	}
+	return &user_service.PurgeUserResponse{Success: true}, nil
+}
+
+// SetUserRole меняет роль и отзывает токены пользователя: claims role в выданных токенах устарели.
+func (s *Server) SetUserRole(ctx context.Context, req *user_service.SetUserRoleRequest) (*user_service.UserResponse, error) {
+	roleReq := &dto.SetUserRoleRequest{
+		ID:      req.GetId(),
+		Role:    req.GetRole(),
+		Reason:  req.GetReason(),
+		ActorID: s.userIDFromContext(ctx),
+	}
+	if err := s.Validate.ValidateSetUserRoleRequest(roleReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	before, err := s.User.GetUser(ctx, roleReq.ID)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	resp, err := s.User.SetUserRole(ctx, roleReq)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	if before.Role != resp.Role {
+		if err := s.Token.RevokeAllForUser(ctx, resp.ID); err != nil {
+			log.Printf("set role: revoke tokens of %s: %v", resp.ID, err)
+		}
+	}
+	return toProtoUserResponse(resp), nil
+}
+
 func (s *Server) GetMe(ctx context.Context, req *user_service.GetMeRequest) (*user_service.UserResponse, error) {
 	userID := s.userIDFromContext(ctx)
 	if userID == "" {
@@ -77,17 +278,22 @@ func (s *Server) UpdateMe(ctx context.Context, req *user_service.UpdateUserReque
 	if userID == "" {
 		return nil, status.Error(codes.Unauthenticated, "unauthorized")
 	}
-	resp, err := s.User.UpdateUser(ctx, &dto.UpdateUserRequest{
-		ID:       userID,
-		Username: req.GetUsername(),
-		Email:    req.GetEmail(),
-		Phone:    req.GetPhone(),
-		Password: req.GetPassword(),
-		Status:   req.GetStatus(),
-	})
+	updateReq := updateRequestFromProto(userID, req)
+	version, err := expectedVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	updateReq.ExpectedVersion = version
+	updateReq.RequireCurrentPassword = true
+	updateReq.CurrentPassword = req.GetCurrentPassword()
+	if err := s.Validate.ValidateUpdateUserRequest(updateReq, dto.SelfUpdatableFields); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	resp, err := s.User.UpdateUser(ctx, updateReq)
 	if err != nil {
 		return nil, s.mapError(err)
 	}
+	s.revokeAfterUpdate(ctx, updateReq, resp)
 	return toProtoUserResponse(resp), nil
 }
 
@@ -97,6 +303,7 @@ func (s *Server) Register(ctx context.Context, req *user_service.RegisterRequest
 		Email:    req.GetEmail(),
 		Password: req.GetPassword(),
 		Role:     req.GetRole(),
+		DeviceID: req.GetDeviceId(),
 	}
 	if err := s.Validate.ValidateRegisterRequest(regReq); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -110,14 +317,19 @@ func (s *Server) Register(ctx context.Context, req *user_service.RegisterRequest
 	if err != nil {
 		return nil, s.mapError(err)
 	}
-	access, refresh, err := s.JWTConfig.GeneratePair(user.ID, user.Email, user.Role, user.OperatorStatus, user.IsAvailable)
+	if !user.EmailVerified && s.EmailVerification != nil {
+		// Письмо можно запросить повторно (ResendVerification): ошибка доставки регистрацию не отменяет.
+		if err := s.EmailVerification.Send(ctx, user.ID); err != nil {
+			log.Printf("register: send email verification to %s: %v", user.ID, err)
+		}
+	}
+	pair, err := s.Token.Issue(ctx, user, regReq.DeviceID)
+	if errors.Is(err, errs.ErrEmailNotVerified) {
+		// EMAIL_VERIFICATION=required: токены — после подтверждения email и входа.
+		return &user_service.AuthResponse{User: toProtoUserResponse(user)}, nil
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to generate tokens")
 	}
-	return &user_service.AuthResponse{
-		AccessToken:  access,
-		RefreshToken: refresh,
-		ExpiresIn:    900,
-		User:         toProtoUserResponse(user),
-	}, nil
+	return toProtoAuthResponse(pair), nil
 }
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+)
+
+// PathJWKS — публичные ключи проверки JWT (RFC 7517) для других сервисов PSDS.
+const PathJWKS = "/.well-known/jwks.json"
+
+// JWKS отдаёт публичные ключи набора ks; без асимметричных ключей — пустой список.
+func JWKS(ks *auth.KeySet) http.HandlerFunc {
+	body, _ := json.Marshal(ks.JWKS())
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Write(body)
+	}
+}
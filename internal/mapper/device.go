@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// DeviceToResponse преобразует entity UserDevice в DTO UserDeviceResponse.
+func DeviceToResponse(d *model.UserDevice) *dto.UserDeviceResponse {
+	if d == nil {
+		return nil
+	}
+	out := &dto.UserDeviceResponse{
+		ID:                d.ID,
+		UserID:            d.UserID,
+		DeviceID:          d.DeviceID,
+		DeviceType:        d.DeviceType,
+		UserAgent:         d.UserAgent,
+		ConnectionID:      d.ConnectionID,
+		IsConnected:       d.IsConnected,
+		LastHeartbeat:     d.LastHeartbeat,
+		SupportsWebRTC:    d.SupportsWebRTC,
+		SupportsWebSocket: d.SupportsWebSocket,
+		BandwidthLimit:    d.BandwidthLimit,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+	}
+	if d.IPAddress != nil {
+		out.IPAddress = *d.IPAddress
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// ServiceRouteToResponse преобразует entity UserService в DTO UserServiceRouteResponse.
+// Некорректный JSON в enabled_buttons/schedule трактуется как пустое значение.
+func ServiceRouteToResponse(s *model.UserService) *dto.UserServiceRouteResponse {
+	if s == nil {
+		return nil
+	}
+	out := &dto.UserServiceRouteResponse{
+		ID:                 s.ID,
+		UserID:             s.UserID,
+		ServiceName:        s.ServiceName,
+		ServiceType:        s.ServiceType,
+		BaseURL:            s.BaseURL,
+		APIEndpoint:        s.APIEndpoint,
+		Port:               s.Port,
+		UseSSL:             s.UseSSL,
+		SSLCertificatePath: s.SSLCertificatePath,
+		RoutingKey:         s.RoutingKey,
+		QueueName:          s.QueueName,
+		TopicName:          s.TopicName,
+		MaxBitrate:         s.MaxBitrate,
+		MaxConnections:     s.MaxConnections,
+		Priority:           s.Priority,
+		IsActive:           s.IsActive,
+		CreatedAt:          s.CreatedAt,
+		UpdatedAt:          s.UpdatedAt,
+	}
+	if len(s.EnabledButtons) > 0 {
+		_ = json.Unmarshal(s.EnabledButtons, &out.EnabledButtons)
+	}
+	if len(s.Schedule) > 0 {
+		var sched dto.ServiceSchedule
+		if err := json.Unmarshal(s.Schedule, &sched); err == nil {
+			out.Schedule = &sched
+		}
+	}
+	if len(s.Parameters) > 0 {
+		out.Parameters = json.RawMessage(s.Parameters)
+	}
+	return out
+}
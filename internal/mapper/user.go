@@ -14,6 +14,8 @@ func UserToResponse(u *model.User) *dto.UserResponse {
 		ID:             u.ID,
 		Username:       u.Username,
 		Email:          u.Email,
+		EmailVerified:  u.EmailVerifiedAt != nil,
+		MFAEnabled:     u.MFAEnabledAt != nil,
 		Phone:          u.Phone,
 		Status:         u.Status,
 		Role:           u.Role,
@@ -35,5 +37,6 @@ func UserToResponse(u *model.User) *dto.UserResponse {
 		LastLogin:      u.LastLogin,
 		LastActivity:   u.LastActivity,
 		LastSeenAt:     u.LastSeenAt,
+		Version:        u.Version,
 	}
 }
@@ -13,7 +13,7 @@ type contextKey string
 const ClaimsContextKey contextKey = "claims"
 
 // JWTAuth проверяет Bearer JWT и кладёт claims в контекст.
-func JWTAuth(cfg auth.Config, blacklist *auth.Blacklist) func(http.Handler) http.Handler {
+func JWTAuth(cfg auth.Config, blacklist auth.Blacklist) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			header := r.Header.Get("Authorization")
@@ -32,7 +32,12 @@ func JWTAuth(cfg auth.Config, blacklist *auth.Blacklist) func(http.Handler) http
 				http.Error(w, "invalid or expired token", http.StatusUnauthorized)
 				return
 			}
-			if blacklist != nil && blacklist.Contains(claims.ID) {
+			revoked, err := auth.IsRevoked(r.Context(), blacklist, claims)
+			if err != nil {
+				http.Error(w, "token revocation check failed", http.StatusServiceUnavailable)
+				return
+			}
+			if revoked {
 				http.Error(w, "token revoked", http.StatusUnauthorized)
 				return
 			}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule — правило доступа к gRPC-методу.
+//   - Public: токен не требуется (если передан и валиден — claims всё равно кладутся в контекст);
+//   - SelfField: имя поля запроса с user_id; если оно совпадает с claims.UserID, доступ разрешён;
+//   - Permission: разрешение из constants.PermissionsByRole для роли вызывающего;
+//   - AllowLimited: метод доступен и с ограниченным токеном (claims.Limited: не подтверждён email или не настроена обязательная 2FA),
+//     но только через SelfField или без Permission — разрешения роли такому токену не действуют.
+//
+// Без Public, SelfField и Permission метод доступен любому аутентифицированному пользователю.
+// Если задан SelfField без Permission, чужие записи недоступны никому.
+type Rule struct {
+	Public     bool
+	SelfField  string
+	Permission string
+
+	AllowLimited bool
+}
+
+// Policy — правила по полному имени метода (/user_service.UserService/GetUser).
+// Метод, отсутствующий в политике, запрещён.
+type Policy map[string]Rule
+
+// UnaryAuth проверяет Bearer JWT один раз на вызов, кладёт claims в контекст (см. GetClaims)
+// и применяет Policy. Через grpc-gateway заголовок Authorization приходит как metadata authorization.
+func UnaryAuth(cfg auth.Config, blacklist auth.Blacklist, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rule, ok := policy[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		}
+		claims, err := claimsFromMetadata(ctx, cfg, blacklist)
+		if err != nil && !rule.Public {
+			return nil, err
+		}
+		if claims != nil {
+			ctx = context.WithValue(ctx, ClaimsContextKey, claims)
+		}
+		if rule.Public {
+			return handler(ctx, req)
+		}
+		if claims == nil {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+		if !allowed(rule, claims, req) {
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func allowed(rule Rule, claims *auth.Claims, req interface{}) bool {
+	if claims.Limited {
+		if !rule.AllowLimited {
+			return false
+		}
+		if rule.SelfField != "" {
+			return requestField(req, rule.SelfField) == claims.UserID
+		}
+		return rule.Permission == ""
+	}
+	if rule.SelfField != "" && requestField(req, rule.SelfField) == claims.UserID {
+		return true
+	}
+	if rule.Permission != "" {
+		return RoleHasPermission(claims.Role, rule.Permission)
+	}
+	return rule.SelfField == ""
+}
+
+// RoleHasPermission — есть ли разрешение у роли (constants.PermissionsByRole).
+func RoleHasPermission(role, perm string) bool {
+	for _, p := range constants.PermissionsByRole[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// requestField возвращает строковое поле proto-запроса по имени или "".
+func requestField(req interface{}, name string) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return m.Get(fd).String()
+}
+
+// claimsFromMetadata: nil, nil — токена нет; ошибка Unauthenticated — токен невалиден или отозван;
+// Unavailable — хранилище отзыва недоступно (fail closed).
+func claimsFromMetadata(ctx context.Context, cfg auth.Config, blacklist auth.Blacklist) (*auth.Claims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	var token string
+	for _, v := range md.Get("authorization") {
+		if strings.HasPrefix(v, "Bearer ") {
+			token = strings.TrimPrefix(v, "Bearer ")
+			break
+		}
+	}
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := cfg.ValidateAccess(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	revoked, err := auth.IsRevoked(ctx, blacklist, claims)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "token revocation check failed")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	}
+	return claims, nil
+}
@@ -9,14 +9,20 @@ import (
 
 // User — сущность пользователя (схема БД: users, PSDS).
 type User struct {
-	ID             string `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Username       string `gorm:"size:100;uniqueIndex;not null"`
-	Email          string `gorm:"size:255;uniqueIndex;not null"`
-	PasswordHash   string `gorm:"column:password_hash;size:255;not null"`
-	Role           string `gorm:"size:20;not null;default:client"`                // client, operator, admin
-	OperatorStatus string `gorm:"column:operator_status;size:20;default:pending"` // pending, verified, blocked
-	MaxSessions    int    `gorm:"column:max_sessions;default:1"`
-	IsAvailable    bool   `gorm:"column:is_available;default:false"`
+	ID              string `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Username        string `gorm:"size:100;uniqueIndex:idx_users_username_active,where:deleted_at IS NULL;not null"`
+	Email           string `gorm:"size:255;uniqueIndex:idx_users_email_active,where:deleted_at IS NULL;not null"`
+	PasswordHash    string `gorm:"column:password_hash;size:255;not null"`
+	Role            string `gorm:"size:20;not null;default:client"`                // client, operator, admin, service
+	OperatorStatus  string `gorm:"column:operator_status;size:20;default:pending"` // pending, verified, blocked
+	MaxSessions     int    `gorm:"column:max_sessions;default:1"`
+	IsAvailable     bool   `gorm:"column:is_available;default:false"`
+	AutoUnavailable bool   `gorm:"column:auto_unavailable;default:false"` // снята автоматически по max_sessions
+
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at"`                // nil — email не подтверждён
+	MFASecret       string     `gorm:"column:mfa_secret;size:64"`               // base32 TOTP; задан при enrol, до подтверждения 2FA не включена
+	MFAEnabledAt    *time.Time `gorm:"column:mfa_enabled_at"`                   // nil — 2FA выключена
+	MFALastStep     int64      `gorm:"column:mfa_last_step;not null;default:0"` // шаг последнего принятого TOTP (защита от повтора)
 
 	FullName       string `gorm:"column:full_name;size:255"`
 	AvatarURL      string `gorm:"column:avatar_url;size:500"`
@@ -28,6 +34,8 @@ type User struct {
 
 	TotalSessions int     `gorm:"column:total_sessions;default:0"`
 	Rating        float64 `gorm:"type:decimal(3,2);default:0"`
+	RatingCount   int     `gorm:"column:rating_count;default:0"`
+	RatingSum     int     `gorm:"column:rating_sum;not null;default:0"` // сумма оценок; Rating = RatingSum / RatingCount
 
 	IsActive   bool       `gorm:"column:is_active;default:true"`
 	IsOnline   bool       `gorm:"column:is_online;default:false"`
@@ -42,6 +50,13 @@ type User struct {
 	UpdatedAt       time.Time
 	LastLogin       *time.Time
 	LastActivity    *time.Time
+
+	// Version — счётчик изменений строки (оптимистическая блокировка, ETag); растёт при каждом изменении,
+	// кроме служебных полей (статистика входов, шаг TOTP).
+	Version int64 `gorm:"not null;default:1"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index"`            // мягкое удаление: GORM исключает такие строки из запросов
+	PurgedAt  *time.Time     `gorm:"column:purged_at"` // персональные данные обезличены (PurgeUser)
 }
 
 func (User) TableName() string { return "users" }
@@ -49,9 +64,9 @@ func (User) TableName() string { return "users" }
 // UserSession — сессия пользователя (связь с session-manager).
 type UserSession struct {
 	ID                   string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	UserID               string     `gorm:"type:uuid;not null;index"`
+	UserID               string     `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_sessions_active_external,where:left_at IS NULL"`
 	SessionType          string     `gorm:"column:session_type;size:50;not null"`
-	SessionExternalID    string     `gorm:"column:session_external_id;size:255;not null"`
+	SessionExternalID    string     `gorm:"column:session_external_id;size:255;not null;uniqueIndex:idx_user_sessions_active_external,where:left_at IS NULL"`
 	ParticipantRole      string     `gorm:"column:participant_role;size:20;not null"`
 	JoinedAt             time.Time  `gorm:"column:joined_at"`
 	LeftAt               *time.Time `gorm:"column:left_at"`
@@ -67,12 +82,12 @@ func (UserSession) TableName() string { return "user_sessions" }
 // UserDevice — устройство пользователя (WebSocket/подключения).
 type UserDevice struct {
 	ID                string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	UserID            string     `gorm:"type:uuid;not null;index"`
-	DeviceID          string     `gorm:"column:device_id;size:255;not null"`
+	UserID            string     `gorm:"type:uuid;not null;index;uniqueIndex:user_devices_user_id_device_id_key"`
+	DeviceID          string     `gorm:"column:device_id;size:255;not null;uniqueIndex:user_devices_user_id_device_id_key"`
 	DeviceType        string     `gorm:"column:device_type;size:50;not null"`
 	UserAgent         string     `gorm:"column:user_agent;type:text"`
-	IPAddress         string     `gorm:"column:ip_address"`
-	ConnectionID      string     `gorm:"column:connection_id;size:255"`
+	IPAddress         *string    `gorm:"column:ip_address"` // INET; nil — адрес неизвестен
+	ConnectionID      string     `gorm:"column:connection_id;size:255;uniqueIndex:idx_user_devices_connection_id_active,where:connection_id <> ''"`
 	IsConnected       bool       `gorm:"column:is_connected;default:false"`
 	LastHeartbeat     *time.Time `gorm:"column:last_heartbeat"`
 	SupportsWebRTC    bool       `gorm:"column:supports_webrtc;default:false"`
@@ -111,6 +126,138 @@ type UserService struct {
 
 func (UserService) TableName() string { return "user_services" }
 
+// RefreshToken — выданный refresh-токен. Хранится SHA-256 от jti, сам токен не сохраняется.
+// Токены одного входа образуют семейство (FamilyID); ReplacedBy — следующий токен после ротации.
+type RefreshToken struct {
+	ID         string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID     string     `gorm:"type:uuid;not null;index"`
+	FamilyID   string     `gorm:"column:family_id;type:uuid;not null;index"`
+	TokenHash  string     `gorm:"column:token_hash;size:64;not null;uniqueIndex"`
+	DeviceID   string     `gorm:"column:device_id;size:255"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	ReplacedBy *string    `gorm:"column:replaced_by;type:uuid"`
+	CreatedAt  time.Time
+}
+
+func (RefreshToken) TableName() string { return "refresh_tokens" }
+
+// RevokedToken — отозванный access-токен (jti) до момента его истечения.
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;size:255;primaryKey"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null;index"`
+	CreatedAt time.Time
+}
+
+func (RevokedToken) TableName() string { return "revoked_tokens" }
+
+// OutboxEvent — событие transactional outbox. Payload — сериализованный events/v1.Envelope;
+// PublishedAt == nil — ещё не опубликовано relay.
+type OutboxEvent struct {
+	ID            string     `gorm:"type:uuid;primaryKey"`
+	AggregateType string     `gorm:"column:aggregate_type;size:50;not null"`
+	AggregateID   string     `gorm:"column:aggregate_id;size:255;not null"`
+	EventType     string     `gorm:"column:event_type;size:100;not null"`
+	SchemaVersion int        `gorm:"column:schema_version;not null;default:1"`
+	Payload       []byte     `gorm:"column:payload;not null"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0"`
+	LastError     string     `gorm:"column:last_error;type:text"`
+	PublishedAt   *time.Time `gorm:"column:published_at"`
+	ClaimedUntil  *time.Time `gorm:"column:claimed_until"` // аренда relay: публикация идёт вне транзакции
+	ParkedAt      *time.Time `gorm:"column:parked_at"`     // исчерпаны попытки публикации, событие выведено из очереди
+	CreatedAt     time.Time
+}
+
+func (OutboxEvent) TableName() string { return "outbox_events" }
+
+// ProcessedMessage — обработанное сообщение брокера; вставляется в одной транзакции с его эффектом.
+type ProcessedMessage struct {
+	MessageID   string    `gorm:"column:message_id;size:255;primaryKey"`
+	Consumer    string    `gorm:"column:consumer;size:100;primaryKey"`
+	ProcessedAt time.Time `gorm:"column:processed_at;autoCreateTime"`
+}
+
+func (ProcessedMessage) TableName() string { return "processed_messages" }
+
+// SessionTombstone — последний выход участника из сессии session-manager (UserID "" — сессия закрыта).
+// Опоздавший participant.joined с occurred_at не позже LeftAt не открывает участие.
+type SessionTombstone struct {
+	SessionExternalID string    `gorm:"column:session_external_id;size:255;primaryKey"`
+	UserID            string    `gorm:"column:user_id;size:36;primaryKey;default:''"`
+	LeftAt            time.Time `gorm:"column:left_at;not null"`
+	CreatedAt         time.Time `gorm:"column:created_at;index:idx_session_tombstones_created_at"`
+}
+
+func (SessionTombstone) TableName() string { return "session_tombstones" }
+
+// UserToken — одноразовый токен действия (Purpose: constants.TokenPurpose*). Хранится SHA-256 от токена;
+// UsedAt != nil — токен погашен.
+type UserToken struct {
+	ID        string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    string     `gorm:"type:uuid;not null;index:idx_user_tokens_user_purpose"`
+	Purpose   string     `gorm:"size:50;not null;index:idx_user_tokens_user_purpose"`
+	TokenHash string     `gorm:"column:token_hash;size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time
+}
+
+func (UserToken) TableName() string { return "user_tokens" }
+
+// MFARecoveryCode — одноразовый код восстановления 2FA (SHA-256 от нормализованного кода).
+type MFARecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    string     `gorm:"type:uuid;not null;uniqueIndex:idx_mfa_recovery_codes_user_code"`
+	CodeHash  string     `gorm:"column:code_hash;size:64;not null;uniqueIndex:idx_mfa_recovery_codes_user_code"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time
+}
+
+func (MFARecoveryCode) TableName() string { return "mfa_recovery_codes" }
+
+// MFAChallenge — выданный MFA-челлендж (ID = jti токена). UsedAt != nil — челлендж погашен:
+// вход завершён или исчерпан лимит неверных кодов (Failures).
+type MFAChallenge struct {
+	ID        string     `gorm:"column:id;size:36;primaryKey"`
+	UserID    string     `gorm:"type:uuid;not null;index:idx_mfa_challenges_user_id"`
+	Failures  int        `gorm:"column:failures;not null;default:0"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	CreatedAt time.Time
+}
+
+func (MFAChallenge) TableName() string { return "mfa_challenges" }
+
+// LoginThrottle — счётчик неудачных входов по ключу account:<email> или ip:<addr>.
+type LoginThrottle struct {
+	Key           string     `gorm:"column:throttle_key;size:320;primaryKey"`
+	Failures      int        `gorm:"column:failures;not null;default:0"`
+	LastFailureAt *time.Time `gorm:"column:last_failure_at;index"`
+	LockedUntil   *time.Time `gorm:"column:locked_until"`
+}
+
+func (LoginThrottle) TableName() string { return "login_throttle" }
+
+// AuditEvent — запись журнала аудита (только добавление). ActorID == nil — без аутентифицированного
+// инициатора (вход, CLI); TargetID == nil — объект не найден (неудачный вход с неизвестным email).
+// Changes — audit.Changes: старые и новые значения изменённых полей. Source и Reason заполнены у
+// назначений роли (user.create, user.role_change) — это единственный журнал ролей.
+type AuditEvent struct {
+	ID         string         `gorm:"type:uuid;primaryKey"`
+	Action     string         `gorm:"size:64;not null;index:idx_audit_events_action"`
+	ActorID    *string        `gorm:"column:actor_id;type:uuid;index:idx_audit_events_actor_id"`
+	TargetType string         `gorm:"column:target_type;size:32;not null"`
+	TargetID   *string        `gorm:"column:target_id;type:uuid;index:idx_audit_events_target_id"`
+	Changes    datatypes.JSON `gorm:"type:jsonb"`
+	ClientIP   string         `gorm:"column:client_ip;size:64"`
+	UserAgent  string         `gorm:"column:user_agent;size:512"`
+	Source     string         `gorm:"column:source;size:20"` // constants.RoleSource*
+	Reason     string         `gorm:"column:reason;type:text"`
+	CreatedAt  time.Time      `gorm:"not null;index:idx_audit_events_created_at"`
+}
+
+func (AuditEvent) TableName() string { return "audit_events" }
+
 // Base — общие поля для сущностей с автоинкрементом (если понадобятся другие таблицы).
 // Для users/user_services используем UUID и явные timestamps.
 type Base struct {
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogNotifier пишет уведомления в лог процесса (токены видны в логе — только для разработки).
+type LogNotifier struct{}
+
+func (LogNotifier) Send(_ context.Context, msg Message) error {
+	log.Printf("notify: %s to %s: %s\n%s", msg.Kind, msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileNotifier дописывает уведомления в файл по одному JSON на строку (удобно читать из e2e-тестов).
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+type fileRecord struct {
+	Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+func (n *FileNotifier) Send(_ context.Context, msg Message) error {
+	line, err := json.Marshal(fileRecord{Message: msg, SentAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
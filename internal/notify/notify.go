@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Виды уведомлений (Message.Kind) — по ним реальный транспорт выбирает шаблон письма.
+const (
+	KindPasswordReset     = "password_reset"
+	KindEmailVerification = "email_verification"
+)
+
+// Message — уведомление пользователю. Data — параметры шаблона (token, link, ...).
+type Message struct {
+	Kind    string            `json:"kind"`
+	To      string            `json:"to"`
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+// Notifier — доставка уведомлений (email и т.п.). Реализации: LogNotifier и FileNotifier для
+// локальной разработки; почтовый шлюз подключается отдельной реализацией.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New создаёт Notifier по имени из конфигурации: log (default) или file (path — файл JSON Lines).
+func New(kind, path string) (Notifier, error) {
+	switch kind {
+	case "", "log":
+		return LogNotifier{}, nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("notify: file path is required for file notifier")
+		}
+		return NewFileNotifier(path), nil
+	default:
+		return nil, fmt.Errorf("notify: unknown notifier %q (log, file)", kind)
+	}
+}
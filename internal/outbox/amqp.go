@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DefaultExchange — topic exchange событий user-service.
+const DefaultExchange = "psds.user.events"
+
+// AMQPPublisher публикует в topic exchange с подтверждениями издателя (publisher confirms) и флагом
+// mandatory: сообщение, которое exchange не смог маршрутизировать ни в одну очередь, брокер возвращает
+// (basic.return) и затем подтверждает — такая публикация считается неудачной.
+// Соединение открывается лениво и переоткрывается после ошибки.
+type AMQPPublisher struct {
+	url      string
+	exchange string
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	returns chan amqp.Return
+}
+
+func NewAMQPPublisher(url, exchange string) *AMQPPublisher {
+	if exchange == "" {
+		exchange = DefaultExchange
+	}
+	return &AMQPPublisher{url: url, exchange: exchange}
+}
+
+func (p *AMQPPublisher) channel() (*amqp.Channel, error) {
+	if p.ch != nil && !p.ch.IsClosed() {
+		return p.ch, nil
+	}
+	p.closeLocked()
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return nil, fmt.Errorf("amqp dial: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp exchange declare: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp confirm mode: %w", err)
+	}
+	// Публикации сериализованы mu, поэтому в канале не больше одного возврата.
+	p.returns = ch.NotifyReturn(make(chan amqp.Return, 1))
+	p.conn, p.ch = conn, ch
+	return ch, nil
+}
+
+func (p *AMQPPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch, err := p.channel()
+	if err != nil {
+		return err
+	}
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, msg.Type, true, false, amqp.Publishing{
+		MessageId:    msg.ID,
+		Type:         msg.Type,
+		ContentType:  "application/x-protobuf",
+		DeliveryMode: amqp.Persistent,
+		Headers:      amqp.Table{"schema_version": int32(msg.SchemaVersion)},
+		Body:         msg.Body,
+	})
+	if err != nil {
+		p.closeLocked()
+		return fmt.Errorf("amqp publish: %w", err)
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		p.closeLocked()
+		return fmt.Errorf("amqp confirm: %w", err)
+	}
+	if !ok {
+		return errors.New("amqp: message nacked by broker")
+	}
+	// basic.return приходит до basic.ack того же сообщения и к этому моменту уже в канале returns.
+	select {
+	case ret, open := <-p.returns:
+		if open && ret.MessageId == msg.ID {
+			return fmt.Errorf("amqp: message returned by broker: %d %s", ret.ReplyCode, ret.ReplyText)
+		}
+	default:
+	}
+	return nil
+}
+
+// Close закрывает соединение с брокером.
+func (p *AMQPPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeLocked()
+	return nil
+}
+
+func (p *AMQPPublisher) closeLocked() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn, p.ch, p.returns = nil, nil, nil
+}
@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/model"
+	eventsv1 "github.com/psds-microservice/user-service/pkg/gen/user_service/events/v1"
+)
+
+// SchemaVersion — версия схемы событий (пакет user_service.events.v1).
+const SchemaVersion = 1
+
+// AggregateUser — тип агрегата всех событий user-service.
+const AggregateUser = "user"
+
+// Типы событий: routing key в exchange и Envelope.event_type.
+const (
+	EventUserCreated                 = "user.created"
+	EventUserUpdated                 = "user.updated"
+	EventUserDeleted                 = "user.deleted"
+	EventUserRestored                = "user.restored"
+	EventUserPurged                  = "user.purged"
+	EventUserStatusChanged           = "user.status_changed"
+	EventOperatorVerified            = "operator.verified"
+	EventOperatorAvailabilityChanged = "operator.availability_changed"
+	EventUserPresenceChanged         = "user.presence_changed"
+	EventUserSessionStarted          = "user.session_started"
+)
+
+// NewEnvelope создаёт конверт события для пользователя userID; payload задаёт вызывающий.
+func NewEnvelope(eventType, userID string) *eventsv1.Envelope {
+	return &eventsv1.Envelope{
+		EventId:       uuid.New().String(),
+		EventType:     eventType,
+		SchemaVersion: SchemaVersion,
+		AggregateId:   userID,
+		OccurredAt:    timestamppb.New(time.Now()),
+	}
+}
+
+// Enqueue записывает событие в outbox_events. tx — транзакция, в которой меняются данные:
+// событие публикуется тогда и только тогда, когда изменение закоммичено.
+func Enqueue(tx *gorm.DB, env *eventsv1.Envelope) error {
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&model.OutboxEvent{
+		ID:            env.GetEventId(),
+		AggregateType: AggregateUser,
+		AggregateID:   env.GetAggregateId(),
+		EventType:     env.GetEventType(),
+		SchemaVersion: int(env.GetSchemaVersion()),
+		Payload:       payload,
+	}).Error
+}
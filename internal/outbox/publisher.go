@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// Message — событие для публикации: ID — AMQP message_id, Type — routing key.
+type Message struct {
+	ID            string
+	Type          string
+	SchemaVersion int
+	Body          []byte
+}
+
+// Publisher — транспорт событий outbox. Publish возвращает nil только после подтверждения брокером.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// MemoryPublisher — Publisher в памяти (тесты, локальный запуск без брокера).
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages возвращает копию опубликованных сообщений.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Message, len(p.messages))
+	copy(out, p.messages)
+	return out
+}
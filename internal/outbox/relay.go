@@ -0,0 +1,158 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// Relay переносит неопубликованные события из outbox_events в Publisher в порядке записи.
+// Пакет забирается короткой транзакцией (FOR UPDATE SKIP LOCKED + аренда claimed_until) и публикуется
+// без блокировок строк, поэтому relay можно запускать в нескольких репликах.
+// Доставка — at-least-once: потребители дедуплицируют по message_id.
+type Relay struct {
+	db          *gorm.DB
+	publisher   Publisher
+	batchSize   int
+	maxAttempts int
+	interval    time.Duration
+}
+
+// claimTTL — аренда пакета: если реплика упала посреди публикации, события снова доступны через claimTTL.
+const claimTTL = 2 * time.Minute
+
+// publishTimeout ограничивает ожидание подтверждения брокера для одного события.
+const publishTimeout = 10 * time.Second
+
+// NewRelay создаёт relay. maxAttempts — после стольких неудачных публикаций событие откладывается
+// (parked_at) и больше не задерживает последующие.
+func NewRelay(db *gorm.DB, publisher Publisher, batchSize, maxAttempts int, interval time.Duration) *Relay {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &Relay{db: db, publisher: publisher, batchSize: batchSize, maxAttempts: maxAttempts, interval: interval}
+}
+
+// Run публикует события каждые interval до отмены ctx.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := r.Flush(ctx)
+				if err != nil {
+					log.Printf("outbox relay: %v", err)
+					break
+				}
+				if n < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Flush публикует один пакет и возвращает число опубликованных событий. На первой ошибке публикации
+// пакет прерывается (чтобы не нарушить порядок): у события растёт attempts и сохраняется last_error,
+// после maxAttempts оно откладывается, остаток пакета освобождается для следующего вызова.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	batch, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	for i, ev := range batch {
+		pubCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+		publishErr := r.publisher.Publish(pubCtx, Message{
+			ID:            ev.ID,
+			Type:          ev.EventType,
+			SchemaVersion: ev.SchemaVersion,
+			Body:          ev.Payload,
+		})
+		cancel()
+		if publishErr != nil {
+			if err := r.fail(ctx, ev, publishErr); err != nil {
+				return published, err
+			}
+			return published, r.release(ctx, batch[i+1:], publishErr)
+		}
+		err := r.db.WithContext(ctx).Model(ev).Updates(map[string]interface{}{
+			"published_at":  time.Now(),
+			"claimed_until": nil,
+		}).Error
+		if err != nil {
+			return published, r.release(ctx, batch[i+1:], err)
+		}
+		published++
+	}
+	return published, nil
+}
+
+// claim выбирает пакет неопубликованных и неарендованных событий и арендует его на claimTTL.
+func (r *Relay) claim(ctx context.Context) ([]*model.OutboxEvent, error) {
+	var batch []*model.OutboxEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND parked_at IS NULL").
+			Where("claimed_until IS NULL OR claimed_until < ?", now).
+			Order("created_at ASC").Limit(r.batchSize).
+			Find(&batch).Error
+		if err != nil || len(batch) == 0 {
+			return err
+		}
+		ids := make([]string, len(batch))
+		for i, ev := range batch {
+			ids[i] = ev.ID
+		}
+		return tx.Model(&model.OutboxEvent{}).Where("id IN ?", ids).Update("claimed_until", now.Add(claimTTL)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// fail учитывает неудачную публикацию и снимает аренду; после maxAttempts событие откладывается.
+func (r *Relay) fail(ctx context.Context, ev *model.OutboxEvent, publishErr error) error {
+	updates := map[string]interface{}{
+		"attempts":      gorm.Expr("attempts + 1"),
+		"last_error":    publishErr.Error(),
+		"claimed_until": nil,
+	}
+	if ev.Attempts+1 >= r.maxAttempts {
+		updates["parked_at"] = time.Now()
+		log.Printf("outbox relay: event %s (%s) parked after %d attempts: %v", ev.ID, ev.EventType, ev.Attempts+1, publishErr)
+	}
+	return r.db.WithContext(ctx).Model(ev).Updates(updates).Error
+}
+
+// release снимает аренду с неопубликованного остатка пакета и возвращает cause.
+func (r *Relay) release(ctx context.Context, rest []*model.OutboxEvent, cause error) error {
+	if len(rest) == 0 {
+		return cause
+	}
+	ids := make([]string, len(rest))
+	for i, ev := range rest {
+		ids[i] = ev.ID
+	}
+	if err := r.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id IN ?", ids).Update("claimed_until", nil).Error; err != nil {
+		log.Printf("outbox relay: release claim: %v", err)
+	}
+	return cause
+}
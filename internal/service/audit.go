@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// AuditService — чтение журнала аудита. Записи добавляет сервисный слой (audit.Record) в транзакциях действий.
+type AuditService interface {
+	// ListAuditEvents возвращает записи от новых к старым с keyset-пагинацией.
+	ListAuditEvents(ctx context.Context, filters *dto.AuditFilters) (*dto.AuditPage, error)
+}
+
+type auditService struct {
+	db *gorm.DB
+}
+
+// NewAuditService создаёт сервис журнала аудита.
+func NewAuditService(db *gorm.DB) AuditService {
+	return &auditService{db: db}
+}
+
+// auditUser пишет действие над пользователем с изменёнными полями before → after.
+func auditUser(ctx context.Context, tx *gorm.DB, action string, before, after *model.User, passwordChanged bool) error {
+	return audit.Record(ctx, tx, audit.Entry{
+		Action:   action,
+		TargetID: after.ID,
+		Changes:  userAuditChanges(before, after, passwordChanged),
+	})
+}
+
+// userAuditChanges — изменённые поля пользователя. Журнал неизменяем и переживает PurgeUser,
+// поэтому персональные данные и пароль только отмечаются как изменённые.
+func userAuditChanges(before, after *model.User, passwordChanged bool) audit.Changes {
+	out := audit.Changes{}
+	value := func(name string, old, new interface{}) {
+		if old != new {
+			out[name] = audit.Change{Old: old, New: new}
+		}
+	}
+	redacted := func(name string, changed bool) {
+		if changed {
+			out[name] = audit.Change{Redacted: true}
+		}
+	}
+	value("status", before.Status, after.Status)
+	value("role", before.Role, after.Role)
+	value("operator_status", before.OperatorStatus, after.OperatorStatus)
+	value("email_verified", before.EmailVerifiedAt != nil, after.EmailVerifiedAt != nil)
+	value("mfa_enabled", before.MFAEnabledAt != nil, after.MFAEnabledAt != nil)
+	value("timezone", before.Timezone, after.Timezone)
+	value("language", before.Language, after.Language)
+	redacted("username", before.Username != after.Username)
+	redacted("email", before.Email != after.Email)
+	redacted("phone", before.Phone != after.Phone)
+	redacted("full_name", before.FullName != after.FullName)
+	redacted("avatar_url", before.AvatarURL != after.AvatarURL)
+	redacted("company", before.Company != after.Company)
+	redacted("specialization", before.Specialization != after.Specialization)
+	redacted("password", passwordChanged)
+	return out
+}
+
+// auditCursor — курсор keyset-пагинации журнала: (created_at, id) последней записи.
+type auditCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func (s *auditService) ListAuditEvents(ctx context.Context, filters *dto.AuditFilters) (*dto.AuditPage, error) {
+	if filters == nil {
+		filters = &dto.AuditFilters{}
+	}
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	query := s.db.WithContext(ctx).Model(&model.AuditEvent{})
+	if filters.ActorID != "" {
+		query = query.Where("actor_id = ?", filters.ActorID)
+	}
+	if filters.TargetID != "" {
+		query = query.Where("target_id = ?", filters.TargetID)
+	}
+	if filters.Action != "" {
+		query = query.Where("action = ?", filters.Action)
+	}
+	if filters.From != nil {
+		query = query.Where("created_at >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("created_at < ?", *filters.To)
+	}
+	if filters.Cursor != "" {
+		b, err := base64.RawURLEncoding.DecodeString(filters.Cursor)
+		if err != nil {
+			return nil, errs.ErrInvalidCursor
+		}
+		var c auditCursor
+		if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+			return nil, errs.ErrInvalidCursor
+		}
+		query = query.Where("(created_at < ?) OR (created_at = ? AND id < ?)", c.CreatedAt, c.CreatedAt, c.ID)
+	}
+
+	var list []*model.AuditEvent
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(limit + 1).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	page := &dto.AuditPage{}
+	if len(list) > limit {
+		list = list[:limit]
+		last := list[len(list)-1]
+		b, _ := json.Marshal(auditCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(b)
+	}
+	page.Events = make([]*dto.AuditEvent, len(list))
+	for i, ev := range list {
+		out := &dto.AuditEvent{
+			ID:         ev.ID,
+			Action:     ev.Action,
+			TargetType: ev.TargetType,
+			Changes:    json.RawMessage(ev.Changes),
+			ClientIP:   ev.ClientIP,
+			UserAgent:  ev.UserAgent,
+			Source:     ev.Source,
+			Reason:     ev.Reason,
+			CreatedAt:  ev.CreatedAt,
+		}
+		if ev.ActorID != nil {
+			out.ActorID = *ev.ActorID
+		}
+		if ev.TargetID != nil {
+			out.TargetID = *ev.TargetID
+		}
+		page.Events[i] = out
+	}
+	return page, nil
+}
@@ -2,27 +2,56 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"log"
+	"strings"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/psds-microservice/user-service/internal/audit"
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/internal/errs"
 	"github.com/psds-microservice/user-service/internal/mapper"
 	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
 )
 
 // AuthService — контракт сервиса аутентификации.
 type AuthService interface {
-	Login(ctx context.Context, email, password string) (*dto.UserResponse, error)
+	// Login проверяет пароль. clientIP — адрес клиента для ограничения перебора ("" — не учитывается).
+	// При блокировке возвращает *errs.LockedError (errors.Is(err, errs.ErrTooManyLoginAttempts)),
+	// для users.status = blocked и inactive — errs.ErrUserBlocked и errs.ErrUserInactive.
+	// Для пользователя с 2FA вход не завершён: счётчик неудач сбрасывает MFAService.VerifyChallenge.
+	Login(ctx context.Context, email, password, clientIP string) (*dto.UserResponse, error)
+	// PurgeThrottle удаляет счётчики login_throttle без неудач дольше Window и без действующей
+	// блокировки; возвращает число удалённых.
+	PurgeThrottle(ctx context.Context) (int64, error)
+}
+
+// LoginLimits — защита от перебора паролей. После MaxFailures неудач подряд по учётной записи
+// (IPMaxFailures — по адресу клиента) вход блокируется на BaseLockout, каждая следующая неудача
+// удваивает блокировку до MaxLockout. Неверный код второго фактора считается такой же неудачей.
+// Счётчик сбрасывается успешным входом (только по учётной записи) или через Window после последней
+// неудачи. MaxFailures <= 0 — ограничение выключено.
+type LoginLimits struct {
+	MaxFailures   int
+	IPMaxFailures int
+	BaseLockout   time.Duration
+	MaxLockout    time.Duration
+	Window        time.Duration
 }
 
 type authService struct {
-	db *gorm.DB
+	db       *gorm.DB
+	throttle *loginThrottle
 }
 
-func NewAuthService(db *gorm.DB) AuthService {
-	return &authService{db: db}
+func NewAuthService(db *gorm.DB, limits LoginLimits) AuthService {
+	return &authService{db: db, throttle: newLoginThrottle(db, limits)}
 }
 
 func (s *authService) getByEmail(ctx context.Context, email string) (*model.User, error) {
@@ -37,16 +66,233 @@ func (s *authService) getByEmail(ctx context.Context, email string) (*model.User
 	return &u, nil
 }
 
-func (s *authService) Login(ctx context.Context, email, password string) (*dto.UserResponse, error) {
+func (s *authService) Login(ctx context.Context, email, password, clientIP string) (*dto.UserResponse, error) {
+	keys := s.throttle.keys(email, clientIP)
+	if err := s.throttle.checkLocked(ctx, keys); err != nil {
+		return nil, err
+	}
 	u, err := s.getByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-	if u == nil {
+	if u == nil || !checkPassword(u.PasswordHash, password) {
+		if err := s.throttle.recordFailure(ctx, keys, u); err != nil {
+			return nil, err
+		}
 		return nil, errs.ErrInvalidCredentials
 	}
-	if !checkPassword(u.PasswordHash, password) {
-		return nil, errs.ErrInvalidCredentials
+	if err := statusError(u); err != nil {
+		return nil, err
+	}
+	if u.MFAEnabledAt != nil {
+		return mapper.UserToResponse(u), nil
+	}
+	if err := s.throttle.recordSuccess(ctx, keys, u); err != nil {
+		return nil, err
 	}
 	return mapper.UserToResponse(u), nil
 }
+
+func (s *authService) PurgeThrottle(ctx context.Context) (int64, error) {
+	now := time.Now()
+	res := s.db.WithContext(ctx).
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", now.Add(-s.throttle.limits.Window), now).
+		Delete(&model.LoginThrottle{})
+	return res.RowsAffected, res.Error
+}
+
+// RunPurgeThrottle каждые interval удаляет устаревшие счётчики login_throttle до отмены ctx.
+func RunPurgeThrottle(ctx context.Context, auth AuthService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := auth.PurgeThrottle(ctx)
+			if err != nil {
+				log.Printf("login throttle purge: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("login throttle purge: removed %d", n)
+			}
+		}
+	}
+}
+
+// loginThrottle — счётчики login_throttle; общие для проверки пароля и второго фактора.
+type loginThrottle struct {
+	db     *gorm.DB
+	limits LoginLimits
+}
+
+func newLoginThrottle(db *gorm.DB, limits LoginLimits) *loginThrottle {
+	if limits.BaseLockout <= 0 {
+		limits.BaseLockout = 30 * time.Second
+	}
+	if limits.MaxLockout < limits.BaseLockout {
+		limits.MaxLockout = limits.BaseLockout
+	}
+	if limits.Window <= 0 {
+		limits.Window = 15 * time.Minute
+	}
+	return &loginThrottle{db: db, limits: limits}
+}
+
+// throttleKey — ключ login_throttle с порогом неудач.
+type throttleKey struct {
+	key       string
+	threshold int
+}
+
+// keys: счётчик по email ведётся и для несуществующих адресов — блокировка не раскрывает,
+// есть ли учётная запись.
+func (s *loginThrottle) keys(email, clientIP string) []throttleKey {
+	if s.limits.MaxFailures <= 0 {
+		return nil
+	}
+	keys := []throttleKey{{key: "account:" + strings.ToLower(strings.TrimSpace(email)), threshold: s.limits.MaxFailures}}
+	if clientIP != "" && s.limits.IPMaxFailures > 0 {
+		keys = append(keys, throttleKey{key: "ip:" + clientIP, threshold: s.limits.IPMaxFailures})
+	}
+	return keys
+}
+
+func (s *loginThrottle) checkLocked(ctx context.Context, keys []throttleKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.key
+	}
+	var rows []model.LoginThrottle
+	if err := s.db.WithContext(ctx).Where("throttle_key IN ?", names).Find(&rows).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	var wait time.Duration
+	for _, r := range rows {
+		if r.LockedUntil != nil && r.LockedUntil.After(now) && r.LockedUntil.Sub(now) > wait {
+			wait = r.LockedUntil.Sub(now)
+		}
+	}
+	if wait > 0 {
+		return &errs.LockedError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// lockoutFor — длительность блокировки после failures неудач при пороге threshold.
+func (s *loginThrottle) lockoutFor(failures, threshold int) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+	d := s.limits.BaseLockout
+	for i := threshold; i < failures && d < s.limits.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > s.limits.MaxLockout {
+		d = s.limits.MaxLockout
+	}
+	return d
+}
+
+func (s *loginThrottle) recordFailure(ctx context.Context, keys []throttleKey, u *model.User) error {
+	now := time.Now()
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, k := range keys {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&model.LoginThrottle{Key: k.key}).Error; err != nil {
+				return err
+			}
+			var row model.LoginThrottle
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("throttle_key = ?", k.key).First(&row).Error; err != nil {
+				return err
+			}
+			if row.LastFailureAt == nil || now.Sub(*row.LastFailureAt) > s.limits.Window {
+				row.Failures = 0
+			}
+			row.Failures++
+			row.LastFailureAt = &now
+			row.LockedUntil = nil
+			if d := s.lockoutFor(row.Failures, k.threshold); d > 0 {
+				until := now.Add(d)
+				row.LockedUntil = &until
+			}
+			if err := tx.Save(&row).Error; err != nil {
+				return err
+			}
+		}
+		entry := audit.Entry{Action: audit.ActionLoginFailed}
+		if u != nil {
+			entry.TargetID, entry.ActorID = u.ID, u.ID
+		}
+		if err := audit.Record(ctx, tx, entry); err != nil {
+			return err
+		}
+		if u == nil {
+			return nil
+		}
+		return bumpLoginStats(tx, u.ID, "failed_logins", nil)
+	})
+}
+
+func (s *loginThrottle) recordSuccess(ctx context.Context, keys []throttleKey, u *model.User) error {
+	now := time.Now()
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(keys) > 0 {
+			// Успех сбрасывает только счётчик учётной записи: адрес с перебором по многим email остаётся под учётом.
+			if err := tx.Where("throttle_key = ?", keys[0].key).Delete(&model.LoginThrottle{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionLogin, TargetID: u.ID, ActorID: u.ID}); err != nil {
+			return err
+		}
+		u.LastLogin = &now
+		u.LastActivity = &now
+		return bumpLoginStats(tx, u.ID, "successful_logins", map[string]interface{}{
+			"last_login":    now,
+			"last_activity": now,
+		})
+	})
+}
+
+// bumpLoginStats увеличивает счётчик в users.stats (JSONB) и применяет extra в той же записи.
+func bumpLoginStats(tx *gorm.DB, userID, counter string, extra map[string]interface{}) error {
+	var u model.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stats").Where("id = ?", userID).First(&u).Error; err != nil {
+		return err
+	}
+	stats := map[string]interface{}{}
+	if len(u.Stats) > 0 {
+		if err := json.Unmarshal(u.Stats, &stats); err != nil {
+			stats = map[string]interface{}{}
+		}
+	}
+	n, _ := stats[counter].(float64)
+	stats[counter] = n + 1
+	raw, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	updates := map[string]interface{}{"stats": datatypes.JSON(raw)}
+	for k, v := range extra {
+		updates[k] = v
+	}
+	return tx.Model(&model.User{}).Where("id = ?", userID).Updates(updates).Error
+}
+
+// statusError — отказ в выдаче токенов по users.status: вход, ротация refresh-токена.
+func statusError(u *model.User) error {
+	switch u.Status {
+	case constants.UserStatusBlocked:
+		return errs.ErrUserBlocked
+	case constants.UserStatusInactive:
+		return errs.ErrUserInactive
+	}
+	return nil
+}
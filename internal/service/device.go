@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// DeviceService — контракт сервиса устройств пользователя (user_devices).
+type DeviceService interface {
+	RegisterDevice(ctx context.Context, userID string, req *dto.RegisterDeviceRequest) (*dto.UserDeviceResponse, error)
+	ListDevices(ctx context.Context, userID string) ([]*dto.UserDeviceResponse, error)
+	RemoveDevice(ctx context.Context, userID, deviceID string) error
+	ConnectDevice(ctx context.Context, req *dto.ConnectDeviceRequest) (*dto.UserDeviceResponse, error)
+	DisconnectDevice(ctx context.Context, connectionID string) (*dto.UserDeviceResponse, error)
+	Heartbeat(ctx context.Context, connectionID string) (*dto.UserDeviceResponse, error)
+}
+
+type deviceService struct {
+	db *gorm.DB
+}
+
+func NewDeviceService(db *gorm.DB) DeviceService {
+	return &deviceService{db: db}
+}
+
+func (s *deviceService) getByUserAndDeviceID(ctx context.Context, userID, deviceID string) (*model.UserDevice, error) {
+	var d model.UserDevice
+	err := s.db.WithContext(ctx).Where("user_id = ? AND device_id = ?", userID, deviceID).First(&d).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *deviceService) getByConnectionID(ctx context.Context, connectionID string) (*model.UserDevice, error) {
+	var d model.UserDevice
+	err := s.db.WithContext(ctx).Where("connection_id = ?", connectionID).First(&d).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *deviceService) userExists(ctx context.Context, userID string) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (s *deviceService) RegisterDevice(ctx context.Context, userID string, req *dto.RegisterDeviceRequest) (*dto.UserDeviceResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	ok, err := s.userExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errs.ErrUserNotFound
+	}
+	device := &model.UserDevice{
+		ID:                uuid.New().String(),
+		UserID:            userID,
+		DeviceID:          req.DeviceID,
+		DeviceType:        req.DeviceType,
+		UserAgent:         req.UserAgent,
+		IPAddress:         optionalString(req.IPAddress),
+		SupportsWebRTC:    req.SupportsWebRTC,
+		SupportsWebSocket: req.SupportsWebSocket,
+	}
+	if req.BandwidthLimit > 0 {
+		limit := req.BandwidthLimit
+		device.BandwidthLimit = &limit
+	}
+	err = s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "device_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"device_type", "user_agent", "ip_address",
+			"supports_webrtc", "supports_websocket", "bandwidth_limit", "updated_at",
+		}),
+	}).Create(device).Error
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.getByUserAndDeviceID(ctx, userID, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.DeviceToResponse(stored), nil
+}
+
+func (s *deviceService) ListDevices(ctx context.Context, userID string) ([]*dto.UserDeviceResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var list []*model.UserDevice
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("is_connected DESC").Order("updated_at DESC").Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*dto.UserDeviceResponse, len(list))
+	for i := range list {
+		out[i] = mapper.DeviceToResponse(list[i])
+	}
+	return out, nil
+}
+
+func (s *deviceService) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	res := s.db.WithContext(ctx).Where("user_id = ? AND device_id = ?", userID, deviceID).Delete(&model.UserDevice{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (s *deviceService) ConnectDevice(ctx context.Context, req *dto.ConnectDeviceRequest) (*dto.UserDeviceResponse, error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	device, err := s.getByUserAndDeviceID(ctx, req.UserID, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, errs.ErrDeviceNotFound
+	}
+	now := time.Now()
+	updates := map[string]interface{}{
+		"connection_id":  req.ConnectionID,
+		"is_connected":   true,
+		"last_heartbeat": now,
+	}
+	if req.IPAddress != "" {
+		updates["ip_address"] = req.IPAddress
+	}
+	if req.UserAgent != "" {
+		updates["user_agent"] = req.UserAgent
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// connection_id уникален среди подключённых: соединение, переданное другому устройству
+		// (переподключение с тем же id), у прежнего устройства закрывается.
+		if err := tx.Model(&model.UserDevice{}).
+			Where("connection_id = ? AND id <> ?", req.ConnectionID, device.ID).
+			Updates(map[string]interface{}{"connection_id": "", "is_connected": false}).Error; err != nil {
+			return err
+		}
+		return tx.Model(device).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	device.ConnectionID = req.ConnectionID
+	device.IsConnected = true
+	device.LastHeartbeat = &now
+	if req.IPAddress != "" {
+		device.IPAddress = optionalString(req.IPAddress)
+	}
+	if req.UserAgent != "" {
+		device.UserAgent = req.UserAgent
+	}
+	return mapper.DeviceToResponse(device), nil
+}
+
+func (s *deviceService) DisconnectDevice(ctx context.Context, connectionID string) (*dto.UserDeviceResponse, error) {
+	device, err := s.getByConnectionID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, errs.ErrDeviceNotFound
+	}
+	if err := s.db.WithContext(ctx).Model(device).Updates(map[string]interface{}{
+		"connection_id": "",
+		"is_connected":  false,
+	}).Error; err != nil {
+		return nil, err
+	}
+	device.ConnectionID = ""
+	device.IsConnected = false
+	return mapper.DeviceToResponse(device), nil
+}
+
+func (s *deviceService) Heartbeat(ctx context.Context, connectionID string) (*dto.UserDeviceResponse, error) {
+	device, err := s.getByConnectionID(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil || !device.IsConnected {
+		return nil, errs.ErrDeviceNotFound
+	}
+	now := time.Now()
+	if err := s.db.WithContext(ctx).Model(device).Update("last_heartbeat", now).Error; err != nil {
+		return nil, err
+	}
+	device.LastHeartbeat = &now
+	return mapper.DeviceToResponse(device), nil
+}
+
+// optionalString возвращает nil для пустой строки (nullable-колонки вроде INET).
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
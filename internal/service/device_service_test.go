@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+func TestDevice_RegisterConnectHeartbeatRemove(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.UserDevice{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	deviceSvc := NewDeviceService(conn)
+	ctx := context.Background()
+
+	u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "phone@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	// Повторная регистрация того же device_id обновляет запись, а не создаёт вторую.
+	first, err := deviceSvc.RegisterDevice(ctx, u.ID, &dto.RegisterDeviceRequest{DeviceID: "phone", DeviceType: "mobile", UserAgent: "app/1"})
+	if err != nil {
+		t.Fatalf("RegisterDevice failed: %v", err)
+	}
+	again, err := deviceSvc.RegisterDevice(ctx, u.ID, &dto.RegisterDeviceRequest{DeviceID: "phone", DeviceType: "mobile", UserAgent: "app/2", BandwidthLimit: 500})
+	if err != nil {
+		t.Fatalf("RegisterDevice (upsert) failed: %v", err)
+	}
+	if again.ID != first.ID || again.UserAgent != "app/2" || again.BandwidthLimit == nil || *again.BandwidthLimit != 500 {
+		t.Fatalf("Expected upsert of %s, got %+v", first.ID, again)
+	}
+	if _, err := deviceSvc.RegisterDevice(ctx, u.ID, &dto.RegisterDeviceRequest{DeviceID: "laptop", DeviceType: "web"}); err != nil {
+		t.Fatalf("RegisterDevice failed: %v", err)
+	}
+	list, err := deviceSvc.ListDevices(ctx, u.ID)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListDevices: %v, %d devices", err, len(list))
+	}
+
+	if _, err := deviceSvc.Heartbeat(ctx, "ws-1"); !errors.Is(err, errs.ErrDeviceNotFound) {
+		t.Fatalf("Heartbeat before connect: expected ErrDeviceNotFound, got %v", err)
+	}
+	connected, err := deviceSvc.ConnectDevice(ctx, &dto.ConnectDeviceRequest{UserID: u.ID, DeviceID: "phone", ConnectionID: "ws-1"})
+	if err != nil || !connected.IsConnected || connected.LastHeartbeat == nil {
+		t.Fatalf("ConnectDevice: %v %+v", err, connected)
+	}
+	beat, err := deviceSvc.Heartbeat(ctx, "ws-1")
+	if err != nil || beat.DeviceID != "phone" {
+		t.Fatalf("Heartbeat: %v %+v", err, beat)
+	}
+
+	// Тот же connection_id у другого устройства: прежнее отключается, Heartbeat попадает в новое.
+	if _, err := deviceSvc.ConnectDevice(ctx, &dto.ConnectDeviceRequest{UserID: u.ID, DeviceID: "laptop", ConnectionID: "ws-1"}); err != nil {
+		t.Fatalf("ConnectDevice (laptop) failed: %v", err)
+	}
+	beat, err = deviceSvc.Heartbeat(ctx, "ws-1")
+	if err != nil || beat.DeviceID != "laptop" {
+		t.Fatalf("Heartbeat after reconnect: %v %+v", err, beat)
+	}
+	var phone model.UserDevice
+	conn.Where("user_id = ? AND device_id = ?", u.ID, "phone").First(&phone)
+	if phone.IsConnected || phone.ConnectionID != "" {
+		t.Errorf("Expected previous device disconnected, got connected=%v connection_id=%q", phone.IsConnected, phone.ConnectionID)
+	}
+
+	disconnected, err := deviceSvc.DisconnectDevice(ctx, "ws-1")
+	if err != nil || disconnected.DeviceID != "laptop" || disconnected.IsConnected {
+		t.Fatalf("DisconnectDevice: %v %+v", err, disconnected)
+	}
+	if _, err := deviceSvc.DisconnectDevice(ctx, "ws-1"); !errors.Is(err, errs.ErrDeviceNotFound) {
+		t.Fatalf("second DisconnectDevice: expected ErrDeviceNotFound, got %v", err)
+	}
+
+	if err := deviceSvc.RemoveDevice(ctx, u.ID, "phone"); err != nil {
+		t.Fatalf("RemoveDevice failed: %v", err)
+	}
+	if err := deviceSvc.RemoveDevice(ctx, u.ID, "phone"); !errors.Is(err, errs.ErrDeviceNotFound) {
+		t.Fatalf("second RemoveDevice: expected ErrDeviceNotFound, got %v", err)
+	}
+	if _, err := deviceSvc.ConnectDevice(ctx, &dto.ConnectDeviceRequest{UserID: u.ID, DeviceID: "phone", ConnectionID: "ws-2"}); !errors.Is(err, errs.ErrDeviceNotFound) {
+		t.Fatalf("ConnectDevice for removed device: expected ErrDeviceNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/notify"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// EmailVerificationService — контракт подтверждения email по одноразовому токену из письма.
+type EmailVerificationService interface {
+	// Send выпускает токен и отправляет письмо (после регистрации).
+	Send(ctx context.Context, userID string) error
+	// Verify гасит токен и отмечает email подтверждённым.
+	Verify(ctx context.Context, token string) (*dto.UserResponse, error)
+	// ResendForUser — повторная отправка аутентифицированному пользователю:
+	// errs.ErrEmailAlreadyVerified, errs.ErrVerificationCooldown.
+	ResendForUser(ctx context.Context, userID string) error
+	// ResendForEmail — повторная отправка по адресу без токена. Неизвестный адрес, подтверждённый
+	// email, cooldown и ошибка доставки (пишется в лог) не отличаются от успеха — ответ не раскрывает
+	// наличие учётной записи.
+	ResendForEmail(ctx context.Context, email string) error
+}
+
+type emailVerificationService struct {
+	db       *gorm.DB
+	notifier notify.Notifier
+	ttl      time.Duration
+	cooldown time.Duration
+	link     string // шаблон ссылки с плейсхолдером {token}; пусто — в письме только токен
+}
+
+func NewEmailVerificationService(db *gorm.DB, notifier notify.Notifier, ttl, cooldown time.Duration, linkTemplate string) EmailVerificationService {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &emailVerificationService{db: db, notifier: notifier, ttl: ttl, cooldown: cooldown, link: linkTemplate}
+}
+
+func (s *emailVerificationService) Send(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	var u model.User
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrUserNotFound
+		}
+		return err
+	}
+	return s.send(ctx, &u, false)
+}
+
+func (s *emailVerificationService) ResendForUser(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	var u model.User
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrUserNotFound
+		}
+		return err
+	}
+	return s.send(ctx, &u, true)
+}
+
+func (s *emailVerificationService) ResendForEmail(ctx context.Context, email string) error {
+	var u model.User
+	err := s.db.WithContext(ctx).Where("email = ?", strings.TrimSpace(email)).First(&u).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = s.send(ctx, &u, true)
+	if err != nil && !errors.Is(err, errs.ErrEmailAlreadyVerified) && !errors.Is(err, errs.ErrVerificationCooldown) {
+		log.Printf("resend verification for %s: %v", u.ID, err)
+	}
+	return nil
+}
+
+// send выпускает новый токен (прежние гасятся) и отправляет письмо; resend — проверять cooldown.
+func (s *emailVerificationService) send(ctx context.Context, u *model.User, resend bool) error {
+	if u.EmailVerifiedAt != nil {
+		return errs.ErrEmailAlreadyVerified
+	}
+	var token string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if resend && s.cooldown > 0 {
+			var recent int64
+			if err := tx.Model(&model.UserToken{}).
+				Where("user_id = ? AND purpose = ? AND created_at > ?", u.ID, constants.TokenPurposeEmailVerification, time.Now().Add(-s.cooldown)).
+				Count(&recent).Error; err != nil {
+				return err
+			}
+			if recent > 0 {
+				return errs.ErrVerificationCooldown
+			}
+		}
+		var err error
+		token, err = issueUserToken(tx, u.ID, constants.TokenPurposeEmailVerification, s.ttl)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	msg := actionMessage(notify.KindEmailVerification, u.Email, "Confirm your email", "confirm your email", s.link, token, s.ttl)
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		return fmt.Errorf("send email verification: %w", err)
+	}
+	return nil
+}
+
+func (s *emailVerificationService) Verify(ctx context.Context, token string) (*dto.UserResponse, error) {
+	var u model.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := consumeUserToken(tx, token, constants.TokenPurposeEmailVerification)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return errs.ErrInvalidVerificationToken
+		}
+		if err := tx.Where("id = ?", t.UserID).First(&u).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrInvalidVerificationToken
+			}
+			return err
+		}
+		if u.EmailVerifiedAt != nil {
+			return nil
+		}
+		before := u
+		now := time.Now()
+		u.EmailVerifiedAt = &now
+		if err := tx.Model(&u).Updates(map[string]interface{}{"email_verified_at": now, "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionEmailVerify,
+			TargetID: u.ID,
+			ActorID:  u.ID,
+			Changes:  userAuditChanges(&before, &u, false),
+		}); err != nil {
+			return err
+		}
+		return enqueueUserUpdated(tx, &before, &u, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.UserToResponse(&u), nil
+}
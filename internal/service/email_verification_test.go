@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+func TestEmailVerification_PolicyAndCooldown(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.RefreshToken{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, _ := auth.NewConfig("test-secret", "15m", "24h")
+	notifier := &captureNotifier{}
+	userSvc := NewUserService(conn)
+	verifySvc := NewEmailVerificationService(conn, notifier, time.Hour, time.Minute, "")
+	required := NewTokenService(conn, cfg, nil, AccessPolicy{EmailVerification: constants.EmailVerificationRequired})
+	limited := NewTokenService(conn, cfg, nil, AccessPolicy{EmailVerification: constants.EmailVerificationLimited})
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "client",
+		Email:    "client@example.com",
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if user.EmailVerified {
+		t.Fatal("new user must not be verified")
+	}
+	if _, err := required.Issue(ctx, user, ""); !errors.Is(err, errs.ErrEmailNotVerified) {
+		t.Fatalf("required policy: expected ErrEmailNotVerified, got %v", err)
+	}
+	pair, err := limited.Issue(ctx, user, "")
+	if err != nil {
+		t.Fatalf("limited Issue failed: %v", err)
+	}
+	if claims, err := cfg.ValidateAccess(pair.AccessToken); err != nil || !claims.Limited || len(claims.Permissions) != 0 {
+		t.Fatalf("limited policy must issue a limited token, claims=%+v err=%v", claims, err)
+	}
+
+	if err := verifySvc.Send(ctx, user.ID); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := verifySvc.ResendForUser(ctx, user.ID); !errors.Is(err, errs.ErrVerificationCooldown) {
+		t.Fatalf("resend within cooldown: expected ErrVerificationCooldown, got %v", err)
+	}
+	if err := verifySvc.ResendForEmail(ctx, user.Email); err != nil || len(notifier.sent) != 1 {
+		t.Fatalf("anonymous resend within cooldown must be silent, err=%v sent=%d", err, len(notifier.sent))
+	}
+
+	verified, err := verifySvc.Verify(ctx, notifier.sent[0].Data["token"])
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !verified.EmailVerified {
+		t.Fatal("user must be verified")
+	}
+	if _, err := verifySvc.Verify(ctx, notifier.sent[0].Data["token"]); !errors.Is(err, errs.ErrInvalidVerificationToken) {
+		t.Fatalf("used token: expected ErrInvalidVerificationToken, got %v", err)
+	}
+	if err := verifySvc.ResendForUser(ctx, user.ID); !errors.Is(err, errs.ErrEmailAlreadyVerified) {
+		t.Fatalf("expected ErrEmailAlreadyVerified, got %v", err)
+	}
+	if _, err := required.Issue(ctx, verified, ""); err != nil {
+		t.Fatalf("verified user must get tokens: %v", err)
+	}
+
+	changed, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: user.ID, Email: "new@example.com", UpdateMask: []string{dto.UserFieldEmail}})
+	if err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+	if changed.EmailVerified {
+		t.Fatal("changing email must reset verification")
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/outbox"
+	eventsv1 "github.com/psds-microservice/user-service/pkg/gen/user_service/events/v1"
+)
+
+// Конструкторы событий outbox (pkg/user_service/user_events.proto). Вызываются внутри транзакции изменения.
+
+func enqueueUserCreated(tx *gorm.DB, u *model.User) error {
+	env := outbox.NewEnvelope(outbox.EventUserCreated, u.ID)
+	env.Payload = &eventsv1.Envelope_UserCreated{UserCreated: &eventsv1.UserCreated{
+		UserId:         u.ID,
+		Username:       u.Username,
+		Email:          u.Email,
+		Role:           u.Role,
+		Status:         u.Status,
+		OperatorStatus: u.OperatorStatus,
+	}}
+	return outbox.Enqueue(tx, env)
+}
+
+// enqueueUserUpdated пишет user.updated (если есть изменения) и user.status_changed при смене статуса.
+func enqueueUserUpdated(tx *gorm.DB, before, after *model.User, passwordChanged bool) error {
+	changed := changedUserFields(before, after)
+	if passwordChanged {
+		changed = append(changed, "password")
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	env := outbox.NewEnvelope(outbox.EventUserUpdated, after.ID)
+	env.Payload = &eventsv1.Envelope_UserUpdated{UserUpdated: &eventsv1.UserUpdated{
+		UserId:        after.ID,
+		ChangedFields: changed,
+		Username:      after.Username,
+		Email:         after.Email,
+		Status:        after.Status,
+		Role:          after.Role,
+	}}
+	if err := outbox.Enqueue(tx, env); err != nil {
+		return err
+	}
+	if before.Status == after.Status {
+		return nil
+	}
+	env = outbox.NewEnvelope(outbox.EventUserStatusChanged, after.ID)
+	env.Payload = &eventsv1.Envelope_UserStatusChanged{UserStatusChanged: &eventsv1.UserStatusChanged{
+		UserId:    after.ID,
+		OldStatus: before.Status,
+		NewStatus: after.Status,
+	}}
+	return outbox.Enqueue(tx, env)
+}
+
+func changedUserFields(before, after *model.User) []string {
+	var out []string
+	add := func(name string, changed bool) {
+		if changed {
+			out = append(out, name)
+		}
+	}
+	add("username", before.Username != after.Username)
+	add("email", before.Email != after.Email)
+	add("email_verified", (before.EmailVerifiedAt == nil) != (after.EmailVerifiedAt == nil))
+	add("phone", before.Phone != after.Phone)
+	add("status", before.Status != after.Status)
+	add("role", before.Role != after.Role)
+	add("full_name", before.FullName != after.FullName)
+	add("avatar_url", before.AvatarURL != after.AvatarURL)
+	add("timezone", before.Timezone != after.Timezone)
+	add("language", before.Language != after.Language)
+	add("company", before.Company != after.Company)
+	add("specialization", before.Specialization != after.Specialization)
+	return out
+}
+
+func enqueueUserDeleted(tx *gorm.DB, userID string) error {
+	env := outbox.NewEnvelope(outbox.EventUserDeleted, userID)
+	env.Payload = &eventsv1.Envelope_UserDeleted{UserDeleted: &eventsv1.UserDeleted{UserId: userID}}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueueUserRestored(tx *gorm.DB, u *model.User) error {
+	env := outbox.NewEnvelope(outbox.EventUserRestored, u.ID)
+	env.Payload = &eventsv1.Envelope_UserRestored{UserRestored: &eventsv1.UserRestored{
+		UserId:   u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+		Role:     u.Role,
+		Status:   u.Status,
+	}}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueueUserPurged(tx *gorm.DB, userID string) error {
+	env := outbox.NewEnvelope(outbox.EventUserPurged, userID)
+	env.Payload = &eventsv1.Envelope_UserPurged{UserPurged: &eventsv1.UserPurged{UserId: userID}}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueueOperatorVerified(tx *gorm.DB, userID, oldStatus, newStatus string) error {
+	env := outbox.NewEnvelope(outbox.EventOperatorVerified, userID)
+	env.Payload = &eventsv1.Envelope_OperatorVerified{OperatorVerified: &eventsv1.OperatorVerified{
+		UserId:    userID,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueueAvailabilityChanged(tx *gorm.DB, userID string, available, automatic bool) error {
+	env := outbox.NewEnvelope(outbox.EventOperatorAvailabilityChanged, userID)
+	env.Payload = &eventsv1.Envelope_OperatorAvailabilityChanged{OperatorAvailabilityChanged: &eventsv1.OperatorAvailabilityChanged{
+		UserId:      userID,
+		IsAvailable: available,
+		Automatic:   automatic,
+	}}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueuePresenceChanged(tx *gorm.DB, u *model.User) error {
+	env := outbox.NewEnvelope(outbox.EventUserPresenceChanged, u.ID)
+	payload := &eventsv1.UserPresenceChanged{UserId: u.ID, IsOnline: u.IsOnline}
+	if u.LastSeenAt != nil {
+		payload.LastSeenAt = timestamppb.New(*u.LastSeenAt)
+	}
+	env.Payload = &eventsv1.Envelope_UserPresenceChanged{UserPresenceChanged: payload}
+	return outbox.Enqueue(tx, env)
+}
+
+func enqueueSessionStarted(tx *gorm.DB, s *model.UserSession) error {
+	env := outbox.NewEnvelope(outbox.EventUserSessionStarted, s.UserID)
+	env.Payload = &eventsv1.Envelope_SessionStarted{SessionStarted: &eventsv1.SessionStarted{
+		UserId:            s.UserID,
+		SessionId:         s.ID,
+		SessionType:       s.SessionType,
+		SessionExternalId: s.SessionExternalID,
+		ParticipantRole:   s.ParticipantRole,
+		JoinedAt:          timestamppb.New(s.JoinedAt),
+	}}
+	return outbox.Enqueue(tx, env)
+}
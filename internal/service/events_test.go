@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/outbox"
+	"github.com/psds-microservice/user-service/pkg/constants"
+	eventsv1 "github.com/psds-microservice/user-service/pkg/gen/user_service/events/v1"
+)
+
+func TestOutbox_UserLifecycleEventsRelayed(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "bob",
+		Email:    "bob@example.com",
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{
+		ID:         user.ID,
+		Status:     constants.UserStatusBlocked,
+		UpdateMask: []string{dto.UserFieldStatus},
+	}); err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+
+	pub := outbox.NewMemoryPublisher()
+	relay := outbox.NewRelay(conn, pub, 10, 3, time.Second)
+	n, err := relay.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	want := []string{outbox.EventUserCreated, outbox.EventUserUpdated, outbox.EventUserStatusChanged}
+	msgs := pub.Messages()
+	if n != len(want) || len(msgs) != len(want) {
+		t.Fatalf("expected %d published events, got %d (%d messages)", len(want), n, len(msgs))
+	}
+	for i, m := range msgs {
+		if m.Type != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], m.Type)
+		}
+	}
+
+	var env eventsv1.Envelope
+	if err := proto.Unmarshal(msgs[2].Body, &env); err != nil {
+		t.Fatalf("unmarshal envelope: %v", err)
+	}
+	changed := env.GetUserStatusChanged()
+	if env.GetEventId() != msgs[2].ID || changed.GetNewStatus() != constants.UserStatusBlocked || changed.GetOldStatus() != constants.UserStatusActive {
+		t.Errorf("unexpected status_changed payload: %v", &env)
+	}
+
+	var pending int64
+	conn.Model(&model.OutboxEvent{}).Where("published_at IS NULL").Count(&pending)
+	if pending != 0 {
+		t.Errorf("expected all events marked published, %d pending", pending)
+	}
+	if n, _ := relay.Flush(ctx); n != 0 {
+		t.Errorf("second flush must publish nothing, got %d", n)
+	}
+}
+
+// rejectingPublisher не принимает события типа badType, остальные передаёт в MemoryPublisher.
+type rejectingPublisher struct {
+	*outbox.MemoryPublisher
+	badType string
+}
+
+func (p rejectingPublisher) Publish(ctx context.Context, msg outbox.Message) error {
+	if msg.Type == p.badType {
+		return errors.New("unroutable")
+	}
+	return p.MemoryPublisher.Publish(ctx, msg)
+}
+
+func TestOutbox_RelayParksEventAfterMaxAttempts(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "parked@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if _, err := userSvc.SetStatus(ctx, user.ID, constants.UserStatusBlocked); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+
+	pub := rejectingPublisher{MemoryPublisher: outbox.NewMemoryPublisher(), badType: outbox.EventUserCreated}
+	relay := outbox.NewRelay(conn, pub, 10, 2, time.Second)
+	for i := 0; i < 2; i++ {
+		if n, err := relay.Flush(ctx); err == nil || n != 0 {
+			t.Fatalf("flush %d: expected publish error and nothing published, got n=%d err=%v", i, n, err)
+		}
+	}
+	var parked model.OutboxEvent
+	if err := conn.Where("event_type = ?", outbox.EventUserCreated).First(&parked).Error; err != nil {
+		t.Fatalf("load event: %v", err)
+	}
+	if parked.ParkedAt == nil || parked.Attempts != 2 || parked.LastError != "unroutable" || parked.ClaimedUntil != nil {
+		t.Fatalf("expected event parked after 2 attempts, got %+v", parked)
+	}
+
+	// Отложенное событие больше не задерживает очередь.
+	n, err := relay.Flush(ctx)
+	if err != nil || n == 0 {
+		t.Fatalf("expected remaining events published, got n=%d err=%v", n, err)
+	}
+	for _, m := range pub.Messages() {
+		if m.Type == outbox.EventUserCreated {
+			t.Fatalf("parked event must not be published")
+		}
+	}
+	var pending int64
+	conn.Model(&model.OutboxEvent{}).Where("published_at IS NULL AND parked_at IS NULL").Count(&pending)
+	if pending != 0 {
+		t.Errorf("expected no pending events, got %d", pending)
+	}
+}
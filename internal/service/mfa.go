@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// recoveryCodeCount — сколько кодов восстановления выдаётся при включении 2FA.
+const recoveryCodeCount = 10
+
+// mfaChallengeMaxFailures — после стольких неверных кодов MFA-челлендж гасится; нужен новый вход по паролю.
+const mfaChallengeMaxFailures = 5
+
+// MFAService — контракт двухфакторной аутентификации (TOTP + коды восстановления).
+type MFAService interface {
+	// Enroll генерирует новый секрет (2FA ещё не включена до Confirm).
+	Enroll(ctx context.Context, userID string) (*dto.MFAEnrollment, error)
+	// Confirm включает 2FA по первому коду из приложения и возвращает коды восстановления (показываются один раз).
+	Confirm(ctx context.Context, userID, code string) ([]string, error)
+	// Disable выключает 2FA по коду TOTP или коду восстановления.
+	Disable(ctx context.Context, userID, code string) error
+	// Challenge выдаёт токен MFA-челленджа после проверки пароля.
+	Challenge(ctx context.Context, userID, deviceID string) (string, error)
+	// VerifyChallenge проверяет челлендж и второй фактор; токен челленджа одноразовый. Неверный код
+	// учитывается в login_throttle (clientIP — адрес клиента, "" — не учитывается) и в счётчике челленджа.
+	VerifyChallenge(ctx context.Context, mfaToken, code, clientIP string) (*dto.UserResponse, string, error)
+}
+
+type mfaService struct {
+	db                *gorm.DB
+	jwt               auth.Config
+	throttle          *loginThrottle
+	issuer            string // issuer в otpauth URI
+	requiredForAdmins bool
+}
+
+func NewMFAService(db *gorm.DB, jwt auth.Config, limits LoginLimits, issuer string, requiredForAdmins bool) MFAService {
+	if issuer == "" {
+		issuer = "PSDS"
+	}
+	return &mfaService{db: db, jwt: jwt, throttle: newLoginThrottle(db, limits), issuer: issuer, requiredForAdmins: requiredForAdmins}
+}
+
+func (s *mfaService) getUser(tx *gorm.DB, userID string) (*model.User, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var u model.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", userID).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *mfaService) Enroll(ctx context.Context, userID string) (*dto.MFAEnrollment, error) {
+	var out *dto.MFAEnrollment
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		u, err := s.getUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if u.MFAEnabledAt != nil {
+			return errs.ErrMFAAlreadyEnabled
+		}
+		secret, err := auth.GenerateTOTPSecret()
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(u).Updates(map[string]interface{}{"mfa_secret": secret, "mfa_last_step": 0}).Error; err != nil {
+			return err
+		}
+		out = &dto.MFAEnrollment{Secret: secret, URI: auth.TOTPURI(s.issuer, u.Email, secret)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *mfaService) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	var codes []string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		u, err := s.getUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if u.MFAEnabledAt != nil {
+			return errs.ErrMFAAlreadyEnabled
+		}
+		if u.MFASecret == "" {
+			return errs.ErrMFANotEnrolled
+		}
+		step, ok := auth.ValidateTOTP(u.MFASecret, strings.TrimSpace(code), time.Now(), u.MFALastStep)
+		if !ok {
+			return errs.ErrInvalidMFACode
+		}
+		if err := tx.Model(u).Updates(map[string]interface{}{
+			"mfa_enabled_at": time.Now(),
+			"mfa_last_step":  step,
+			"version":        bumpVersion,
+		}).Error; err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionMFAEnable,
+			TargetID: u.ID,
+			Changes:  audit.Changes{"mfa_enabled": {Old: false, New: true}},
+		}); err != nil {
+			return err
+		}
+		codes, err = replaceRecoveryCodes(tx, u.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID, code string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		u, err := s.getUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if u.MFAEnabledAt == nil {
+			return errs.ErrMFANotEnrolled
+		}
+		if s.requiredForAdmins && u.Role == constants.RoleAdmin {
+			return errs.ErrMFARequired
+		}
+		if err := checkSecondFactor(tx, u, code); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", u.ID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(u).Updates(map[string]interface{}{
+			"mfa_secret":     "",
+			"mfa_enabled_at": nil,
+			"mfa_last_step":  0,
+			"version":        bumpVersion,
+		}).Error; err != nil {
+			return err
+		}
+		return audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionMFADisable,
+			TargetID: u.ID,
+			Changes:  audit.Changes{"mfa_enabled": {Old: true, New: false}},
+		})
+	})
+}
+
+func (s *mfaService) Challenge(ctx context.Context, userID, deviceID string) (string, error) {
+	token, jti, expiresAt, err := s.jwt.GenerateMFAChallenge(userID, deviceID)
+	if err != nil {
+		return "", err
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at < ?", userID, time.Now()).Delete(&model.MFAChallenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.MFAChallenge{ID: jti, UserID: userID, ExpiresAt: expiresAt}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// VerifyChallenge блокирует строку челленджа и гасит её в той же транзакции, что и второй фактор:
+// параллельные попытки с разными кодами восстановления не завершат вход дважды.
+func (s *mfaService) VerifyChallenge(ctx context.Context, mfaToken, code, clientIP string) (*dto.UserResponse, string, error) {
+	claims, err := s.jwt.ValidateMFAChallenge(mfaToken)
+	if err != nil {
+		return nil, "", errs.ErrInvalidMFAToken
+	}
+	var owner model.User
+	if err := s.db.WithContext(ctx).Select("id", "email").Where("id = ?", claims.UserID).First(&owner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errs.ErrInvalidMFAToken
+		}
+		return nil, "", err
+	}
+	keys := s.throttle.keys(owner.Email, clientIP)
+	if err := s.throttle.checkLocked(ctx, keys); err != nil {
+		return nil, "", err
+	}
+	var (
+		u      *model.User
+		failed bool
+	)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ch model.MFAChallenge
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", claims.ID, claims.UserID).First(&ch).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrInvalidMFAToken
+		}
+		if err != nil {
+			return err
+		}
+		if ch.UsedAt != nil {
+			return errs.ErrInvalidMFAToken
+		}
+		u, err = s.getUser(tx, claims.UserID)
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return errs.ErrInvalidMFAToken
+		}
+		if err != nil {
+			return err
+		}
+		if u.MFAEnabledAt == nil {
+			return errs.ErrInvalidMFAToken
+		}
+		if err := statusError(u); err != nil {
+			return err
+		}
+		now := time.Now()
+		err = checkSecondFactor(tx, u, code)
+		if errors.Is(err, errs.ErrInvalidMFACode) {
+			// Неудача фиксируется (транзакция не откатывается), ошибка возвращается после неё.
+			failed = true
+			updates := map[string]interface{}{"failures": ch.Failures + 1}
+			if ch.Failures+1 >= mfaChallengeMaxFailures {
+				updates["used_at"] = now
+			}
+			return tx.Model(&ch).Updates(updates).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&ch).Update("used_at", now).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if failed {
+		if err := s.throttle.recordFailure(ctx, keys, u); err != nil {
+			return nil, "", err
+		}
+		return nil, "", errs.ErrInvalidMFACode
+	}
+	if err := s.throttle.recordSuccess(ctx, keys, u); err != nil {
+		return nil, "", err
+	}
+	return mapper.UserToResponse(u), claims.DeviceID, nil
+}
+
+// checkSecondFactor принимает код TOTP (6 цифр, не повторный) или неиспользованный код восстановления
+// и фиксирует его использование в tx.
+func checkSecondFactor(tx *gorm.DB, u *model.User, code string) error {
+	code = strings.TrimSpace(code)
+	if step, ok := auth.ValidateTOTP(u.MFASecret, code, time.Now(), u.MFALastStep); ok {
+		return tx.Model(u).Update("mfa_last_step", step).Error
+	}
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return errs.ErrInvalidMFACode
+	}
+	res := tx.Model(&model.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", u.ID, hashTokenID(normalized)).
+		Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// recoveryAlphabet — base32 (RFC 4648, нижний регистр); код вида xxxxx-xxxxx (50 бит).
+const recoveryAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+func replaceRecoveryCodes(tx *gorm.DB, userID string) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, recoveryCodeCount)
+	for len(codes) < recoveryCodeCount {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		for i := range b {
+			b[i] = recoveryAlphabet[b[i]&31]
+		}
+		code := string(b[:5]) + "-" + string(b[5:])
+		row := &model.MFARecoveryCode{
+			ID:       uuid.New().String(),
+			UserID:   userID,
+			CodeHash: hashTokenID(normalizeRecoveryCode(code)),
+		}
+		if err := tx.Create(row).Error; err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode убирает дефисы и пробелы и приводит к нижнему регистру.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+func TestMFA_EnrolChallengeAndRecovery(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.RefreshToken{}, &model.MFARecoveryCode{}, &model.MFAChallenge{}, &model.LoginThrottle{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, _ := auth.NewConfig("test-secret", "15m", "24h")
+	blacklist := auth.NewMemoryBlacklist()
+	userSvc := NewUserService(conn)
+	mfaSvc := NewMFAService(conn, cfg, LoginLimits{}, "PSDS", true)
+	tokenSvc := NewTokenService(conn, cfg, blacklist, AccessPolicy{MFARequiredForAdmins: true})
+	ctx := context.Background()
+
+	admin, err := userSvc.CreateAdmin(ctx, &dto.CreateUserRequest{
+		Username: "admin",
+		Email:    "admin@example.com",
+		Password: "secretpassword",
+	}, true)
+	if err != nil {
+		t.Fatalf("CreateAdmin failed: %v", err)
+	}
+	pair, err := tokenSvc.Issue(ctx, admin, "")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if claims, _ := cfg.ValidateAccess(pair.AccessToken); claims == nil || !claims.Limited {
+		t.Fatal("admin without 2FA must get a limited token when 2FA is mandatory")
+	}
+
+	enrollment, err := mfaSvc.Enroll(ctx, admin.ID)
+	if err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	if _, err := mfaSvc.Confirm(ctx, admin.ID, "000000"); !errors.Is(err, errs.ErrInvalidMFACode) {
+		t.Fatalf("wrong code: expected ErrInvalidMFACode, got %v", err)
+	}
+	code, _ := auth.TOTPCode(enrollment.Secret, time.Now())
+	recovery, err := mfaSvc.Confirm(ctx, admin.ID, code)
+	if err != nil {
+		t.Fatalf("Confirm failed: %v", err)
+	}
+	if len(recovery) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(recovery))
+	}
+
+	challenge, err := mfaSvc.Challenge(ctx, admin.ID, "laptop")
+	if err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if _, err := cfg.ValidateAccess(challenge); err == nil {
+		t.Fatal("mfa challenge must not be accepted as an access token")
+	}
+	if _, _, err := mfaSvc.VerifyChallenge(ctx, challenge, code, ""); !errors.Is(err, errs.ErrInvalidMFACode) {
+		t.Fatalf("replayed TOTP code: expected ErrInvalidMFACode, got %v", err)
+	}
+	user, deviceID, err := mfaSvc.VerifyChallenge(ctx, challenge, recovery[0], "")
+	if err != nil {
+		t.Fatalf("VerifyChallenge with recovery code failed: %v", err)
+	}
+	if !user.MFAEnabled || deviceID != "laptop" {
+		t.Fatalf("unexpected verify result: mfa=%v device=%q", user.MFAEnabled, deviceID)
+	}
+	if _, _, err := mfaSvc.VerifyChallenge(ctx, challenge, recovery[1], ""); !errors.Is(err, errs.ErrInvalidMFAToken) {
+		t.Fatalf("challenge must be single-use, got %v", err)
+	}
+	second, _ := mfaSvc.Challenge(ctx, admin.ID, "")
+	if _, _, err := mfaSvc.VerifyChallenge(ctx, second, recovery[0], ""); !errors.Is(err, errs.ErrInvalidMFACode) {
+		t.Fatalf("recovery code must be single-use, got %v", err)
+	}
+	// После mfaChallengeMaxFailures неверных кодов челлендж погашен и верный код уже не принимается.
+	for i := 1; i < mfaChallengeMaxFailures; i++ {
+		if _, _, err := mfaSvc.VerifyChallenge(ctx, second, "000000", ""); !errors.Is(err, errs.ErrInvalidMFACode) {
+			t.Fatalf("wrong code #%d: expected ErrInvalidMFACode, got %v", i+1, err)
+		}
+	}
+	if _, _, err := mfaSvc.VerifyChallenge(ctx, second, recovery[1], ""); !errors.Is(err, errs.ErrInvalidMFAToken) {
+		t.Fatalf("challenge must be burned after %d wrong codes, got %v", mfaChallengeMaxFailures, err)
+	}
+
+	full, err := tokenSvc.Issue(ctx, user, deviceID)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if claims, _ := cfg.ValidateAccess(full.AccessToken); claims == nil || claims.Limited {
+		t.Fatal("admin with 2FA must get a full token")
+	}
+	if err := mfaSvc.Disable(ctx, admin.ID, recovery[2]); !errors.Is(err, errs.ErrMFARequired) {
+		t.Fatalf("admin must not disable mandatory 2FA, got %v", err)
+	}
+}
+
+func TestMFA_WrongCodesCountAsLoginFailures(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.MFARecoveryCode{}, &model.MFAChallenge{}, &model.LoginThrottle{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, _ := auth.NewConfig("test-secret", "15m", "24h")
+	limits := LoginLimits{MaxFailures: 3, IPMaxFailures: 10, BaseLockout: time.Minute}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, limits)
+	mfaSvc := NewMFAService(conn, cfg, limits, "PSDS", false)
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "client",
+		Email:    "client@example.com",
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	enrollment, err := mfaSvc.Enroll(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+	code, _ := auth.TOTPCode(enrollment.Secret, time.Now())
+	if _, err := mfaSvc.Confirm(ctx, user.ID, code); err != nil {
+		t.Fatalf("Confirm failed: %v", err)
+	}
+
+	// Верный пароль пользователя с 2FA не сбрасывает счётчик: каждый новый челлендж продолжает его.
+	for i := 0; i < limits.MaxFailures; i++ {
+		if _, err := authSvc.Login(ctx, "client@example.com", "secretpassword", "10.0.0.1"); err != nil {
+			t.Fatalf("Login #%d failed: %v", i+1, err)
+		}
+		challenge, err := mfaSvc.Challenge(ctx, user.ID, "")
+		if err != nil {
+			t.Fatalf("Challenge failed: %v", err)
+		}
+		if _, _, err := mfaSvc.VerifyChallenge(ctx, challenge, "000000", "10.0.0.1"); !errors.Is(err, errs.ErrInvalidMFACode) {
+			t.Fatalf("wrong code #%d: expected ErrInvalidMFACode, got %v", i+1, err)
+		}
+	}
+	if _, err := authSvc.Login(ctx, "client@example.com", "secretpassword", "10.0.0.2"); !errors.Is(err, errs.ErrTooManyLoginAttempts) {
+		t.Fatalf("account must be locked after wrong second factors, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/psds-microservice/user-service/internal/audit"
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/internal/errs"
 	"github.com/psds-microservice/user-service/internal/mapper"
@@ -78,8 +79,19 @@ func (s *operatorService) UpdateAvailability(ctx context.Context, userID string,
 	if user == nil {
 		return nil, errs.ErrUserNotFound
 	}
+	changed := user.IsAvailable != available
 	user.IsAvailable = available
-	if err := s.db.WithContext(ctx).Save(user).Error; err != nil {
+	user.AutoUnavailable = false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveUser(tx, user); err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+		return enqueueAvailabilityChanged(tx, user.ID, available, false)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return mapper.UserToResponse(user), nil
@@ -102,8 +114,19 @@ func (s *operatorService) VerifyOperator(ctx context.Context, operatorID string,
 	if user.Role != constants.RoleOperator {
 		return nil, errs.ErrNotOperator
 	}
+	before := *user
+	oldStatus := user.OperatorStatus
 	user.OperatorStatus = status
-	if err := s.db.WithContext(ctx).Save(user).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveUser(tx, user); err != nil {
+			return err
+		}
+		if err := auditUser(ctx, tx, audit.ActionOperatorVerify, &before, user, false); err != nil {
+			return err
+		}
+		return enqueueOperatorVerified(tx, user.ID, oldStatus, status)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return mapper.UserToResponse(user), nil
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/notify"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// PasswordResetService — контракт сброса пароля по одноразовому токену из письма.
+type PasswordResetService interface {
+	// RequestReset выпускает токен и отправляет его уведомлением. Для неизвестного или заблокированного
+	// email ничего не делает и не возвращает ошибку — ответ не раскрывает наличие учётной записи.
+	// Ошибка выпуска токена или доставки письма по той же причине только пишется в лог.
+	RequestReset(ctx context.Context, email string) error
+	// ConfirmReset гасит токен, устанавливает новый пароль и отзывает все токены пользователя.
+	ConfirmReset(ctx context.Context, token, newPassword string) error
+}
+
+type passwordResetService struct {
+	db       *gorm.DB
+	tokens   TokenService
+	notifier notify.Notifier
+	ttl      time.Duration
+	link     string // шаблон ссылки с плейсхолдером {token}; пусто — в письме только токен
+}
+
+func NewPasswordResetService(db *gorm.DB, tokens TokenService, notifier notify.Notifier, ttl time.Duration, linkTemplate string) PasswordResetService {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &passwordResetService{db: db, tokens: tokens, notifier: notifier, ttl: ttl, link: linkTemplate}
+}
+
+func (s *passwordResetService) RequestReset(ctx context.Context, email string) error {
+	var u model.User
+	err := s.db.WithContext(ctx).Where("email = ?", strings.TrimSpace(email)).First(&u).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.Status == constants.UserStatusBlocked {
+		return nil
+	}
+	var token string
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		token, err = issueUserToken(tx, u.ID, constants.TokenPurposePasswordReset, s.ttl)
+		return err
+	})
+	if err != nil {
+		log.Printf("password reset for %s: issue token: %v", u.ID, err)
+		return nil
+	}
+	msg := actionMessage(notify.KindPasswordReset, u.Email, "Password reset", "reset your password", s.link, token, s.ttl)
+	if err := s.notifier.Send(ctx, msg); err != nil {
+		log.Printf("password reset for %s: send: %v", u.ID, err)
+	}
+	return nil
+}
+
+func (s *passwordResetService) ConfirmReset(ctx context.Context, token, newPassword string) error {
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	var userID string
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		t, err := consumeUserToken(tx, token, constants.TokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return errs.ErrInvalidResetToken
+		}
+		var u model.User
+		if err := tx.Where("id = ?", t.UserID).First(&u).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrInvalidResetToken
+			}
+			return err
+		}
+		before := u
+		u.PasswordHash = hashed
+		if err := tx.Model(&u).Updates(map[string]interface{}{"password_hash": hashed, "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+		userID = u.ID
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionPasswordReset,
+			TargetID: u.ID,
+			ActorID:  u.ID,
+			Changes:  userAuditChanges(&before, &u, true),
+		}); err != nil {
+			return err
+		}
+		return enqueueUserUpdated(tx, &before, &u, true)
+	})
+	if err != nil {
+		return err
+	}
+	return s.tokens.RevokeAllForUser(ctx, userID)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/notify"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+type captureNotifier struct {
+	sent []notify.Message
+	err  error // ошибка доставки; письмо при этом не запоминается
+}
+
+func (n *captureNotifier) Send(_ context.Context, msg notify.Message) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func TestPasswordReset_SingleUseAndRevokesTokens(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.RefreshToken{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, _ := auth.NewConfig("test-secret", "15m", "24h")
+	blacklist := auth.NewMemoryBlacklist()
+	notifier := &captureNotifier{}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{})
+	tokenSvc := NewTokenService(conn, cfg, blacklist, AccessPolicy{EmailVerification: constants.EmailVerificationOff})
+	resetSvc := NewPasswordResetService(conn, tokenSvc, notifier, time.Hour, "https://app.example.com/reset?token={token}")
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "client",
+		Email:    "client@example.com",
+		Password: "oldpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	pair, err := tokenSvc.Issue(ctx, user, "")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+
+	if err := resetSvc.RequestReset(ctx, "nobody@example.com"); err != nil || len(notifier.sent) != 0 {
+		t.Fatalf("unknown email must succeed silently, err=%v sent=%d", err, len(notifier.sent))
+	}
+	// Ошибка доставки не отличает существующий адрес от неизвестного.
+	notifier.err = errors.New("smtp unavailable")
+	if err := resetSvc.RequestReset(ctx, user.Email); err != nil {
+		t.Fatalf("delivery failure must not be reported, got %v", err)
+	}
+	notifier.err = nil
+	if err := resetSvc.RequestReset(ctx, user.Email); err != nil {
+		t.Fatalf("RequestReset failed: %v", err)
+	}
+	if err := resetSvc.RequestReset(ctx, user.Email); err != nil {
+		t.Fatalf("RequestReset failed: %v", err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(notifier.sent))
+	}
+	stale, token := notifier.sent[0].Data["token"], notifier.sent[1].Data["token"]
+
+	if err := resetSvc.ConfirmReset(ctx, stale, "newpassword"); !errors.Is(err, errs.ErrInvalidResetToken) {
+		t.Fatalf("superseded token: expected ErrInvalidResetToken, got %v", err)
+	}
+	if err := resetSvc.ConfirmReset(ctx, token, "newpassword"); err != nil {
+		t.Fatalf("ConfirmReset failed: %v", err)
+	}
+	if err := resetSvc.ConfirmReset(ctx, token, "otherpassword"); !errors.Is(err, errs.ErrInvalidResetToken) {
+		t.Fatalf("used token: expected ErrInvalidResetToken, got %v", err)
+	}
+
+	if _, err := authSvc.Login(ctx, user.Email, "oldpassword", ""); !errors.Is(err, errs.ErrInvalidCredentials) {
+		t.Fatalf("old password must stop working, got %v", err)
+	}
+	if _, err := authSvc.Login(ctx, user.Email, "newpassword", ""); err != nil {
+		t.Fatalf("login with new password failed: %v", err)
+	}
+	if _, err := tokenSvc.Rotate(ctx, pair.RefreshToken); !errors.Is(err, errs.ErrInvalidRefreshToken) {
+		t.Fatalf("refresh token must be revoked, got %v", err)
+	}
+	claims, err := cfg.ValidateAccess(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccess failed: %v", err)
+	}
+	if revoked, _ := auth.IsRevoked(ctx, blacklist, claims); !revoked {
+		t.Fatal("access token must be revoked after password reset")
+	}
+}
@@ -49,7 +49,17 @@ func (s *presenceService) UpdatePresence(ctx context.Context, userID string, isO
 		return errs.ErrUserNotFound
 	}
 	now := time.Now()
+	changed := user.IsOnline != isOnline
 	user.IsOnline = isOnline
 	user.LastSeenAt = &now
-	return s.db.WithContext(ctx).Save(user).Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := saveUser(tx, user); err != nil {
+			return err
+		}
+		if !changed {
+			// Повторный heartbeat с тем же статусом не порождает события.
+			return nil
+		}
+		return enqueuePresenceChanged(tx, user)
+	})
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+func validRole(role string) bool {
+	return role == constants.RoleClient || role == constants.RoleOperator || role == constants.RoleAdmin || role == constants.RoleService
+}
+
+// lockAdmins блокирует строки admin до конца транзакции и возвращает их число: проверки
+// «последний admin» и «admin ещё нет» не обходятся параллельными запросами.
+func lockAdmins(tx *gorm.DB) (int, error) {
+	var ids []string
+	err := tx.Model(&model.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ?", constants.RoleAdmin).Pluck("id", &ids).Error
+	return len(ids), err
+}
+
+// SetUserRole меняет роль. Новый operator получает operator_status = pending; при уходе из operator
+// статус сбрасывается в pending, доступность оператора снимается. Снять роль с последнего admin нельзя.
+func (s *userService) SetUserRole(ctx context.Context, req *dto.SetUserRoleRequest) (*dto.UserResponse, error) {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	if !validRole(req.Role) {
+		return nil, errs.ErrInvalidRole
+	}
+	source := constants.RoleSourceAdmin
+	if req.ActorID == "" {
+		source = constants.RoleSourceCLI
+	}
+	var user model.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		admins, err := lockAdmins(tx)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", req.ID).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrUserNotFound
+			}
+			return err
+		}
+		if user.Role == req.Role {
+			return nil
+		}
+		if user.Role == constants.RoleAdmin && admins <= 1 {
+			return errs.ErrLastAdmin
+		}
+		before := user
+		user.Role = req.Role
+		switch {
+		case req.Role == constants.RoleOperator:
+			user.OperatorStatus = constants.OperatorStatusPending
+		case before.Role == constants.RoleOperator:
+			// operator_status ограничен CHECK (pending, verified, blocked): верификация сбрасывается в pending.
+			user.OperatorStatus = constants.OperatorStatusPending
+			user.IsAvailable = false
+			user.AutoUnavailable = false
+		}
+		if err := saveUser(tx, &user); err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionUserRole,
+			TargetID: user.ID,
+			ActorID:  req.ActorID,
+			Changes:  userAuditChanges(&before, &user, false),
+			Source:   source,
+			Reason:   req.Reason,
+		}); err != nil {
+			return err
+		}
+		return enqueueUserUpdated(tx, &before, &user, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.UserToResponse(&user), nil
+}
+
+func (s *userService) CreateAdmin(ctx context.Context, req *dto.CreateUserRequest, firstOnly bool) (*dto.UserResponse, error) {
+	if !firstOnly {
+		return s.createUser(ctx, req, constants.RoleAdmin, constants.RoleSourceCLI, nil)
+	}
+	return s.createUser(ctx, req, constants.RoleAdmin, constants.RoleSourceBootstrap, func(tx *gorm.DB) error {
+		admins, err := lockAdmins(tx)
+		if err != nil {
+			return err
+		}
+		if admins > 0 {
+			return errs.ErrAdminExists
+		}
+		return nil
+	})
+}
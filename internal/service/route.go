@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// RouteService — контракт сервиса конфигурации маршрутизации (user_services) для API gateway.
+type RouteService interface {
+	ListRoutes(ctx context.Context, userID string) ([]*dto.UserServiceRouteResponse, error)
+	CreateRoute(ctx context.Context, req *dto.UserServiceRouteRequest) (*dto.UserServiceRouteResponse, error)
+	UpdateRoute(ctx context.Context, req *dto.UserServiceRouteRequest) (*dto.UserServiceRouteResponse, error)
+	DeleteRoute(ctx context.Context, userID, id string) error
+	ResolveRoute(ctx context.Context, userID, button string, now time.Time) (*dto.UserServiceRouteResponse, error)
+}
+
+type routeService struct {
+	db *gorm.DB
+}
+
+func NewRouteService(db *gorm.DB) RouteService {
+	return &routeService{db: db}
+}
+
+func (s *routeService) getByID(ctx context.Context, userID, id string) (*model.UserService, error) {
+	var r model.UserService
+	err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&r).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *routeService) nameTaken(ctx context.Context, userID, name, exceptID string) (bool, error) {
+	var count int64
+	q := s.db.WithContext(ctx).Model(&model.UserService{}).Where("user_id = ? AND service_name = ?", userID, name)
+	if exceptID != "" {
+		q = q.Where("id <> ?", exceptID)
+	}
+	err := q.Count(&count).Error
+	return count > 0, err
+}
+
+func (s *routeService) getUser(ctx context.Context, userID string) (*model.User, error) {
+	var u model.User
+	err := s.db.WithContext(ctx).Where("id = ?", userID).First(&u).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *routeService) ListRoutes(ctx context.Context, userID string) ([]*dto.UserServiceRouteResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var list []*model.UserService
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("priority ASC").Order("service_name ASC").Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*dto.UserServiceRouteResponse, len(list))
+	for i := range list {
+		out[i] = mapper.ServiceRouteToResponse(list[i])
+	}
+	return out, nil
+}
+
+func (s *routeService) CreateRoute(ctx context.Context, req *dto.UserServiceRouteRequest) (*dto.UserServiceRouteResponse, error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	user, err := s.getUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+	taken, err := s.nameTaken(ctx, req.UserID, req.ServiceName, "")
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, errs.ErrServiceRouteExists
+	}
+	route := &model.UserService{
+		ID:     uuid.New().String(),
+		UserID: req.UserID,
+	}
+	if err := applyRouteRequest(route, req); err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).Create(route).Error; err != nil {
+		return nil, err
+	}
+	return mapper.ServiceRouteToResponse(route), nil
+}
+
+func (s *routeService) UpdateRoute(ctx context.Context, req *dto.UserServiceRouteRequest) (*dto.UserServiceRouteResponse, error) {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return nil, errs.ErrServiceRouteNotFound
+	}
+	route, err := s.getByID(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if route == nil {
+		return nil, errs.ErrServiceRouteNotFound
+	}
+	if len(req.UpdateMask) == 0 {
+		return mapper.ServiceRouteToResponse(route), nil
+	}
+	for _, field := range req.UpdateMask {
+		if field == dto.RouteFieldServiceName && route.ServiceName != req.ServiceName {
+			taken, err := s.nameTaken(ctx, req.UserID, req.ServiceName, route.ID)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				return nil, errs.ErrServiceRouteExists
+			}
+		}
+		if err := applyRouteField(route, req, field); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.db.WithContext(ctx).Save(route).Error; err != nil {
+		return nil, err
+	}
+	return mapper.ServiceRouteToResponse(route), nil
+}
+
+func (s *routeService) DeleteRoute(ctx context.Context, userID, id string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return errs.ErrServiceRouteNotFound
+	}
+	res := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.UserService{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrServiceRouteNotFound
+	}
+	return nil
+}
+
+// ResolveRoute возвращает активный сервис с наивысшим приоритетом, у которого включена кнопка button
+// и расписание которого покрывает момент now в часовом поясе пользователя.
+func (s *routeService) ResolveRoute(ctx context.Context, userID, button string, now time.Time) (*dto.UserServiceRouteResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+	loc := time.UTC
+	if user.Timezone != "" {
+		if l, err := time.LoadLocation(user.Timezone); err == nil {
+			loc = l
+		}
+	}
+	var list []*model.UserService
+	err = s.db.WithContext(ctx).Where("user_id = ? AND is_active = ?", userID, true).
+		Order("priority ASC").Order("service_name ASC").Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range list {
+		resp := mapper.ServiceRouteToResponse(route)
+		if !containsString(resp.EnabledButtons, button) {
+			continue
+		}
+		if !ScheduleActive(resp.Schedule, now.In(loc)) {
+			continue
+		}
+		return resp, nil
+	}
+	return nil, errs.ErrNoActiveServiceRoute
+}
+
+// ScheduleActive проверяет, попадает ли локальное время t в окно расписания.
+// nil или always=true — сервис доступен всегда. Окно с end_time < start_time переходит через полночь
+// и относится к дню, в который началось.
+func ScheduleActive(sched *dto.ServiceSchedule, t time.Time) bool {
+	if sched == nil || sched.Always {
+		return true
+	}
+	start, okStart := parseClock(sched.StartTime, 0)
+	end, okEnd := parseClock(sched.EndTime, 24*60-1)
+	if !okStart || !okEnd {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if start <= end {
+		return weekdayAllowed(sched.Weekdays, day) && minute >= start && minute <= end
+	}
+	if minute >= start {
+		return weekdayAllowed(sched.Weekdays, day)
+	}
+	if minute <= end {
+		return weekdayAllowed(sched.Weekdays, (day+6)%7)
+	}
+	return false
+}
+
+// parseClock разбирает "HH:MM" в минуты от полуночи; пустая строка — def.
+func parseClock(v string, def int) (int, bool) {
+	if v == "" {
+		return def, true
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func weekdayAllowed(weekdays []string, day time.Weekday) bool {
+	if len(weekdays) == 0 {
+		return true
+	}
+	name := strings.ToLower(day.String())
+	for _, w := range weekdays {
+		if strings.ToLower(w) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRouteRequest переносит все поля запроса в entity (создание записи).
+func applyRouteRequest(route *model.UserService, req *dto.UserServiceRouteRequest) error {
+	for _, field := range dto.RouteFields {
+		if err := applyRouteField(route, req, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyRouteField переносит одно поле запроса в entity; пустое значение заменяется значением
+// по умолчанию из миграции 000002.
+func applyRouteField(route *model.UserService, req *dto.UserServiceRouteRequest, field string) error {
+	switch field {
+	case dto.RouteFieldServiceName:
+		route.ServiceName = req.ServiceName
+	case dto.RouteFieldServiceType:
+		route.ServiceType = req.ServiceType
+	case dto.RouteFieldBaseURL:
+		route.BaseURL = req.BaseURL
+	case dto.RouteFieldAPIEndpoint:
+		route.APIEndpoint = req.APIEndpoint
+		if route.APIEndpoint == "" {
+			route.APIEndpoint = "/api/v1"
+		}
+	case dto.RouteFieldPort:
+		route.Port = req.Port
+	case dto.RouteFieldUseSSL:
+		route.UseSSL = req.UseSSL
+	case dto.RouteFieldSSLCertificatePath:
+		route.SSLCertificatePath = req.SSLCertificatePath
+	case dto.RouteFieldRoutingKey:
+		route.RoutingKey = req.RoutingKey
+	case dto.RouteFieldQueueName:
+		route.QueueName = req.QueueName
+	case dto.RouteFieldTopicName:
+		route.TopicName = req.TopicName
+	case dto.RouteFieldMaxBitrate:
+		route.MaxBitrate = req.MaxBitrate
+		if route.MaxBitrate == 0 {
+			route.MaxBitrate = 5000
+		}
+	case dto.RouteFieldMaxConnections:
+		route.MaxConnections = req.MaxConnections
+		if route.MaxConnections == 0 {
+			route.MaxConnections = 10
+		}
+	case dto.RouteFieldPriority:
+		route.Priority = req.Priority
+		if route.Priority == 0 {
+			route.Priority = 1
+		}
+	case dto.RouteFieldIsActive:
+		route.IsActive = req.IsActive == nil || *req.IsActive
+	case dto.RouteFieldEnabledButtons:
+		buttons := req.EnabledButtons
+		if buttons == nil {
+			buttons = constants.DefaultEnabledButtons
+		}
+		b, err := json.Marshal(buttons)
+		if err != nil {
+			return err
+		}
+		route.EnabledButtons = datatypes.JSON(b)
+	case dto.RouteFieldSchedule:
+		sched := req.Schedule
+		if sched == nil {
+			sched = &dto.ServiceSchedule{Always: true}
+		}
+		b, err := json.Marshal(sched)
+		if err != nil {
+			return err
+		}
+		route.Schedule = datatypes.JSON(b)
+	case dto.RouteFieldParameters:
+		params := req.Parameters
+		if len(params) == 0 {
+			params = json.RawMessage("{}")
+		}
+		route.Parameters = datatypes.JSON(params)
+	default:
+		return errs.ErrInvalidUpdateMask
+	}
+	return nil
+}
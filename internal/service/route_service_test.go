@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+func TestScheduleActive(t *testing.T) {
+	// 2026-03-02 — понедельник.
+	at := func(day int, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	workdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+	tests := []struct {
+		name  string
+		sched *dto.ServiceSchedule
+		now   time.Time
+		want  bool
+	}{
+		{"nil schedule", nil, at(2, 3, 0), true},
+		{"always", &dto.ServiceSchedule{Always: true, Weekdays: []string{"sunday"}}, at(2, 3, 0), true},
+		{"inside window", &dto.ServiceSchedule{Weekdays: workdays, StartTime: "09:00", EndTime: "18:00"}, at(2, 10, 30), true},
+		{"before window", &dto.ServiceSchedule{Weekdays: workdays, StartTime: "09:00", EndTime: "18:00"}, at(2, 8, 59), false},
+		{"weekend", &dto.ServiceSchedule{Weekdays: workdays, StartTime: "09:00", EndTime: "18:00"}, at(7, 10, 0), false},
+		{"overnight after start", &dto.ServiceSchedule{Weekdays: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"}, at(6, 23, 0), true},
+		{"overnight next morning", &dto.ServiceSchedule{Weekdays: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"}, at(7, 5, 0), true},
+		{"overnight wrong day", &dto.ServiceSchedule{Weekdays: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"}, at(6, 5, 0), false},
+		{"invalid time", &dto.ServiceSchedule{StartTime: "9am", EndTime: "18:00"}, at(2, 10, 0), false},
+	}
+	for _, tt := range tests {
+		if got := ScheduleActive(tt.sched, tt.now); got != tt.want {
+			t.Errorf("%s: ScheduleActive = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRoute_CRUDAndPartialUpdate(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.UserService{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	routeSvc := NewRouteService(conn)
+	ctx := context.Background()
+
+	u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "routes@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	create := func(name string, priority int) *dto.UserServiceRouteResponse {
+		t.Helper()
+		r, err := routeSvc.CreateRoute(ctx, &dto.UserServiceRouteRequest{
+			UserID: u.ID, ServiceName: name, ServiceType: constants.ServiceTypeStreaming,
+			BaseURL: "http://" + name + ":8080", Port: 8080, Priority: priority,
+		})
+		if err != nil {
+			t.Fatalf("CreateRoute(%s) failed: %v", name, err)
+		}
+		return r
+	}
+	beta := create("beta", 2)
+	alpha := create("alpha", 2)
+	first := create("zeta", 1)
+	if beta.APIEndpoint != "/api/v1" || beta.MaxBitrate != 5000 || !beta.IsActive || len(beta.EnabledButtons) != 3 {
+		t.Fatalf("Expected defaults on create, got %+v", beta)
+	}
+	if _, err := routeSvc.CreateRoute(ctx, &dto.UserServiceRouteRequest{UserID: u.ID, ServiceName: "beta", ServiceType: constants.ServiceTypeCustom, BaseURL: "http://x", Port: 1}); !errors.Is(err, errs.ErrServiceRouteExists) {
+		t.Fatalf("Expected ErrServiceRouteExists, got %v", err)
+	}
+
+	// Порядок — priority, затем service_name.
+	list, err := routeSvc.ListRoutes(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("ListRoutes failed: %v", err)
+	}
+	if len(list) != 3 || list[0].ID != first.ID || list[1].ID != alpha.ID || list[2].ID != beta.ID {
+		t.Fatalf("Unexpected order: %s, %s, %s", list[0].ServiceName, list[1].ServiceName, list[2].ServiceName)
+	}
+
+	// Частичное обновление не трогает поля вне маски.
+	inactive := false
+	updated, err := routeSvc.UpdateRoute(ctx, &dto.UserServiceRouteRequest{
+		ID: beta.ID, UserID: u.ID, MaxBitrate: 8000, EnabledButtons: []string{"button9"},
+		UpdateMask: []string{dto.RouteFieldMaxBitrate, dto.RouteFieldEnabledButtons},
+	})
+	if err != nil {
+		t.Fatalf("UpdateRoute failed: %v", err)
+	}
+	if updated.MaxBitrate != 8000 || len(updated.EnabledButtons) != 1 || updated.BaseURL != beta.BaseURL || updated.Priority != 2 || !updated.IsActive {
+		t.Fatalf("Expected partial update, got %+v", updated)
+	}
+	updated, err = routeSvc.UpdateRoute(ctx, &dto.UserServiceRouteRequest{ID: beta.ID, UserID: u.ID, IsActive: &inactive, UpdateMask: []string{dto.RouteFieldIsActive}})
+	if err != nil || updated.IsActive || updated.MaxBitrate != 8000 {
+		t.Fatalf("Expected is_active=false with bitrate kept, got %+v, %v", updated, err)
+	}
+	if _, err := routeSvc.UpdateRoute(ctx, &dto.UserServiceRouteRequest{ID: beta.ID, UserID: u.ID, ServiceName: "alpha", UpdateMask: []string{dto.RouteFieldServiceName}}); !errors.Is(err, errs.ErrServiceRouteExists) {
+		t.Fatalf("Expected ErrServiceRouteExists on rename, got %v", err)
+	}
+	if _, err := routeSvc.UpdateRoute(ctx, &dto.UserServiceRouteRequest{ID: beta.ID, UserID: u.ID, UpdateMask: []string{"user_id"}}); !errors.Is(err, errs.ErrInvalidUpdateMask) {
+		t.Fatalf("Expected ErrInvalidUpdateMask, got %v", err)
+	}
+
+	if err := routeSvc.DeleteRoute(ctx, u.ID, beta.ID); err != nil {
+		t.Fatalf("DeleteRoute failed: %v", err)
+	}
+	if err := routeSvc.DeleteRoute(ctx, u.ID, beta.ID); !errors.Is(err, errs.ErrServiceRouteNotFound) {
+		t.Fatalf("Expected ErrServiceRouteNotFound on second delete, got %v", err)
+	}
+}
+
+func TestRoute_ResolveUsesUserTimezone(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.UserService{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	routeSvc := NewRouteService(conn)
+	ctx := context.Background()
+
+	u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "tokyo@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	office, err := routeSvc.CreateRoute(ctx, &dto.UserServiceRouteRequest{
+		UserID: u.ID, ServiceName: "office", ServiceType: constants.ServiceTypeStreaming, BaseURL: "http://office", Port: 80, Priority: 1,
+		Schedule: &dto.ServiceSchedule{Weekdays: []string{"monday"}, StartTime: "09:00", EndTime: "18:00"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoute failed: %v", err)
+	}
+	fallback, err := routeSvc.CreateRoute(ctx, &dto.UserServiceRouteRequest{
+		UserID: u.ID, ServiceName: "fallback", ServiceType: constants.ServiceTypeRecording, BaseURL: "http://fallback", Port: 80, Priority: 5,
+	})
+	if err != nil {
+		t.Fatalf("CreateRoute failed: %v", err)
+	}
+
+	// 2026-03-02 01:00 UTC — понедельник, 10:00 в Asia/Tokyo.
+	now := time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC)
+	resolve := func() string {
+		t.Helper()
+		r, err := routeSvc.ResolveRoute(ctx, u.ID, "button1", now)
+		if err != nil {
+			t.Fatalf("ResolveRoute failed: %v", err)
+		}
+		return r.ID
+	}
+	if got := resolve(); got != fallback.ID {
+		t.Fatalf("Expected fallback outside UTC window, got %s", got)
+	}
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: u.ID, Timezone: "Asia/Tokyo", UpdateMask: []string{dto.UserFieldTimezone}}); err != nil {
+		t.Fatalf("UpdateUser failed: %v", err)
+	}
+	if got := resolve(); got != office.ID {
+		t.Fatalf("Expected office route inside Tokyo window, got %s", got)
+	}
+	if _, err := routeSvc.ResolveRoute(ctx, u.ID, "button7", now); !errors.Is(err, errs.ErrNoActiveServiceRoute) {
+		t.Fatalf("Expected ErrNoActiveServiceRoute for unknown button, got %v", err)
+	}
+}
@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/internal/errs"
@@ -20,7 +23,11 @@ type SessionService interface {
 	GetUserSessions(ctx context.Context, userID string, limit, offset int) ([]*dto.UserSessionResponse, int64, error)
 	GetActiveSessions(ctx context.Context, userID string) ([]*dto.UserSessionResponse, error)
 	CreateSession(ctx context.Context, userID string, req *dto.CreateSessionRequest) (*dto.UserSessionResponse, error)
-	ValidateUserSession(ctx context.Context, userID, sessionExternalID, participantRole string) (bool, error)
+	// ValidateUserSession отвечает, пустит ли CreateSession пользователя в сессию типа sessionType.
+	ValidateUserSession(ctx context.Context, userID, sessionExternalID, sessionType, participantRole string) (bool, error)
+	EndSession(ctx context.Context, userID, sessionID string) (*dto.UserSessionResponse, error)
+	EndSessionsByExternalID(ctx context.Context, sessionExternalID string) (int64, error)
+	RateConsultation(ctx context.Context, userID string, req *dto.RateConsultationRequest) (*dto.UserSessionResponse, error)
 }
 
 type sessionService struct {
@@ -31,27 +38,24 @@ func NewSessionService(db *gorm.DB) SessionService {
 	return &sessionService{db: db}
 }
 
-func (s *sessionService) getUserByID(ctx context.Context, userID string) (*model.User, error) {
+// lockUser читает пользователя с блокировкой строки до конца транзакции (strength — UPDATE или SHARE).
+// Вход в сессии сериализуется по пользователю: лимиты проверяются и участие вставляется под одной блокировкой.
+func lockUser(tx *gorm.DB, userID, strength string) (*model.User, error) {
 	var u model.User
-	err := s.db.WithContext(ctx).Where("id = ?", userID).First(&u).Error
-	if err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: strength}).Where("id = ?", userID).First(&u).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
+			return nil, errs.ErrUserNotFound
 		}
 		return nil, err
 	}
 	return &u, nil
 }
 
-func (s *sessionService) countActiveByUser(ctx context.Context, userID string) (int64, error) {
-	var count int64
-	err := s.db.WithContext(ctx).Model(&model.UserSession{}).Where("user_id = ? AND left_at IS NULL", userID).Count(&count).Error
-	return count, err
-}
-
-func (s *sessionService) findActiveByUserAndExternalID(ctx context.Context, userID, sessionExternalID string) (*model.UserSession, error) {
+// findActiveSession — активное участие пользователя в сессии session-manager (не больше одного,
+// idx_user_sessions_active_external).
+func findActiveSession(tx *gorm.DB, userID, sessionExternalID string) (*model.UserSession, error) {
 	var sess model.UserSession
-	err := s.db.WithContext(ctx).Where("user_id = ? AND session_external_id = ? AND left_at IS NULL", userID, sessionExternalID).First(&sess).Error
+	err := tx.Where("user_id = ? AND session_external_id = ? AND left_at IS NULL", userID, sessionExternalID).First(&sess).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -61,32 +65,77 @@ func (s *sessionService) findActiveByUserAndExternalID(ctx context.Context, user
 	return &sess, nil
 }
 
-func (s *sessionService) ValidateUserSession(ctx context.Context, userID, sessionExternalID, participantRole string) (allowed bool, err error) {
-	if _, err := uuid.Parse(userID); err != nil {
-		return false, nil
+// admitSession проверяет лимиты входа в новую сессию. Вызывается под lockUser: параллельные входы
+// того же пользователя ждут блокировку и видят уже вставленные участия.
+func admitSession(tx *gorm.DB, user *model.User, sessionType string) error {
+	var activeCount int64
+	if err := tx.Model(&model.UserSession{}).Where("user_id = ? AND left_at IS NULL", user.ID).Count(&activeCount).Error; err != nil {
+		return err
 	}
-	user, err := s.getUserByID(ctx, userID)
-	if err != nil || user == nil || !user.IsActive {
-		return false, nil
+	if user.Role == constants.RoleClient && sessionType == "streaming" && activeCount >= 1 {
+		return errs.ErrClientStreamingLimit
 	}
-	existing, err := s.findActiveByUserAndExternalID(ctx, userID, sessionExternalID)
-	if err != nil {
-		return false, err
+	if user.Role == constants.RoleOperator && (user.OperatorStatus != constants.OperatorStatusVerified || !user.IsAvailable) {
+		return errs.ErrOperatorNotVerifiedOrAvailable
 	}
-	if existing != nil {
-		return true, nil
+	if int(activeCount) >= user.MaxSessions {
+		return errs.ErrMaxSessionsReached
 	}
-	activeCount, err := s.countActiveByUser(ctx, userID)
-	if err != nil {
-		return false, err
+	return nil
+}
+
+// startSession вставляет участие, обновляет счётчики пользователя и ставит session.started в outbox.
+func startSession(tx *gorm.DB, user *model.User, session *model.UserSession) error {
+	if err := tx.Create(session).Error; err != nil {
+		return err
 	}
-	if user.Role == constants.RoleOperator && (user.OperatorStatus != constants.OperatorStatusVerified || !user.IsAvailable) {
-		return false, nil
+	if err := tx.Model(user).Updates(map[string]interface{}{
+		"total_sessions": gorm.Expr("total_sessions + 1"),
+		"is_online":      true,
+		"version":        bumpVersion,
+	}).Error; err != nil {
+		return err
 	}
-	if int(activeCount) >= user.MaxSessions {
+	return enqueueSessionStarted(tx, session)
+}
+
+// ValidateUserSession — предварительная проверка для session-manager с теми же лимитами, что и
+// CreateSession (включая один streaming для client). Строка пользователя читается с FOR SHARE: ответ
+// согласован с завершёнными входами, окончательно лимиты проверяет CreateSession.
+func (s *sessionService) ValidateUserSession(ctx context.Context, userID, sessionExternalID, sessionType, participantRole string) (allowed bool, err error) {
+	if _, err := uuid.Parse(userID); err != nil {
 		return false, nil
 	}
-	return true, nil
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := lockUser(tx, userID, "SHARE")
+		if errors.Is(err, errs.ErrUserNotFound) {
+			return nil
+		}
+		if err != nil || !user.IsActive {
+			return err
+		}
+		existing, err := findActiveSession(tx, userID, sessionExternalID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			allowed = true
+			return nil
+		}
+		switch err := admitSession(tx, user, sessionType); {
+		case err == nil:
+			allowed = true
+		case errors.Is(err, errs.ErrMaxSessionsReached), errors.Is(err, errs.ErrOperatorNotVerifiedOrAvailable),
+			errors.Is(err, errs.ErrClientStreamingLimit):
+		default:
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return allowed, nil
 }
 
 func (s *sessionService) GetUserSessions(ctx context.Context, userID string, limit, offset int) ([]*dto.UserSessionResponse, int64, error) {
@@ -130,41 +179,297 @@ func (s *sessionService) CreateSession(ctx context.Context, userID string, req *
 	if _, err := uuid.Parse(userID); err != nil {
 		return nil, errs.ErrInvalidUserID
 	}
-	user, err := s.getUserByID(ctx, userID)
+	var session *model.UserSession
+	full := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := lockUser(tx, userID, "UPDATE")
+		if err != nil {
+			return err
+		}
+		existing, err := findActiveSession(tx, userID, req.SessionExternalID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			// Повторный вход в ту же сессию (ретрай session-manager) возвращает действующее участие.
+			session = existing
+			return nil
+		}
+		if err := admitSession(tx, user, req.SessionType); err != nil {
+			if !errors.Is(err, errs.ErrMaxSessionsReached) {
+				return err
+			}
+			// Снятие с доступности фиксируется, вход отклоняется после коммита.
+			full = true
+			if !user.IsAvailable {
+				return nil
+			}
+			if err := tx.Model(user).Updates(map[string]interface{}{
+				"is_available":     false,
+				"auto_unavailable": true,
+				"version":          bumpVersion,
+			}).Error; err != nil {
+				return err
+			}
+			return enqueueAvailabilityChanged(tx, user.ID, false, true)
+		}
+		session = &model.UserSession{
+			ID:                uuid.New().String(),
+			UserID:            userID,
+			SessionType:       req.SessionType,
+			SessionExternalID: req.SessionExternalID,
+			ParticipantRole:   req.ParticipantRole,
+			JoinedAt:          time.Now(),
+		}
+		return startSession(tx, user, session)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, errs.ErrUserNotFound
+	if full {
+		return nil, errs.ErrMaxSessionsReached
+	}
+	return mapper.SessionToResponse(session), nil
+}
+
+func (s *sessionService) EndSession(ctx context.Context, userID, sessionID string) (*dto.UserSessionResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return nil, errs.ErrSessionNotFound
 	}
-	activeCount, err := s.countActiveByUser(ctx, userID)
+	var session model.UserSession
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Блокировка пользователя (как в CreateSession): завершение и вход того же пользователя не перемежаются.
+		// Unscoped — сессию удалённого пользователя тоже можно завершить.
+		if _, err := lockUser(tx.Unscoped(), userID, "UPDATE"); err != nil {
+			if errors.Is(err, errs.ErrUserNotFound) {
+				return errs.ErrSessionNotFound
+			}
+			return err
+		}
+		if err := tx.Where("id = ? AND user_id = ?", sessionID, userID).First(&session).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrSessionNotFound
+			}
+			return err
+		}
+		if session.LeftAt != nil {
+			// Уже завершена — повторный вызов ничего не меняет.
+			return nil
+		}
+		closed, err := closeSession(tx, &session, time.Now())
+		if err != nil || !closed {
+			return err
+		}
+		return restoreAutoAvailability(tx, userID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if user.Role == constants.RoleClient && req.SessionType == "streaming" && activeCount >= 1 {
-		return nil, errs.ErrClientStreamingLimit
+	return mapper.SessionToResponse(&session), nil
+}
+
+func (s *sessionService) EndSessionsByExternalID(ctx context.Context, sessionExternalID string) (int64, error) {
+	var ended int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		ended, err = closeExternalSession(tx, sessionExternalID, time.Now())
+		return err
+	})
+	if err != nil {
+		return 0, err
 	}
-	if user.Role == constants.RoleOperator && (user.OperatorStatus != constants.OperatorStatusVerified || !user.IsAvailable) {
-		return nil, errs.ErrOperatorNotVerifiedOrAvailable
+	return ended, nil
+}
+
+// closeSession проставляет left_at и duration_seconds активной сессии. Обновление условное
+// (left_at IS NULL): сессию, уже закрытую параллельным запросом, повторно не закрывает и возвращает false.
+func closeSession(tx *gorm.DB, session *model.UserSession, now time.Time) (bool, error) {
+	duration := int(now.Sub(session.JoinedAt).Seconds())
+	if duration < 0 {
+		duration = 0
+	}
+	res := tx.Model(&model.UserSession{}).Where("id = ? AND left_at IS NULL", session.ID).Updates(map[string]interface{}{
+		"left_at":          now,
+		"duration_seconds": duration,
+	})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+	session.LeftAt = &now
+	session.DurationSeconds = duration
+	return true, nil
+}
+
+// closeExternalSession закрывает все активные участия в сессии session-manager и возвращает их число.
+func closeExternalSession(tx *gorm.DB, sessionExternalID string, now time.Time) (int64, error) {
+	var list []*model.UserSession
+	if err := tx.Where("session_external_id = ? AND left_at IS NULL", sessionExternalID).Find(&list).Error; err != nil {
+		return 0, err
+	}
+	if err := lockSessionUsers(tx, list); err != nil {
+		return 0, err
+	}
+	users := make(map[string]struct{}, len(list))
+	var ended int64
+	for _, session := range list {
+		closed, err := closeSession(tx, session, now)
+		if err != nil {
+			return 0, err
+		}
+		if closed {
+			ended++
+			users[session.UserID] = struct{}{}
+		}
+	}
+	for userID := range users {
+		if err := restoreAutoAvailability(tx, userID); err != nil {
+			return 0, err
+		}
+	}
+	return ended, nil
+}
+
+// lockSessionUsers блокирует участников в порядке id до закрытия их участий: порядок блокировок
+// тот же, что в CreateSession и EndSession (пользователь, затем участие), без взаимных ожиданий.
+func lockSessionUsers(tx *gorm.DB, list []*model.UserSession) error {
+	ids := make([]string, 0, len(list))
+	seen := make(map[string]struct{}, len(list))
+	for _, session := range list {
+		if _, ok := seen[session.UserID]; !ok {
+			seen[session.UserID] = struct{}{}
+			ids = append(ids, session.UserID)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, err := lockUser(tx.Unscoped(), id, "UPDATE"); err != nil && !errors.Is(err, errs.ErrUserNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreAutoAvailability возвращает оператора в доступные, если его сняли автоматически
+// по max_sessions, он по-прежнему verified и у него освободился слот.
+func restoreAutoAvailability(tx *gorm.DB, userID string) error {
+	var user model.User
+	if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.Role != constants.RoleOperator || !user.AutoUnavailable {
+		return nil
+	}
+	if user.OperatorStatus != constants.OperatorStatusVerified || !user.IsActive {
+		return nil
+	}
+	var activeCount int64
+	if err := tx.Model(&model.UserSession{}).Where("user_id = ? AND left_at IS NULL", userID).Count(&activeCount).Error; err != nil {
+		return err
 	}
 	if int(activeCount) >= user.MaxSessions {
-		user.IsAvailable = false
-		_ = s.db.WithContext(ctx).Save(user)
-		return nil, errs.ErrMaxSessionsReached
+		return nil
 	}
-	session := &model.UserSession{
-		ID:                uuid.New().String(),
-		UserID:            userID,
-		SessionType:       req.SessionType,
-		SessionExternalID: req.SessionExternalID,
-		ParticipantRole:   req.ParticipantRole,
-		JoinedAt:          time.Now(),
+	if err := tx.Model(&user).Updates(map[string]interface{}{
+		"is_available":     true,
+		"auto_unavailable": false,
+		"version":          bumpVersion,
+	}).Error; err != nil {
+		return err
 	}
-	if err := s.db.WithContext(ctx).Create(session).Error; err != nil {
+	return enqueueAvailabilityChanged(tx, userID, true, true)
+}
+
+func (s *sessionService) RateConsultation(ctx context.Context, userID string, req *dto.RateConsultationRequest) (*dto.UserSessionResponse, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var session model.UserSession
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND user_id = ?", req.SessionID, userID).First(&session).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrSessionNotFound
+			}
+			return err
+		}
+		var client model.User
+		if err := tx.Where("id = ?", userID).First(&client).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrUserNotFound
+			}
+			return err
+		}
+		if session.SessionType != "consultation" || client.Role != constants.RoleClient || session.ParticipantRole == "operator" {
+			return errs.ErrNotConsultationClient
+		}
+		if session.LeftAt == nil {
+			return errs.ErrSessionNotFinished
+		}
+		if session.ConsultationRating != nil {
+			return errs.ErrSessionAlreadyRated
+		}
+
+		var operatorSession model.UserSession
+		err := tx.Where("session_external_id = ? AND participant_role = ? AND user_id <> ?", session.SessionExternalID, "operator", userID).
+			Order("joined_at ASC").First(&operatorSession).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrConsultationOperatorNotFound
+			}
+			return err
+		}
+
+		// Условие consultation_rating IS NULL защищает от двойной оценки при гонке запросов.
+		res := tx.Model(&model.UserSession{}).
+			Where("id = ? AND consultation_rating IS NULL", session.ID).
+			Updates(map[string]interface{}{
+				"consultation_rating":   req.Rating,
+				"consultation_feedback": req.Feedback,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrSessionAlreadyRated
+		}
+		rating := req.Rating
+		session.ConsultationRating = &rating
+		session.ConsultationFeedback = req.Feedback
+
+		return applyOperatorRating(tx, operatorSession.UserID, req.Rating)
+	})
+	if err != nil {
 		return nil, err
 	}
-	user.TotalSessions++
-	user.IsOnline = true
-	_ = s.db.WithContext(ctx).Save(user)
-	return mapper.SessionToResponse(session), nil
+	return mapper.SessionToResponse(&session), nil
+}
+
+// applyOperatorRating добавляет оценку к rating_sum/rating_count оператора и пересчитывает users.rating
+// из точной суммы (округляется только отображаемое среднее). Строка оператора блокируется до конца транзакции.
+func applyOperatorRating(tx *gorm.DB, operatorID string, rating int) error {
+	var operator model.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", operatorID).First(&operator).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrConsultationOperatorNotFound
+		}
+		return err
+	}
+	if operator.Role != constants.RoleOperator {
+		return errs.ErrConsultationOperatorNotFound
+	}
+	count := operator.RatingCount + 1
+	sum := operator.RatingSum + rating
+	avg := math.Round(float64(sum)/float64(count)*100) / 100
+	return tx.Model(&operator).Updates(map[string]interface{}{
+		"rating":       avg,
+		"rating_count": count,
+		"rating_sum":   sum,
+		"version":      bumpVersion,
+	}).Error
 }
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// sessionEventsConsumer — имя consumer в processed_messages.
+const sessionEventsConsumer = "session-events"
+
+// SessionEventService — контракт применения событий session-manager к user_sessions.
+type SessionEventService interface {
+	// Apply применяет событие ровно один раз для messageID. false — сообщение уже обработано.
+	// errs.ErrInvalidSessionEvent и errs.ErrUserNotFound — постоянные ошибки (сообщение в DLQ).
+	Apply(ctx context.Context, messageID string, ev *dto.SessionEvent) (bool, error)
+	// PurgeProcessed удаляет отметки обработки и надгробия старше before и возвращает число удалённых отметок.
+	PurgeProcessed(ctx context.Context, before time.Time) (int64, error)
+}
+
+type sessionEventService struct {
+	db *gorm.DB
+}
+
+func NewSessionEventService(db *gorm.DB) SessionEventService {
+	return &sessionEventService{db: db}
+}
+
+func (s *sessionEventService) Apply(ctx context.Context, messageID string, ev *dto.SessionEvent) (bool, error) {
+	if messageID == "" || ev.SessionID == "" {
+		return false, errs.ErrInvalidSessionEvent
+	}
+	now := ev.OccurredAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	applied := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ProcessedMessage{
+			MessageID: messageID,
+			Consumer:  sessionEventsConsumer,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+		switch ev.Type {
+		case constants.SessionEventStarted:
+			if ev.UserID == "" {
+				// Сессия без инициатора: участники придут отдельными participant.joined.
+				return nil
+			}
+			return joinSession(tx, ev, constants.ParticipantRoleHost, now)
+		case constants.SessionEventParticipantJoined:
+			return joinSession(tx, ev, ev.Role, now)
+		case constants.SessionEventParticipantLeft:
+			return leaveSession(tx, ev, now)
+		case constants.SessionEventClosed:
+			if err := recordTombstone(tx, ev.SessionID, "", now); err != nil {
+				return err
+			}
+			_, err := closeExternalSession(tx, ev.SessionID, now)
+			return err
+		default:
+			return errs.ErrInvalidSessionEvent
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+func (s *sessionEventService) PurgeProcessed(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("consumer = ? AND processed_at < ?", sessionEventsConsumer, before).Delete(&model.ProcessedMessage{})
+		if res.Error != nil {
+			return res.Error
+		}
+		purged = res.RowsAffected
+		return tx.Where("created_at < ?", before).Delete(&model.SessionTombstone{}).Error
+	})
+	return purged, err
+}
+
+// RunPurgeProcessed каждые interval удаляет отметки обработки старше retention до отмены ctx.
+// retention должен превышать окно повторной доставки брокера: после него дубликат будет применён снова.
+func RunPurgeProcessed(ctx context.Context, events SessionEventService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := events.PurgeProcessed(ctx, time.Now().Add(-retention))
+			if err != nil {
+				log.Printf("processed messages purge: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("processed messages purge: purged %d", n)
+			}
+		}
+	}
+}
+
+// recordTombstone запоминает выход участника (userID "" — закрытие сессии) не раньше уже записанного.
+func recordTombstone(tx *gorm.DB, sessionExternalID, userID string, leftAt time.Time) error {
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.SessionTombstone{
+		SessionExternalID: sessionExternalID,
+		UserID:            userID,
+		LeftAt:            leftAt,
+	})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.Error
+	}
+	return tx.Model(&model.SessionTombstone{}).
+		Where("session_external_id = ? AND user_id = ? AND left_at < ?", sessionExternalID, userID, leftAt).
+		Update("left_at", leftAt).Error
+}
+
+// joinedAfterLeave — событие входа не старше записанного выхода участника или закрытия сессии:
+// пришло после них не по порядку и участие не открывает.
+func joinedAfterLeave(tx *gorm.DB, sessionExternalID, userID string, joinedAt time.Time) (bool, error) {
+	var count int64
+	err := tx.Model(&model.SessionTombstone{}).
+		Where("session_external_id = ? AND user_id IN ? AND left_at >= ?", sessionExternalID, []string{userID, ""}, joinedAt).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// joinSession создаёт активную запись участия, если её ещё нет. Лимиты CreateSession здесь не
+// проверяются: событие фиксирует уже состоявшийся вход в session-manager.
+func joinSession(tx *gorm.DB, ev *dto.SessionEvent, role string, now time.Time) error {
+	if _, err := uuid.Parse(ev.UserID); err != nil {
+		return errs.ErrInvalidSessionEvent
+	}
+	stale, err := joinedAfterLeave(tx, ev.SessionID, ev.UserID, now)
+	if err != nil || stale {
+		return err
+	}
+	// Блокировка та же, что в CreateSession: событие и прямой вход не создают два активных участия.
+	user, err := lockUser(tx, ev.UserID, "UPDATE")
+	if err != nil {
+		return err
+	}
+	existing, err := findActiveSession(tx, ev.UserID, ev.SessionID)
+	if err != nil || existing != nil {
+		return err
+	}
+	if role == "" {
+		role = constants.ParticipantRoleViewer
+		if user.Role == constants.RoleOperator {
+			role = constants.ParticipantRoleOperator
+		}
+	}
+	if role != constants.ParticipantRoleHost && role != constants.ParticipantRoleOperator && role != constants.ParticipantRoleViewer {
+		return errs.ErrInvalidSessionEvent
+	}
+	sessionType := ev.SessionType
+	if sessionType == "" {
+		sessionType = "consultation"
+	}
+	session := &model.UserSession{
+		ID:                uuid.New().String(),
+		UserID:            ev.UserID,
+		SessionType:       sessionType,
+		SessionExternalID: ev.SessionID,
+		ParticipantRole:   role,
+		JoinedAt:          now,
+	}
+	return startSession(tx, user, session)
+}
+
+// leaveSession закрывает активные участия и оставляет надгробие: вход, доставленный позже выхода,
+// не откроет участие заново.
+func leaveSession(tx *gorm.DB, ev *dto.SessionEvent, now time.Time) error {
+	if _, err := uuid.Parse(ev.UserID); err != nil {
+		return errs.ErrInvalidSessionEvent
+	}
+	if err := recordTombstone(tx, ev.SessionID, ev.UserID, now); err != nil {
+		return err
+	}
+	var list []*model.UserSession
+	err := tx.Where("user_id = ? AND session_external_id = ? AND left_at IS NULL", ev.UserID, ev.SessionID).
+		Find(&list).Error
+	if err != nil || len(list) == 0 {
+		return err
+	}
+	if err := lockSessionUsers(tx, list); err != nil {
+		return err
+	}
+	for _, session := range list {
+		if _, err := closeSession(tx, session, now); err != nil {
+			return err
+		}
+	}
+	return restoreAutoAvailability(tx, ev.UserID)
+}
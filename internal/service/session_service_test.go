@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/psds-microservice/helpy/db"
+	"gorm.io/gorm"
+
+	"github.com/psds-microservice/user-service/internal/database"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+func TestSession_EndSessionRestoresOperatorAvailability(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	sessionSvc := NewSessionService(conn)
+	ctx := context.Background()
+
+	op, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "operator",
+		Email:    "operator@example.com",
+		Password: "secretpassword",
+		Role:     constants.RoleOperator,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if err := conn.Model(&model.User{}).Where("id = ?", op.ID).Updates(map[string]interface{}{
+		"operator_status": constants.OperatorStatusVerified,
+		"is_available":    true,
+	}).Error; err != nil {
+		t.Fatalf("verify operator: %v", err)
+	}
+
+	first, err := sessionSvc.CreateSession(ctx, op.ID, &dto.CreateSessionRequest{
+		SessionType:       "consultation",
+		SessionExternalID: "room-1",
+		ParticipantRole:   "operator",
+	})
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	_, err = sessionSvc.CreateSession(ctx, op.ID, &dto.CreateSessionRequest{
+		SessionType:       "consultation",
+		SessionExternalID: "room-2",
+		ParticipantRole:   "operator",
+	})
+	if err != errs.ErrMaxSessionsReached {
+		t.Fatalf("Expected ErrMaxSessionsReached, got %v", err)
+	}
+
+	var user model.User
+	conn.First(&user, "id = ?", op.ID)
+	if user.IsAvailable || !user.AutoUnavailable {
+		t.Fatalf("Expected operator to be auto-unavailable, got is_available=%v auto_unavailable=%v", user.IsAvailable, user.AutoUnavailable)
+	}
+
+	ended, err := sessionSvc.EndSession(ctx, op.ID, first.ID)
+	if err != nil {
+		t.Fatalf("EndSession failed: %v", err)
+	}
+	if ended.LeftAt == nil {
+		t.Error("Expected left_at to be set")
+	}
+
+	conn.First(&user, "id = ?", op.ID)
+	if !user.IsAvailable || user.AutoUnavailable {
+		t.Errorf("Expected operator to be available again, got is_available=%v auto_unavailable=%v", user.IsAvailable, user.AutoUnavailable)
+	}
+
+	active, err := sessionSvc.GetActiveSessions(ctx, op.ID)
+	if err != nil {
+		t.Fatalf("GetActiveSessions failed: %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("Expected no active sessions, got %d", len(active))
+	}
+}
+
+func TestSession_EndSessionsByExternalID(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	sessionSvc := NewSessionService(conn)
+	ctx := context.Background()
+
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: email, Password: "secretpassword"})
+		if err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+		if _, err := sessionSvc.CreateSession(ctx, u.ID, &dto.CreateSessionRequest{
+			SessionType:       "viewing",
+			SessionExternalID: "room-42",
+			ParticipantRole:   "viewer",
+		}); err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+	}
+
+	ended, err := sessionSvc.EndSessionsByExternalID(ctx, "room-42")
+	if err != nil {
+		t.Fatalf("EndSessionsByExternalID failed: %v", err)
+	}
+	if ended != 2 {
+		t.Errorf("Expected 2 ended sessions, got %d", ended)
+	}
+	ended, err = sessionSvc.EndSessionsByExternalID(ctx, "room-42")
+	if err != nil || ended != 0 {
+		t.Errorf("Expected repeated call to end nothing, got %d, %v", ended, err)
+	}
+}
+
+func TestSession_AdmissionLimitsAndRetries(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	sessionSvc := NewSessionService(conn)
+	ctx := context.Background()
+
+	u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "crowd@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if err := conn.Model(&model.User{}).Where("id = ?", u.ID).Update("max_sessions", 2).Error; err != nil {
+		t.Fatalf("set max_sessions: %v", err)
+	}
+	join := func(room, sessionType string) (*dto.UserSessionResponse, error) {
+		return sessionSvc.CreateSession(ctx, u.ID, &dto.CreateSessionRequest{
+			SessionType:       sessionType,
+			SessionExternalID: room,
+			ParticipantRole:   "host",
+		})
+	}
+
+	// ValidateUserSession применяет те же лимиты, что и CreateSession, включая один streaming для client.
+	if ok, err := sessionSvc.ValidateUserSession(ctx, u.ID, "room-0", "streaming", "host"); err != nil || !ok {
+		t.Fatalf("ValidateUserSession (free slot): %v, %v", ok, err)
+	}
+	first, err := join("room-0", "consultation")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if ok, err := sessionSvc.ValidateUserSession(ctx, u.ID, "room-1", "streaming", "host"); err != nil || ok {
+		t.Fatalf("ValidateUserSession must deny a second streaming session for client: %v, %v", ok, err)
+	}
+	if _, err := join("room-1", "streaming"); !errors.Is(err, errs.ErrClientStreamingLimit) {
+		t.Fatalf("CreateSession streaming: expected ErrClientStreamingLimit, got %v", err)
+	}
+	if ok, err := sessionSvc.ValidateUserSession(ctx, u.ID, "room-1", "consultation", "host"); err != nil || !ok {
+		t.Fatalf("ValidateUserSession (consultation): %v, %v", ok, err)
+	}
+	if _, err := join("room-1", "consultation"); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if _, err := join("room-2", "consultation"); !errors.Is(err, errs.ErrMaxSessionsReached) {
+		t.Fatalf("expected ErrMaxSessionsReached, got %v", err)
+	}
+	if ok, err := sessionSvc.ValidateUserSession(ctx, u.ID, "room-2", "consultation", "host"); err != nil || ok {
+		t.Fatalf("ValidateUserSession must deny beyond max_sessions: %v, %v", ok, err)
+	}
+
+	// Повтор входа в ту же сессию возвращает действующее участие.
+	retry, err := join("room-0", "consultation")
+	if err != nil || retry.ID != first.ID {
+		t.Fatalf("retry must return the active session %s, got %+v, %v", first.ID, retry, err)
+	}
+
+	// Повторное и параллельное закрытие не закрывает участие дважды.
+	if _, err := sessionSvc.EndSession(ctx, u.ID, first.ID); err != nil {
+		t.Fatalf("EndSession failed: %v", err)
+	}
+	var stale model.UserSession
+	if err := conn.First(&stale, "id = ?", first.ID).Error; err != nil {
+		t.Fatalf("load session: %v", err)
+	}
+	stale.LeftAt = nil
+	if closed, err := closeSession(conn, &stale, time.Now().Add(time.Hour)); err != nil || closed {
+		t.Fatalf("closeSession on an already closed session: closed=%v err=%v", closed, err)
+	}
+	if n, err := sessionSvc.EndSessionsByExternalID(ctx, "room-0"); err != nil || n != 0 {
+		t.Fatalf("EndSessionsByExternalID on a closed session: n=%d err=%v", n, err)
+	}
+
+	// Уникальный индекс не пропускает второе активное участие в обход сервиса.
+	dup := &model.UserSession{ID: "00000000-0000-0000-0000-000000000001", UserID: u.ID, SessionType: "consultation",
+		SessionExternalID: "room-1", ParticipantRole: "host", JoinedAt: time.Now()}
+	if err := conn.Create(dup).Error; err == nil {
+		t.Error("Expected unique violation for duplicate active session")
+	}
+}
+
+// TestSession_AdmissionWaitsForUserLock проверяет блокировку строки пользователя на PostgreSQL
+// (sqlite в testDB — одно соединение без FOR UPDATE). Первый вход останавливается после проверки лимитов,
+// не вставив участие; второй должен ждать его коммита и увидеть занятый слот.
+func TestSession_AdmissionWaitsForUserLock(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set: row locks need PostgreSQL")
+	}
+	if err := database.MigrateUp(url); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	conn, err := db.Open(url)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	sessionSvc := NewSessionService(conn)
+	ctx := context.Background()
+
+	u, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Email:    fmt.Sprintf("lock-%d@example.com", time.Now().UnixNano()),
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	admitted, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	if err := conn.Callback().Create().Before("gorm:create").Register("test:hold_admission", func(tx *gorm.DB) {
+		if tx.Statement.Table == "user_sessions" {
+			once.Do(func() {
+				close(admitted)
+				<-release
+			})
+		}
+	}); err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	join := func(room string) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := sessionSvc.CreateSession(ctx, u.ID, &dto.CreateSessionRequest{
+				SessionType:       "consultation",
+				SessionExternalID: room,
+				ParticipantRole:   "host",
+			})
+			done <- err
+		}()
+		return done
+	}
+
+	first := join("room-a")
+	<-admitted
+	second := join("room-b")
+	select {
+	case err := <-second:
+		close(release)
+		t.Fatalf("second join finished while the first held the user lock: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatalf("first join: %v", err)
+	}
+	if err := <-second; !errors.Is(err, errs.ErrMaxSessionsReached) {
+		t.Fatalf("second join: expected ErrMaxSessionsReached, got %v", err)
+	}
+}
+
+func TestSession_RateConsultationUpdatesOperatorRating(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	sessionSvc := NewSessionService(conn)
+	ctx := context.Background()
+
+	op, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "op@example.com", Password: "secretpassword", Role: constants.RoleOperator})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	conn.Model(&model.User{}).Where("id = ?", op.ID).Updates(map[string]interface{}{
+		"operator_status": constants.OperatorStatusVerified,
+		"is_available":    true,
+		"max_sessions":    5,
+	})
+
+	var ratings []int
+	for i, rating := range []int{5, 2} {
+		client, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: fmt.Sprintf("client%d@example.com", i), Password: "secretpassword"})
+		if err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+		room := fmt.Sprintf("room-%d", i)
+		if _, err := sessionSvc.CreateSession(ctx, op.ID, &dto.CreateSessionRequest{SessionType: "consultation", SessionExternalID: room, ParticipantRole: "operator"}); err != nil {
+			t.Fatalf("CreateSession (operator) failed: %v", err)
+		}
+		cs, err := sessionSvc.CreateSession(ctx, client.ID, &dto.CreateSessionRequest{SessionType: "consultation", SessionExternalID: room, ParticipantRole: "host"})
+		if err != nil {
+			t.Fatalf("CreateSession (client) failed: %v", err)
+		}
+		req := &dto.RateConsultationRequest{SessionID: cs.ID, Rating: rating, Feedback: "thanks"}
+		if _, err := sessionSvc.RateConsultation(ctx, client.ID, req); err != errs.ErrSessionNotFinished {
+			t.Fatalf("Expected ErrSessionNotFinished, got %v", err)
+		}
+		if _, err := sessionSvc.EndSessionsByExternalID(ctx, room); err != nil {
+			t.Fatalf("EndSessionsByExternalID failed: %v", err)
+		}
+		var before model.User
+		conn.First(&before, "id = ?", op.ID)
+		if _, err := sessionSvc.RateConsultation(ctx, client.ID, req); err != nil {
+			t.Fatalf("RateConsultation failed: %v", err)
+		}
+		// Оценка меняет отдаваемый rating, поэтому и ETag оператора.
+		var after model.User
+		conn.First(&after, "id = ?", op.ID)
+		if after.Version != before.Version+1 {
+			t.Errorf("Expected version %d after rating, got %d", before.Version+1, after.Version)
+		}
+		if _, err := sessionSvc.RateConsultation(ctx, client.ID, req); err != errs.ErrSessionAlreadyRated {
+			t.Fatalf("Expected ErrSessionAlreadyRated, got %v", err)
+		}
+		ratings = append(ratings, rating)
+	}
+
+	var user model.User
+	conn.First(&user, "id = ?", op.ID)
+	if user.RatingCount != len(ratings) {
+		t.Errorf("Expected rating_count %d, got %d", len(ratings), user.RatingCount)
+	}
+	if user.Rating != 3.5 || user.RatingSum != 7 {
+		t.Errorf("Expected rating 3.5 from rating_sum 7, got %v / %d", user.Rating, user.RatingSum)
+	}
+}
+
+func TestSessionEvents_ApplyIsIdempotent(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.ProcessedMessage{}, &model.SessionTombstone{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	eventSvc := NewSessionEventService(conn)
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "viewer",
+		Email:    "viewer@example.com",
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	joined := &dto.SessionEvent{Type: constants.SessionEventParticipantJoined, SessionID: "room-7", UserID: user.ID}
+	for i, want := range []bool{true, false} {
+		applied, err := eventSvc.Apply(ctx, "msg-1", joined)
+		if err != nil || applied != want {
+			t.Fatalf("delivery %d: applied=%v err=%v, want applied=%v", i+1, applied, err, want)
+		}
+	}
+	// Другое сообщение о том же входе тоже не создаёт второе активное участие.
+	if _, err := eventSvc.Apply(ctx, "msg-2", joined); err != nil {
+		t.Fatalf("Apply msg-2: %v", err)
+	}
+	var active int64
+	conn.Model(&model.UserSession{}).Where("user_id = ? AND left_at IS NULL", user.ID).Count(&active)
+	if active != 1 {
+		t.Fatalf("expected 1 active session, got %d", active)
+	}
+
+	closed := &dto.SessionEvent{Type: constants.SessionEventClosed, SessionID: "room-7"}
+	if _, err := eventSvc.Apply(ctx, "msg-3", closed); err != nil {
+		t.Fatalf("Apply session.closed: %v", err)
+	}
+	conn.Model(&model.UserSession{}).Where("user_id = ? AND left_at IS NULL", user.ID).Count(&active)
+	if active != 0 {
+		t.Fatalf("expected no active sessions after session.closed, got %d", active)
+	}
+
+	bad := &dto.SessionEvent{Type: constants.SessionEventParticipantJoined, SessionID: "room-7", UserID: "not-a-uuid"}
+	if _, err := eventSvc.Apply(ctx, "msg-4", bad); !errors.Is(err, errs.ErrInvalidSessionEvent) {
+		t.Fatalf("expected ErrInvalidSessionEvent, got %v", err)
+	}
+}
+
+func TestSessionEvents_OutOfOrderLeaveAndPurge(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.ProcessedMessage{}, &model.SessionTombstone{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	eventSvc := NewSessionEventService(conn)
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "late@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	t0 := time.Now().Add(-time.Hour)
+	apply := func(id, typ, sessionID, userID string, at time.Time) {
+		t.Helper()
+		ev := &dto.SessionEvent{Type: typ, SessionID: sessionID, UserID: userID, OccurredAt: at}
+		if _, err := eventSvc.Apply(ctx, id, ev); err != nil {
+			t.Fatalf("Apply %s: %v", id, err)
+		}
+	}
+	active := func(sessionID string) int64 {
+		var n int64
+		conn.Model(&model.UserSession{}).Where("user_id = ? AND session_external_id = ? AND left_at IS NULL", user.ID, sessionID).Count(&n)
+		return n
+	}
+
+	// participant.left доставлен раньше participant.joined: вход не открывает участие.
+	apply("m1", constants.SessionEventParticipantLeft, "room-1", user.ID, t0.Add(time.Minute))
+	apply("m2", constants.SessionEventParticipantJoined, "room-1", user.ID, t0)
+	if n := active("room-1"); n != 0 {
+		t.Fatalf("late join after leave opened %d sessions", n)
+	}
+	// Повторный вход после выхода — новое участие.
+	apply("m3", constants.SessionEventParticipantJoined, "room-1", user.ID, t0.Add(2*time.Minute))
+	if n := active("room-1"); n != 1 {
+		t.Fatalf("rejoin: expected 1 active session, got %d", n)
+	}
+
+	// То же для session.closed, пришедшего раньше входа.
+	apply("m4", constants.SessionEventClosed, "room-2", "", t0.Add(time.Minute))
+	apply("m5", constants.SessionEventParticipantJoined, "room-2", user.ID, t0)
+	if n := active("room-2"); n != 0 {
+		t.Fatalf("late join after close opened %d sessions", n)
+	}
+
+	purged, err := eventSvc.PurgeProcessed(ctx, time.Now().Add(time.Minute))
+	if err != nil || purged != 5 {
+		t.Fatalf("PurgeProcessed: purged=%d err=%v, want 5", purged, err)
+	}
+	var tombstones int64
+	conn.Model(&model.SessionTombstone{}).Count(&tombstones)
+	if tombstones != 0 {
+		t.Fatalf("expected tombstones purged, got %d", tombstones)
+	}
+}
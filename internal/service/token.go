@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+// TokenService — контракт выдачи и ротации пар токенов (refresh_tokens).
+type TokenService interface {
+	// Issue начинает новое семейство refresh-токенов (вход, регистрация).
+	Issue(ctx context.Context, user *dto.UserResponse, deviceID string) (*dto.TokenResponse, error)
+	// Rotate обменивает refresh-токен на новую пару. Повторное предъявление уже ротированного
+	// токена отзывает всё семейство и возвращает errs.ErrRefreshTokenReused; заблокированный или
+	// неактивный пользователь новой пары не получает (errs.ErrUserBlocked, errs.ErrUserInactive).
+	Rotate(ctx context.Context, refreshToken string) (*dto.TokenResponse, error)
+	// RevokeFamily отзывает refresh-токены семейства и (через blacklist) выданные в нём access-токены.
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByToken(ctx context.Context, refreshToken string) error
+	// RevokeAllForUser отзывает все семейства пользователя (смена пароля, блокировка, смена роли).
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// AccessPolicy — какие токены получает пользователь с неготовой учётной записью.
+type AccessPolicy struct {
+	EmailVerification    string // constants.EmailVerification*: неподтверждённый email
+	MFARequiredForAdmins bool   // admin без 2FA получает ограниченный токен (только настройка 2FA)
+}
+
+type tokenService struct {
+	db        *gorm.DB
+	jwt       auth.Config
+	blacklist auth.Blacklist // nil — access-токены живут до истечения AccessTTL
+	policy    AccessPolicy
+}
+
+func NewTokenService(db *gorm.DB, jwt auth.Config, blacklist auth.Blacklist, policy AccessPolicy) TokenService {
+	return &tokenService{db: db, jwt: jwt, blacklist: blacklist, policy: policy}
+}
+
+// hashTokenID — SHA-256 от jti; в БД не хранится ничего, что можно предъявить как токен.
+func hashTokenID(jti string) string {
+	sum := sha256.Sum256([]byte(jti))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *tokenService) getByHash(tx *gorm.DB, hash string) (*model.RefreshToken, error) {
+	var t model.RefreshToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", hash).First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// issue выдаёт пару в семействе familyID и сохраняет запись refresh-токена в tx. Для неподтверждённого
+// email по политике: errs.ErrEmailNotVerified (required) или ограниченный access-токен (limited);
+// ограниченный токен получает и admin без 2FA при MFARequiredForAdmins.
+func (s *tokenService) issue(tx *gorm.DB, user *dto.UserResponse, familyID, deviceID string) (*dto.TokenResponse, *model.RefreshToken, error) {
+	limited := s.policy.MFARequiredForAdmins && user.Role == constants.RoleAdmin && !user.MFAEnabled
+	if !user.EmailVerified {
+		switch s.policy.EmailVerification {
+		case constants.EmailVerificationRequired:
+			return nil, nil, errs.ErrEmailNotVerified
+		case constants.EmailVerificationLimited:
+			limited = true
+		}
+	}
+	refresh, jti, expiresAt, err := s.jwt.GenerateRefresh(user.ID, familyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var access string
+	if limited {
+		access, err = s.jwt.GenerateLimitedAccess(user.ID, user.Email, user.Role, familyID)
+	} else {
+		access, err = s.jwt.GenerateAccess(user.ID, user.Email, user.Role, user.OperatorStatus, user.IsAvailable, familyID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	row := &model.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashTokenID(jti),
+		DeviceID:  deviceID,
+		ExpiresAt: expiresAt,
+	}
+	if err := tx.Create(row).Error; err != nil {
+		return nil, nil, err
+	}
+	return &dto.TokenResponse{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int(s.jwt.AccessTTL.Seconds()),
+		User:         user,
+	}, row, nil
+}
+
+func (s *tokenService) Issue(ctx context.Context, user *dto.UserResponse, deviceID string) (*dto.TokenResponse, error) {
+	pair, _, err := s.issue(s.db.WithContext(ctx), user, uuid.New().String(), deviceID)
+	return pair, err
+}
+
+func (s *tokenService) Rotate(ctx context.Context, refreshToken string) (*dto.TokenResponse, error) {
+	claims, err := s.jwt.ValidateRefresh(refreshToken)
+	if err != nil {
+		return nil, errs.ErrInvalidRefreshToken
+	}
+	now := time.Now()
+	var pair *dto.TokenResponse
+	reused := false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		cur, err := s.getByHash(tx, hashTokenID(claims.ID))
+		if err != nil {
+			return err
+		}
+		if cur == nil || cur.UserID != claims.UserID || cur.FamilyID != claims.FamilyID {
+			return errs.ErrInvalidRefreshToken
+		}
+		if cur.RevokedAt != nil {
+			if cur.ReplacedBy == nil {
+				return errs.ErrInvalidRefreshToken
+			}
+			// Токен уже обменян: им пользуется кто-то ещё — отзываем семейство целиком (коммитим отзыв).
+			reused = true
+			return revokeFamily(tx, cur.FamilyID, now)
+		}
+		if !cur.ExpiresAt.After(now) {
+			return errs.ErrInvalidRefreshToken
+		}
+		var u model.User
+		if err := tx.Where("id = ?", cur.UserID).First(&u).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrInvalidRefreshToken
+			}
+			return err
+		}
+		if err := statusError(&u); err != nil {
+			return err
+		}
+		var next *model.RefreshToken
+		pair, next, err = s.issue(tx, mapper.UserToResponse(&u), cur.FamilyID, cur.DeviceID)
+		if err != nil {
+			return err
+		}
+		return tx.Model(cur).Updates(map[string]interface{}{
+			"revoked_at":  now,
+			"replaced_by": next.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		if err := s.blockFamilies(ctx, claims.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, errs.ErrRefreshTokenReused
+	}
+	return pair, nil
+}
+
+func (s *tokenService) RevokeFamily(ctx context.Context, familyID string) error {
+	if _, err := uuid.Parse(familyID); err != nil {
+		return nil
+	}
+	if err := revokeFamily(s.db.WithContext(ctx), familyID, time.Now()); err != nil {
+		return err
+	}
+	return s.blockFamilies(ctx, familyID)
+}
+
+// RevokeByToken отзывает семейство предъявленного refresh-токена; невалидный токен игнорируется.
+func (s *tokenService) RevokeByToken(ctx context.Context, refreshToken string) error {
+	claims, err := s.jwt.ValidateRefresh(refreshToken)
+	if err != nil {
+		return nil
+	}
+	return s.RevokeFamily(ctx, claims.FamilyID)
+}
+
+func (s *tokenService) RevokeAllForUser(ctx context.Context, userID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	var families []string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Distinct().Pluck("family_id", &families).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
+		return err
+	}
+	return s.blockFamilies(ctx, families...)
+}
+
+// blockFamilies заносит семейства в blacklist на AccessTTL — дольше их access-токены не живут.
+func (s *tokenService) blockFamilies(ctx context.Context, familyIDs ...string) error {
+	if s.blacklist == nil {
+		return nil
+	}
+	expireAt := time.Now().Add(s.jwt.AccessTTL)
+	for _, id := range familyIDs {
+		if err := s.blacklist.Add(ctx, auth.FamilyKey(id), expireAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func revokeFamily(tx *gorm.DB, familyID string, now time.Time) error {
+	return tx.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/psds-microservice/user-service/internal/auth"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/pkg/constants"
+)
+
+func TestToken_RotateDetectsReuse(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.RefreshToken{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	cfg, _ := auth.NewConfig("test-secret", "15m", "24h")
+	userSvc := NewUserService(conn)
+	tokenSvc := NewTokenService(conn, cfg, nil, AccessPolicy{EmailVerification: constants.EmailVerificationOff})
+	ctx := context.Background()
+
+	user, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{
+		Username: "client",
+		Email:    "client@example.com",
+		Password: "secretpassword",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	first, err := tokenSvc.Issue(ctx, user, "phone-1")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	second, err := tokenSvc.Rotate(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("Rotate must issue a new refresh token")
+	}
+
+	if _, err := tokenSvc.Rotate(ctx, first.RefreshToken); !errors.Is(err, errs.ErrRefreshTokenReused) {
+		t.Fatalf("reusing rotated token: expected ErrRefreshTokenReused, got %v", err)
+	}
+	if _, err := tokenSvc.Rotate(ctx, second.RefreshToken); !errors.Is(err, errs.ErrInvalidRefreshToken) {
+		t.Fatalf("family must be revoked after reuse, got %v", err)
+	}
+
+	third, err := tokenSvc.Issue(ctx, user, "")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if err := tokenSvc.RevokeByToken(ctx, third.RefreshToken); err != nil {
+		t.Fatalf("RevokeByToken failed: %v", err)
+	}
+	if _, err := tokenSvc.Rotate(ctx, third.RefreshToken); !errors.Is(err, errs.ErrInvalidRefreshToken) {
+		t.Fatalf("revoked token: expected ErrInvalidRefreshToken, got %v", err)
+	}
+
+	// Заблокированный или неактивный пользователь не обменивает refresh-токен на новую пару.
+	for _, tc := range []struct {
+		status string
+		want   error
+	}{
+		{constants.UserStatusBlocked, errs.ErrUserBlocked},
+		{constants.UserStatusInactive, errs.ErrUserInactive},
+	} {
+		pair, err := tokenSvc.Issue(ctx, user, "")
+		if err != nil {
+			t.Fatalf("Issue failed: %v", err)
+		}
+		if _, err := userSvc.SetStatus(ctx, user.ID, tc.status); err != nil {
+			t.Fatalf("SetStatus failed: %v", err)
+		}
+		if _, err := tokenSvc.Rotate(ctx, pair.RefreshToken); !errors.Is(err, tc.want) {
+			t.Fatalf("%s user: expected %v, got %v", tc.status, tc.want, err)
+		}
+	}
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/psds-microservice/user-service/internal/audit"
 	"github.com/psds-microservice/user-service/internal/dto"
 	"github.com/psds-microservice/user-service/internal/errs"
 	"github.com/psds-microservice/user-service/internal/mapper"
@@ -19,8 +24,24 @@ type UserService interface {
 	CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error)
 	GetUser(ctx context.Context, id string) (*dto.UserResponse, error)
 	UpdateUser(ctx context.Context, req *dto.UpdateUserRequest) (*dto.UserResponse, error)
+	// DeleteUser — мягкое удаление (deleted_at): пользователь исчезает из всех запросов, данные сохраняются.
 	DeleteUser(ctx context.Context, id string) error
-	ListUsers(ctx context.Context, filters *dto.UserFilters) ([]*dto.UserResponse, int64, error)
+	// RestoreUser отменяет мягкое удаление; errs.ErrUserAlreadyExists — email или username уже заняты.
+	RestoreUser(ctx context.Context, id string) (*dto.UserResponse, error)
+	// PurgeUser обезличивает пользователя (удалённого или нет) и удаляет его устройства и токены.
+	PurgeUser(ctx context.Context, id string) error
+	// PurgeDeleted очищает до limit пользователей, удалённых раньше before; возвращает их число.
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
+	ListUsers(ctx context.Context, filters *dto.UserFilters) (*dto.UserPage, error)
+	// SetUserRole меняет роль и пишет user.role_change с источником и причиной в audit_events.
+	SetUserRole(ctx context.Context, req *dto.SetUserRoleRequest) (*dto.UserResponse, error)
+	// CreateAdmin создаёт admin из CLI. firstOnly — только если admin ещё нет (иначе errs.ErrAdminExists).
+	CreateAdmin(ctx context.Context, req *dto.CreateUserRequest, firstOnly bool) (*dto.UserResponse, error)
+	GetUserByEmail(ctx context.Context, email string) (*dto.UserResponse, error)
+	// SetStatus меняет users.status (active, inactive, blocked).
+	SetStatus(ctx context.Context, id, status string) (*dto.UserResponse, error)
+	// SetPassword устанавливает новый пароль без проверки старого (администрирование).
+	SetPassword(ctx context.Context, id, password string) error
 }
 
 type userService struct {
@@ -68,7 +89,28 @@ func (s *userService) getByUsername(ctx context.Context, username string) (*mode
 	return &u, nil
 }
 
+// CreateUser создаёт пользователя. Без ActorID (регистрация) допустимы только client и operator
+// (operator_status = pending); admin создаёт только другой admin или BootstrapAdmin.
 func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest) (*dto.UserResponse, error) {
+	role := req.Role
+	if role == "" {
+		role = constants.RoleClient
+	}
+	if !validRole(role) {
+		return nil, errs.ErrInvalidRole
+	}
+	source := constants.RoleSourceAdmin
+	if req.ActorID == "" {
+		source = constants.RoleSourceRegister
+		if role == constants.RoleAdmin || role == constants.RoleService {
+			return nil, errs.ErrRoleNotAllowed
+		}
+	}
+	return s.createUser(ctx, req, role, source, nil)
+}
+
+// createUser создаёт пользователя с уже проверенной ролью; guard выполняется в транзакции до вставки.
+func (s *userService) createUser(ctx context.Context, req *dto.CreateUserRequest, role, source string, guard func(tx *gorm.DB) error) (*dto.UserResponse, error) {
 	if req.Username != "" {
 		existing, err := s.getByUsername(ctx, req.Username)
 		if err != nil {
@@ -96,13 +138,6 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 		username = req.Email
 	}
 
-	role := req.Role
-	if role == "" {
-		role = constants.RoleClient
-	}
-	if role != constants.RoleClient && role != constants.RoleOperator && role != constants.RoleAdmin {
-		role = constants.RoleClient
-	}
 	operatorStatus := ""
 	if role == constants.RoleOperator {
 		operatorStatus = constants.OperatorStatusPending
@@ -123,7 +158,27 @@ func (s *userService) CreateUser(ctx context.Context, req *dto.CreateUserRequest
 		Language:       "en",
 	}
 
-	if err := s.db.WithContext(ctx).Create(user).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if guard != nil {
+			if err := guard(tx); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{
+			Action:   audit.ActionUserCreate,
+			TargetID: user.ID,
+			ActorID:  req.ActorID,
+			Changes:  userAuditChanges(&model.User{}, user, false),
+			Source:   source,
+		}); err != nil {
+			return err
+		}
+		return enqueueUserCreated(tx, user)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return mapper.UserToResponse(user), nil
@@ -140,32 +195,79 @@ func (s *userService) UpdateUser(ctx context.Context, req *dto.UpdateUserRequest
 	if user == nil {
 		return nil, errs.ErrUserNotFound
 	}
-
-	if req.Username != "" {
-		user.Username = req.Username
-	}
-	if req.Email != "" {
-		user.Email = req.Email
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != user.Version {
+		return nil, errs.ErrVersionConflict
 	}
-	user.Phone = req.Phone
-	if req.Status != "" {
-		user.Status = req.Status
+	if len(req.UpdateMask) == 0 {
+		return mapper.UserToResponse(user), nil
 	}
-	if req.Password != "" {
-		hashed, err := hashPassword(req.Password)
-		if err != nil {
-			return nil, err
+	before := *user
+
+	passwordChanged := false
+	for _, field := range req.UpdateMask {
+		switch field {
+		case dto.UserFieldUsername:
+			user.Username = req.Username
+		case dto.UserFieldEmail:
+			if req.Email != user.Email {
+				// Новый адрес не подтверждён: прежние ссылки подтверждения недействительны (гасятся в транзакции).
+				user.Email = req.Email
+				user.EmailVerifiedAt = nil
+			}
+		case dto.UserFieldPhone:
+			user.Phone = req.Phone
+		case dto.UserFieldPassword:
+			if req.RequireCurrentPassword && !checkPassword(before.PasswordHash, req.CurrentPassword) {
+				return nil, errs.ErrInvalidCurrentPassword
+			}
+			hashed, err := hashPassword(req.Password)
+			if err != nil {
+				return nil, err
+			}
+			user.PasswordHash = hashed
+			passwordChanged = true
+		case dto.UserFieldStatus:
+			user.Status = req.Status
+		case dto.UserFieldFullName:
+			user.FullName = req.FullName
+		case dto.UserFieldAvatarURL:
+			user.AvatarURL = req.AvatarURL
+		case dto.UserFieldTimezone:
+			user.Timezone = req.Timezone
+		case dto.UserFieldLanguage:
+			user.Language = req.Language
+		case dto.UserFieldCompany:
+			user.Company = req.Company
+		case dto.UserFieldSpecialization:
+			user.Specialization = req.Specialization
+		default:
+			return nil, errs.ErrInvalidUpdateMask
 		}
-		user.PasswordHash = hashed
 	}
-	user.FullName = req.FullName
-	user.AvatarURL = req.AvatarURL
-	user.Timezone = req.Timezone
-	user.Language = req.Language
-	user.Company = req.Company
-	user.Specialization = req.Specialization
 
-	if err := s.db.WithContext(ctx).Save(user).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkIdentityFree(tx, &before, user); err != nil {
+			return err
+		}
+		if err := saveUser(tx, user); err != nil {
+			if isUniqueViolation(err) {
+				return errs.ErrUserAlreadyExists
+			}
+			return err
+		}
+		if before.Email != user.Email {
+			if err := tx.Model(&model.UserToken{}).
+				Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, constants.TokenPurposeEmailVerification).
+				Update("used_at", time.Now()).Error; err != nil {
+				return err
+			}
+		}
+		if err := auditUser(ctx, tx, audit.ActionUserUpdate, &before, user, passwordChanged); err != nil {
+			return err
+		}
+		return enqueueUserUpdated(tx, &before, user, passwordChanged)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return mapper.UserToResponse(user), nil
@@ -175,7 +277,21 @@ func (s *userService) DeleteUser(ctx context.Context, id string) error {
 	if _, err := uuid.Parse(id); err != nil {
 		return errs.ErrInvalidUserID
 	}
-	return s.db.WithContext(ctx).Delete(&model.User{}, "id = ?", id).Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Удалённый пользователь не может оставаться онлайн или доступным для назначения консультаций.
+		res := tx.Model(&model.User{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"is_online": false, "is_available": false, "version": bumpVersion})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := tx.Delete(&model.User{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionUserDelete, TargetID: id}); err != nil {
+			return err
+		}
+		return enqueueUserDeleted(tx, id)
+	})
 }
 
 func (s *userService) GetUser(ctx context.Context, id string) (*dto.UserResponse, error) {
@@ -192,39 +308,250 @@ func (s *userService) GetUser(ctx context.Context, id string) (*dto.UserResponse
 	return mapper.UserToResponse(user), nil
 }
 
-func (s *userService) ListUsers(ctx context.Context, filters *dto.UserFilters) ([]*dto.UserResponse, int64, error) {
-	var list []*model.User
-	var count int64
-	query := s.db.WithContext(ctx).Model(&model.User{})
-	if filters != nil {
-		if filters.Status != "" {
-			query = query.Where("status = ?", filters.Status)
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (*dto.UserResponse, error) {
+	user, err := s.getByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errs.ErrUserNotFound
+	}
+	return mapper.UserToResponse(user), nil
+}
+
+func (s *userService) SetStatus(ctx context.Context, id, status string) (*dto.UserResponse, error) {
+	if status != constants.UserStatusActive && status != constants.UserStatusInactive && status != constants.UserStatusBlocked {
+		return nil, errs.ErrInvalidUserStatus
+	}
+	return s.modify(ctx, id, audit.ActionUserStatus, false, func(u *model.User) error {
+		u.Status = status
+		return nil
+	})
+}
+
+func (s *userService) SetPassword(ctx context.Context, id, password string) error {
+	hashed, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = s.modify(ctx, id, audit.ActionUserPassword, true, func(u *model.User) error {
+		u.PasswordHash = hashed
+		return nil
+	})
+	return err
+}
+
+// checkIdentityFree возвращает errs.ErrUserAlreadyExists, если новый email или username уже занят
+// другим неудалённым пользователем (частичные уникальные индексы users).
+func checkIdentityFree(tx *gorm.DB, before, after *model.User) error {
+	q := tx.Model(&model.User{}).Where("id <> ?", after.ID)
+	switch {
+	case before.Email != after.Email && before.Username != after.Username:
+		q = q.Where("email = ? OR username = ?", after.Email, after.Username)
+	case before.Email != after.Email:
+		q = q.Where("email = ?", after.Email)
+	case before.Username != after.Username:
+		q = q.Where("username = ?", after.Username)
+	default:
+		return nil
+	}
+	var taken int64
+	if err := q.Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return errs.ErrUserAlreadyExists
+	}
+	return nil
+}
+
+// isUniqueViolation — нарушение уникального индекса (Postgres SQLSTATE 23505, SQLite в тестах):
+// параллельная запись заняла email или username между проверкой и сохранением.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "SQLSTATE 23505") || strings.Contains(msg, "UNIQUE constraint failed")
+}
+
+// likeEscaper экранирует спецсимволы LIKE: % и _ в поиске — обычные символы, а не шаблон.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike готовит пользовательский ввод для LIKE/ILIKE ... ESCAPE '\'.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// bumpVersion — значение users.version для точечных Updates: изменение строки меняет ETag.
+var bumpVersion = gorm.Expr("version + 1")
+
+// saveUser сохраняет прочитанного пользователя целиком, только если с момента чтения строку никто
+// не изменил (version не изменилась), и увеличивает version. Иначе — errs.ErrVersionConflict:
+// параллельная запись не затирается.
+func saveUser(tx *gorm.DB, u *model.User) error {
+	read := u.Version
+	u.Version = read + 1
+	res := tx.Model(u).Where("version = ?", read).Select("*").Omit("CreatedAt", "DeletedAt").Updates(u)
+	if res.Error == nil && res.RowsAffected == 0 {
+		res.Error = errs.ErrVersionConflict
+	}
+	if res.Error != nil {
+		u.Version = read
+		return res.Error
+	}
+	return nil
+}
+
+// modify загружает пользователя, применяет apply и сохраняет его вместе с user.updated и записью
+// аудита action в одной транзакции.
+func (s *userService) modify(ctx context.Context, id, action string, passwordChanged bool, apply func(u *model.User) error) (*dto.UserResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var user model.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrUserNotFound
+			}
+			return err
 		}
-		if filters.Role != "" {
-			query = query.Where("role = ?", filters.Role)
+		before := user
+		if err := apply(&user); err != nil {
+			return err
 		}
-		if filters.Search != "" {
-			search := "%" + filters.Search + "%"
-			query = query.Where("username ILIKE ? OR email ILIKE ?", search, search)
+		if err := saveUser(tx, &user); err != nil {
+			return err
 		}
+		if err := auditUser(ctx, tx, action, &before, &user, passwordChanged); err != nil {
+			return err
+		}
+		return enqueueUserUpdated(tx, &before, &user, passwordChanged)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.UserToResponse(&user), nil
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// userCursor — содержимое курсора keyset-пагинации: значение ключа сортировки и id последней строки.
+type userCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func encodeUserCursor(c userCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeUserCursor(raw string) (*userCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errs.ErrInvalidCursor
 	}
-	if err := query.Count(&count).Error; err != nil {
-		return nil, 0, err
+	var c userCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, errs.ErrInvalidCursor
 	}
-	if filters != nil {
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
+	return &c, nil
+}
+
+// ListUsers возвращает страницу пользователей. Пагинация keyset по (ключ сортировки, id):
+// страницы стабильны при вставке новых пользователей и не деградируют на больших смещениях.
+func (s *userService) ListUsers(ctx context.Context, filters *dto.UserFilters) (*dto.UserPage, error) {
+	if filters == nil {
+		filters = &dto.UserFilters{}
+	}
+	sort := filters.Sort
+	if sort == "" {
+		sort = "-created_at"
+	}
+	desc := strings.HasPrefix(sort, "-")
+	column := strings.TrimPrefix(sort, "-")
+	if column != "created_at" && column != "username" {
+		return nil, errs.ErrInvalidSort
+	}
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	query := s.db.WithContext(ctx).Model(&model.User{})
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.Role != "" {
+		query = query.Where("role = ?", filters.Role)
+	}
+	if filters.Search != "" {
+		search := "%" + escapeLike(filters.Search) + "%"
+		query = query.Where(`username ILIKE ? ESCAPE '\' OR email ILIKE ? ESCAPE '\'`, search, search)
+	}
+	if filters.OperatorStatus != "" {
+		query = query.Where("operator_status = ?", filters.OperatorStatus)
+	}
+	if filters.IsOnline != nil {
+		query = query.Where("is_online = ?", *filters.IsOnline)
+	}
+	if filters.IsAvailable != nil {
+		query = query.Where("is_available = ?", *filters.IsAvailable)
+	}
+	if filters.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filters.CreatedBefore)
+	}
+
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+	if filters.Cursor != "" {
+		c, err := decodeUserCursor(filters.Cursor)
+		if err != nil {
+			return nil, err
 		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if c.Sort != sort {
+			return nil, errs.ErrInvalidCursor
 		}
+		var value interface{} = c.Value
+		if column == "created_at" {
+			t, err := time.Parse(time.RFC3339Nano, c.Value)
+			if err != nil {
+				return nil, errs.ErrInvalidCursor
+			}
+			value = t
+		}
+		query = query.Where("("+column+" "+op+" ?) OR ("+column+" = ? AND id "+op+" ?)", value, value, c.ID)
 	}
+	query = query.Order(column + " " + dir).Order("id " + dir).Limit(limit + 1)
+
+	var list []*model.User
 	if err := query.Find(&list).Error; err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	page := &dto.UserPage{}
+	if len(list) > limit {
+		list = list[:limit]
+		last := list[len(list)-1]
+		c := userCursor{Sort: sort, ID: last.ID, Value: last.Username}
+		if column == "created_at" {
+			c.Value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
+		}
+		page.NextCursor = encodeUserCursor(c)
 	}
-	out := make([]*dto.UserResponse, len(list))
+	page.Users = make([]*dto.UserResponse, len(list))
 	for i := range list {
-		out[i] = mapper.UserToResponse(list[i])
+		page.Users[i] = mapper.UserToResponse(list[i])
 	}
-	return out, count, nil
+	return page, nil
 }
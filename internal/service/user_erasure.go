@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/audit"
+	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
+	"github.com/psds-microservice/user-service/internal/mapper"
+	"github.com/psds-microservice/user-service/internal/model"
+)
+
+// purgedEmailDomain — домен обезличенных адресов (.invalid не резолвится, RFC 2606).
+const purgedEmailDomain = "purged.invalid"
+
+// RestoreUser снимает deleted_at. Пока пользователь был удалён, его email или username могли занять заново.
+func (s *userService) RestoreUser(ctx context.Context, id string) (*dto.UserResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errs.ErrInvalidUserID
+	}
+	var user model.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL AND purged_at IS NULL", id).First(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var taken int64
+		if err := tx.Model(&model.User{}).Where("email = ? OR username = ?", user.Email, user.Username).
+			Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return errs.ErrUserAlreadyExists
+		}
+		if err := tx.Unscoped().Model(&model.User{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": bumpVersion}).Error; err != nil {
+			return err
+		}
+		user.DeletedAt = gorm.DeletedAt{}
+		if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionUserRestore, TargetID: id}); err != nil {
+			return err
+		}
+		return enqueueUserRestored(tx, &user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapper.UserToResponse(&user), nil
+}
+
+// PurgeUser заменяет персональные данные пользователя обезличенными значениями и удаляет связанные
+// с ним устройства, маршруты и токены. Запись users и история сессий остаются: на них ссылаются
+// статистика операторов и сессии других участников. Повторная очистка — errs.ErrUserNotFound.
+func (s *userService) PurgeUser(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return errs.ErrInvalidUserID
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return purgeUser(ctx, tx, id)
+	})
+}
+
+func purgeUser(ctx context.Context, tx *gorm.DB, id string) error {
+	var user model.User
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND purged_at IS NULL", id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// Счётчик перебора паролей хранит email в ключе.
+	if err := tx.Where("throttle_key = ?", "account:"+strings.ToLower(user.Email)).Delete(&model.LoginThrottle{}).Error; err != nil {
+		return err
+	}
+	for _, m := range []interface{}{&model.UserDevice{}, &model.UserService{}, &model.RefreshToken{}, &model.UserToken{}, &model.MFARecoveryCode{}, &model.MFAChallenge{}} {
+		if err := tx.Where("user_id = ?", id).Delete(m).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&model.UserSession{}).Where("user_id = ? AND consultation_feedback <> ''", id).
+		Update("consultation_feedback", "").Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	updates := map[string]interface{}{
+		"username":          "purged-" + id,
+		"email":             "purged+" + id + "@" + purgedEmailDomain,
+		"password_hash":     "",
+		"phone":             "",
+		"full_name":         "",
+		"avatar_url":        "",
+		"company":           "",
+		"specialization":    "",
+		"timezone":          "",
+		"email_verified_at": nil,
+		"mfa_secret":        "",
+		"mfa_enabled_at":    nil,
+		"settings":          nil,
+		"streaming_config":  nil,
+		"metadata":          nil,
+		"is_online":         false,
+		"is_available":      false,
+		"purged_at":         now,
+		"version":           bumpVersion,
+	}
+	if !user.DeletedAt.Valid {
+		updates["deleted_at"] = now
+	}
+	if err := tx.Unscoped().Model(&model.User{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return err
+	}
+	if err := audit.Record(ctx, tx, audit.Entry{Action: audit.ActionUserPurge, TargetID: id}); err != nil {
+		return err
+	}
+	return enqueueUserPurged(tx, id)
+}
+
+// PurgeDeleted — очистка по сроку хранения: каждый пользователь очищается в своей транзакции,
+// параллельные экземпляры пропускают уже очищенных.
+func (s *userService) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	var ids []string
+	if err := s.db.WithContext(ctx).Unscoped().Model(&model.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND purged_at IS NULL", before).
+		Order("deleted_at").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, id := range ids {
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return purgeUser(ctx, tx, id)
+		})
+		if errors.Is(err, errs.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeBatchSize — пользователей за один вызов PurgeDeleted в RunPurgeDeleted.
+const purgeBatchSize = 100
+
+// RunPurgeDeleted каждые interval очищает пользователей, удалённых дольше retention, до отмены ctx.
+func RunPurgeDeleted(ctx context.Context, users UserService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total := 0
+			for {
+				n, err := users.PurgeDeleted(ctx, time.Now().Add(-retention), purgeBatchSize)
+				total += n
+				if err != nil {
+					log.Printf("deleted users purge: %v", err)
+					break
+				}
+				if n < purgeBatchSize {
+					break
+				}
+			}
+			if total > 0 {
+				log.Printf("deleted users purge: purged %d", total)
+			}
+		}
+	}
+}
@@ -2,10 +2,17 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/psds-microservice/helpy/db"
+	"github.com/psds-microservice/user-service/internal/audit"
 	"github.com/psds-microservice/user-service/internal/dto"
+	"github.com/psds-microservice/user-service/internal/errs"
 	"github.com/psds-microservice/user-service/internal/model"
 	"gorm.io/gorm"
 )
@@ -16,7 +23,7 @@ func testDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("open in-memory: %v", err)
 	}
-	if err := conn.AutoMigrate(&model.User{}, &model.UserSession{}); err != nil {
+	if err := conn.AutoMigrate(&model.User{}, &model.UserSession{}, &model.OutboxEvent{}, &model.UserToken{}, &model.AuditEvent{}); err != nil {
 		t.Fatalf("migrate: %v", err)
 	}
 	return conn
@@ -25,7 +32,7 @@ func testDB(t *testing.T) *gorm.DB {
 func TestUserAndAuth_CreateAndLogin(t *testing.T) {
 	conn := testDB(t)
 	userSvc := NewUserService(conn)
-	authSvc := NewAuthService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{})
 	ctx := context.Background()
 
 	req := &dto.CreateUserRequest{
@@ -46,7 +53,7 @@ func TestUserAndAuth_CreateAndLogin(t *testing.T) {
 		t.Errorf("Expected email %s, got %s", req.Email, created.Email)
 	}
 
-	loggedIn, err := authSvc.Login(ctx, "test@example.com", "secretpassword")
+	loggedIn, err := authSvc.Login(ctx, "test@example.com", "secretpassword", "")
 	if err != nil {
 		t.Fatalf("Login failed: %v", err)
 	}
@@ -54,8 +61,453 @@ func TestUserAndAuth_CreateAndLogin(t *testing.T) {
 		t.Errorf("Expected logged in user ID %s, got %s", created.ID, loggedIn.ID)
 	}
 
-	_, err = authSvc.Login(ctx, "test@example.com", "wrongpassword")
+	_, err = authSvc.Login(ctx, "test@example.com", "wrongpassword", "")
 	if err == nil {
 		t.Error("Expected error for wrong password, got nil")
 	}
 }
+
+func TestAuth_LoginLockout(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.LoginThrottle{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{MaxFailures: 3, IPMaxFailures: 10, BaseLockout: time.Minute})
+	ctx := context.Background()
+
+	created, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "lock@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err := authSvc.Login(ctx, "lock@example.com", "secretpassword", "10.0.0.1"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	var u model.User
+	if err := conn.First(&u, "id = ?", created.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if u.LastLogin == nil || !strings.Contains(string(u.Stats), `"successful_logins":1`) {
+		t.Errorf("last_login/stats not updated: %v %s", u.LastLogin, u.Stats)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := authSvc.Login(ctx, "lock@example.com", "wrong", "10.0.0.1"); !errors.Is(err, errs.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: expected ErrInvalidCredentials, got %v", i, err)
+		}
+	}
+	// Заблокирована учётная запись: верный пароль не принимается и с другого адреса.
+	_, err = authSvc.Login(ctx, "lock@example.com", "secretpassword", "10.0.0.2")
+	var locked *errs.LockedError
+	if !errors.As(err, &locked) || locked.RetryAfter <= 0 || locked.RetryAfter > time.Minute {
+		t.Fatalf("expected LockedError with RetryAfter <= 1m, got %v", err)
+	}
+	if !errors.Is(err, errs.ErrTooManyLoginAttempts) {
+		t.Error("LockedError must wrap ErrTooManyLoginAttempts")
+	}
+
+	// Несуществующий email блокируется так же, как существующий.
+	for i := 0; i < 3; i++ {
+		_, _ = authSvc.Login(ctx, "ghost@example.com", "wrong", "")
+	}
+	if _, err := authSvc.Login(ctx, "ghost@example.com", "wrong", ""); !errors.Is(err, errs.ErrTooManyLoginAttempts) {
+		t.Errorf("expected lockout for unknown email, got %v", err)
+	}
+
+	// Очистка не трогает действующие блокировки и удаляет счётчики без неудач дольше окна.
+	if n, err := authSvc.PurgeThrottle(ctx); err != nil || n != 0 {
+		t.Fatalf("PurgeThrottle with fresh counters: n=%d err=%v", n, err)
+	}
+	stale := time.Now().Add(-time.Hour)
+	if err := conn.Model(&model.LoginThrottle{}).Where("1 = 1").
+		Updates(map[string]interface{}{"last_failure_at": stale, "locked_until": stale}).Error; err != nil {
+		t.Fatalf("age counters: %v", err)
+	}
+	if n, err := authSvc.PurgeThrottle(ctx); err != nil || n != 3 {
+		t.Fatalf("PurgeThrottle: expected 3 stale counters removed, n=%d err=%v", n, err)
+	}
+}
+
+func TestUser_RoleEscalation(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	if _, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "eve@example.com", Password: "secretpassword", Role: "admin"}); !errors.Is(err, errs.ErrRoleNotAllowed) {
+		t.Fatalf("public admin registration: expected ErrRoleNotAllowed, got %v", err)
+	}
+	if _, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "svc@example.com", Password: "secretpassword", Role: "service"}); !errors.Is(err, errs.ErrRoleNotAllowed) {
+		t.Fatalf("public service registration: expected ErrRoleNotAllowed, got %v", err)
+	}
+	op, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "op@example.com", Password: "secretpassword", Role: "operator"})
+	if err != nil || op.OperatorStatus != "pending" {
+		t.Fatalf("operator registration: %v %+v", err, op)
+	}
+
+	root, err := userSvc.CreateAdmin(ctx, &dto.CreateUserRequest{Email: "root@example.com", Password: "secretpassword"}, true)
+	if err != nil || root.Role != "admin" {
+		t.Fatalf("CreateAdmin: %v %+v", err, root)
+	}
+	if _, err := userSvc.CreateAdmin(ctx, &dto.CreateUserRequest{Email: "root2@example.com", Password: "secretpassword"}, true); !errors.Is(err, errs.ErrAdminExists) {
+		t.Fatalf("second bootstrap: expected ErrAdminExists, got %v", err)
+	}
+	if _, err := userSvc.SetUserRole(ctx, &dto.SetUserRoleRequest{ID: root.ID, Role: "client", ActorID: root.ID}); !errors.Is(err, errs.ErrLastAdmin) {
+		t.Fatalf("demote last admin: expected ErrLastAdmin, got %v", err)
+	}
+
+	promoted, err := userSvc.SetUserRole(ctx, &dto.SetUserRoleRequest{ID: op.ID, Role: "admin", ActorID: root.ID, Reason: "team lead"})
+	if err != nil || promoted.Role != "admin" || promoted.OperatorStatus != "pending" || promoted.IsAvailable {
+		t.Fatalf("SetUserRole: %v %+v", err, promoted)
+	}
+	var trail []model.AuditEvent
+	if err := conn.Where("target_id = ? AND action IN ?", op.ID, []string{"user.create", "user.role_change"}).
+		Order("created_at").Find(&trail).Error; err != nil {
+		t.Fatalf("load trail: %v", err)
+	}
+	if len(trail) != 2 || trail[0].Source != "register" || trail[1].Source != "admin" ||
+		!strings.Contains(string(trail[1].Changes), `"role":{"old":"operator","new":"admin"}`) ||
+		trail[1].ActorID == nil || *trail[1].ActorID != root.ID || trail[1].Reason != "team lead" {
+		t.Errorf("unexpected role trail: %+v", trail)
+	}
+}
+
+func TestUser_AdminStatusAndPassword(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.LoginThrottle{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{})
+	ctx := context.Background()
+
+	created, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "mallory@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	found, err := userSvc.GetUserByEmail(ctx, "mallory@example.com")
+	if err != nil || found.ID != created.ID {
+		t.Fatalf("GetUserByEmail: %v %+v", err, found)
+	}
+	if _, err := userSvc.SetStatus(ctx, created.ID, "frozen"); !errors.Is(err, errs.ErrInvalidUserStatus) {
+		t.Fatalf("expected ErrInvalidUserStatus, got %v", err)
+	}
+	if _, err := userSvc.SetStatus(ctx, created.ID, "blocked"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if _, err := authSvc.Login(ctx, "mallory@example.com", "secretpassword", ""); !errors.Is(err, errs.ErrUserBlocked) {
+		t.Fatalf("blocked login: expected ErrUserBlocked, got %v", err)
+	}
+	if _, err := userSvc.SetStatus(ctx, created.ID, "active"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := userSvc.SetPassword(ctx, created.ID, "anotherpassword"); err != nil {
+		t.Fatalf("SetPassword: %v", err)
+	}
+	if _, err := authSvc.Login(ctx, "mallory@example.com", "anotherpassword", ""); err != nil {
+		t.Fatalf("login with new password: %v", err)
+	}
+}
+
+func TestUser_ListUsersCursorPagination(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	for _, name := range []string{"dave", "alice", "carol", "bob", "erin"} {
+		if _, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Username: name, Email: name + "@example.com", Password: "secretpassword"}); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+
+	var got []string
+	filters := &dto.UserFilters{Sort: "username", Limit: 2}
+	for i := 0; i < 5; i++ {
+		page, err := userSvc.ListUsers(ctx, filters)
+		if err != nil {
+			t.Fatalf("ListUsers failed: %v", err)
+		}
+		for _, u := range page.Users {
+			got = append(got, u.Username)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filters.Cursor = page.NextCursor
+	}
+	want := "alice,bob,carol,dave,erin"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(got, ","))
+	}
+
+	if _, err := userSvc.ListUsers(ctx, &dto.UserFilters{Sort: "-username", Cursor: filters.Cursor}); err != errs.ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor for cursor from another sort, got %v", err)
+	}
+}
+
+func TestUser_SoftDeleteRestorePurge(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.LoginThrottle{}, &model.UserDevice{}, &model.UserService{}, &model.RefreshToken{}, &model.MFARecoveryCode{}, &model.MFAChallenge{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{})
+	ctx := context.Background()
+
+	alice, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Username: "alice", Email: "alice@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	session := &model.UserSession{ID: uuid.New().String(), UserID: alice.ID, SessionType: "consultation",
+		SessionExternalID: "ext-1", ParticipantRole: "client", JoinedAt: time.Now(), ConsultationFeedback: "call me at +100"}
+	if err := conn.Create(session).Error; err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	if err := userSvc.DeleteUser(ctx, alice.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if _, err := userSvc.GetUser(ctx, alice.ID); !errors.Is(err, errs.ErrUserNotFound) {
+		t.Fatalf("deleted user: expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := authSvc.Login(ctx, "alice@example.com", "secretpassword", ""); !errors.Is(err, errs.ErrInvalidCredentials) {
+		t.Fatalf("deleted user login: expected ErrInvalidCredentials, got %v", err)
+	}
+
+	// Email удалённого пользователя свободен; восстановление при занятом email отклоняется.
+	newcomer, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Username: "alice2", Email: "alice@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser with email of deleted user: %v", err)
+	}
+	if _, err := userSvc.RestoreUser(ctx, alice.ID); !errors.Is(err, errs.ErrUserAlreadyExists) {
+		t.Fatalf("restore with taken email: expected ErrUserAlreadyExists, got %v", err)
+	}
+	if err := userSvc.DeleteUser(ctx, newcomer.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	restored, err := userSvc.RestoreUser(ctx, alice.ID)
+	if err != nil || restored.Email != "alice@example.com" {
+		t.Fatalf("RestoreUser: %v %+v", err, restored)
+	}
+	if _, err := userSvc.RestoreUser(ctx, alice.ID); !errors.Is(err, errs.ErrUserNotFound) {
+		t.Fatalf("restore of active user: expected ErrUserNotFound, got %v", err)
+	}
+
+	if err := userSvc.PurgeUser(ctx, alice.ID); err != nil {
+		t.Fatalf("PurgeUser: %v", err)
+	}
+	var purged model.User
+	if err := conn.Unscoped().Where("id = ?", alice.ID).First(&purged).Error; err != nil {
+		t.Fatalf("load purged: %v", err)
+	}
+	if purged.PurgedAt == nil || !purged.DeletedAt.Valid || purged.Email == "alice@example.com" || purged.Username == "alice" || purged.PasswordHash != "" {
+		t.Fatalf("purged user keeps personal data: %+v", purged)
+	}
+	var kept model.UserSession
+	if err := conn.Where("id = ?", session.ID).First(&kept).Error; err != nil {
+		t.Fatalf("session of purged user: %v", err)
+	}
+	if kept.ConsultationFeedback != "" {
+		t.Errorf("feedback not cleared: %q", kept.ConsultationFeedback)
+	}
+	if _, err := userSvc.RestoreUser(ctx, alice.ID); !errors.Is(err, errs.ErrUserNotFound) {
+		t.Fatalf("restore of purged user: expected ErrUserNotFound, got %v", err)
+	}
+
+	if n, err := userSvc.PurgeDeleted(ctx, time.Now().Add(-time.Hour), 10); err != nil || n != 0 {
+		t.Fatalf("PurgeDeleted before retention: n=%d err=%v", n, err)
+	}
+	if n, err := userSvc.PurgeDeleted(ctx, time.Now().Add(time.Second), 10); err != nil || n != 1 {
+		t.Fatalf("PurgeDeleted: n=%d err=%v", n, err)
+	}
+}
+
+func TestAudit_RecordAndList(t *testing.T) {
+	conn := testDB(t)
+	if err := conn.AutoMigrate(&model.LoginThrottle{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	userSvc := NewUserService(conn)
+	authSvc := NewAuthService(conn, LoginLimits{})
+	auditSvc := NewAuditService(conn)
+
+	admin, err := userSvc.CreateAdmin(context.Background(), &dto.CreateUserRequest{Email: "root@example.com", Password: "secretpassword"}, true)
+	if err != nil {
+		t.Fatalf("CreateAdmin: %v", err)
+	}
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: admin.ID, ClientIP: "203.0.113.7", UserAgent: "test"})
+	target, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "eve@example.com", Password: "secretpassword", ActorID: admin.ID})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err := userSvc.SetStatus(ctx, target.ID, "blocked"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	loginCtx := audit.WithActor(context.Background(), audit.Actor{ClientIP: "198.51.100.1"})
+	if _, err := authSvc.Login(loginCtx, "root@example.com", "wrongpassword", ""); !errors.Is(err, errs.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+
+	page, err := auditSvc.ListAuditEvents(ctx, &dto.AuditFilters{Action: audit.ActionUserStatus})
+	if err != nil || len(page.Events) != 1 {
+		t.Fatalf("ListAuditEvents: %v %+v", err, page)
+	}
+	ev := page.Events[0]
+	if ev.ActorID != admin.ID || ev.TargetID != target.ID || ev.ClientIP != "203.0.113.7" || ev.UserAgent != "test" {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+	var changes audit.Changes
+	if err := json.Unmarshal(ev.Changes, &changes); err != nil || changes["status"].New != "blocked" {
+		t.Errorf("unexpected changes: %s (%v)", ev.Changes, err)
+	}
+
+	page, err = auditSvc.ListAuditEvents(ctx, &dto.AuditFilters{TargetID: admin.ID, Action: audit.ActionLoginFailed})
+	if err != nil || len(page.Events) != 1 || page.Events[0].ActorID != admin.ID || page.Events[0].ClientIP != "198.51.100.1" {
+		t.Fatalf("failed login of admin: %v %+v", err, page)
+	}
+
+	// Создание, смена статуса, неудачный вход и создание admin — четыре записи, по одной на страницу.
+	var actions []string
+	filters := &dto.AuditFilters{Limit: 1}
+	for {
+		page, err := auditSvc.ListAuditEvents(ctx, filters)
+		if err != nil {
+			t.Fatalf("ListAuditEvents: %v", err)
+		}
+		for _, e := range page.Events {
+			actions = append(actions, e.Action)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filters.Cursor = page.NextCursor
+	}
+	if len(actions) != 4 {
+		t.Errorf("expected 4 events across pages, got %v", actions)
+	}
+}
+
+func TestUser_UpdateUserFieldMask(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	created, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "frank@example.com", Phone: "+100", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	updated, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{
+		ID:         created.ID,
+		FullName:   "Frank",
+		Company:    "ACME",
+		UpdateMask: []string{dto.UserFieldFullName, dto.UserFieldCompany},
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Phone != "+100" || updated.FullName != "Frank" {
+		t.Fatalf("fields outside the mask changed: %+v", updated)
+	}
+	// Поле из маски с пустым значением очищается.
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: created.ID, UpdateMask: []string{dto.UserFieldPhone}}); err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	var u model.User
+	if err := conn.Where("id = ?", created.ID).First(&u).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if u.Phone != "" || u.FullName != "Frank" || u.Company != "ACME" {
+		t.Errorf("unexpected profile after clearing phone: phone=%q full_name=%q company=%q", u.Phone, u.FullName, u.Company)
+	}
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: created.ID, UpdateMask: []string{"role"}}); !errors.Is(err, errs.ErrInvalidUpdateMask) {
+		t.Errorf("expected ErrInvalidUpdateMask, got %v", err)
+	}
+
+	// Занятые другим пользователем email и username — ErrUserAlreadyExists, а не ошибка БД.
+	other, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Username: "heidi", Email: "heidi@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: created.ID, Email: other.Email, UpdateMask: []string{dto.UserFieldEmail}}); !errors.Is(err, errs.ErrUserAlreadyExists) {
+		t.Errorf("taken email: expected ErrUserAlreadyExists, got %v", err)
+	}
+	if _, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{ID: created.ID, Username: other.Username, UpdateMask: []string{dto.UserFieldUsername}}); !errors.Is(err, errs.ErrUserAlreadyExists) {
+		t.Errorf("taken username: expected ErrUserAlreadyExists, got %v", err)
+	}
+
+	// Смена своего пароля (UpdateMe) требует верного текущего.
+	self := &dto.UpdateUserRequest{ID: created.ID, Password: "newsecretpassword", UpdateMask: []string{dto.UserFieldPassword}, RequireCurrentPassword: true, CurrentPassword: "wrongpassword"}
+	if _, err := userSvc.UpdateUser(ctx, self); !errors.Is(err, errs.ErrInvalidCurrentPassword) {
+		t.Errorf("wrong current password: expected ErrInvalidCurrentPassword, got %v", err)
+	}
+	self.CurrentPassword = "secretpassword"
+	if _, err := userSvc.UpdateUser(ctx, self); err != nil {
+		t.Errorf("UpdateUser with current password: %v", err)
+	}
+}
+
+func TestUser_VersionConflict(t *testing.T) {
+	conn := testDB(t)
+	userSvc := NewUserService(conn)
+	ctx := context.Background()
+
+	created, err := userSvc.CreateUser(ctx, &dto.CreateUserRequest{Email: "grace@example.com", Password: "secretpassword"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	updated, err := userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{
+		ID:              created.ID,
+		FullName:        "Grace",
+		UpdateMask:      []string{dto.UserFieldFullName},
+		ExpectedVersion: created.Version,
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Version != created.Version+1 {
+		t.Fatalf("version = %d, want %d", updated.Version, created.Version+1)
+	}
+	// Клиент с устаревшим ETag получает конфликт, запись не меняется.
+	_, err = userSvc.UpdateUser(ctx, &dto.UpdateUserRequest{
+		ID:              created.ID,
+		FullName:        "Stale",
+		UpdateMask:      []string{dto.UserFieldFullName},
+		ExpectedVersion: created.Version,
+	})
+	if !errors.Is(err, errs.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+
+	// Копия, прочитанная до параллельной записи, не затирает её.
+	var stale model.User
+	if err := conn.Where("id = ?", created.ID).First(&stale).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, err := NewOperatorService(conn).UpdateAvailability(ctx, created.ID, true); err != nil {
+		t.Fatalf("UpdateAvailability: %v", err)
+	}
+	stale.FullName = "Lost update"
+	if err := saveUser(conn, &stale); !errors.Is(err, errs.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	var u model.User
+	if err := conn.Where("id = ?", created.ID).First(&u).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if u.FullName != "Grace" || !u.IsAvailable {
+		t.Errorf("stale save overwrote row: full_name=%q is_available=%v", u.FullName, u.IsAvailable)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	for in, want := range map[string]string{
+		"alice":  "alice",
+		"100%":   `100\%`,
+		"a_b":    `a\_b`,
+		`c:\dir`: `c:\\dir`,
+		`%_\`:    `\%\_\\`,
+	} {
+		if got := escapeLike(in); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/psds-microservice/user-service/internal/model"
+	"github.com/psds-microservice/user-service/internal/notify"
+)
+
+// Одноразовые токены действий по ссылке из письма (user_tokens): сброс пароля, подтверждение email.
+
+// newActionToken — случайный токен для ссылки (256 бит, base64url).
+func newActionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// issueUserToken гасит прежние неиспользованные токены того же назначения и создаёт новый.
+func issueUserToken(tx *gorm.DB, userID, purpose string, ttl time.Duration) (string, error) {
+	token, err := newActionToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	if err := tx.Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error; err != nil {
+		return "", err
+	}
+	row := &model.UserToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashTokenID(token),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := tx.Create(row).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeUserToken гасит действующий токен (блокировка строки) и возвращает его; nil — токен
+// не найден, уже использован или истёк.
+func consumeUserToken(tx *gorm.DB, token, purpose string) (*model.UserToken, error) {
+	var t model.UserToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", hashTokenID(token), purpose).
+		First(&t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if t.UsedAt != nil || !t.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	if err := tx.Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", t.UserID, purpose).
+		Update("used_at", now).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// actionMessage — письмо со ссылкой (linkTemplate с {token}) или, без шаблона, с самим токеном.
+func actionMessage(kind, to, subject, action, linkTemplate, token string, ttl time.Duration) notify.Message {
+	msg := notify.Message{
+		Kind:    kind,
+		To:      to,
+		Subject: subject,
+		Data:    map[string]string{"token": token},
+	}
+	if linkTemplate != "" {
+		link := strings.ReplaceAll(linkTemplate, "{token}", token)
+		msg.Data["link"] = link
+		msg.Body = fmt.Sprintf("To %s open %s\nThe link is valid for %s.", action, link, ttl)
+	} else {
+		msg.Body = fmt.Sprintf("Use this token to %s: %s\nThe token is valid for %s.", action, token, ttl)
+	}
+	return msg
+}
@@ -1,10 +1,15 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/psds-microservice/user-service/internal/dto"
@@ -15,8 +20,24 @@ const (
 	minPasswordLength = 6
 	maxUsernameLength = 128
 	maxEmailLength    = 256
+	maxFeedbackLength = 2000
+	maxDeviceIDLength = 255
+	maxDeviceType     = 50
+	maxServiceName    = 100
+	maxRoutingField   = 100
+	maxURLLength      = 500
+	maxReasonLength   = 500
+	maxPhoneLength    = 50
+	maxProfileField   = 255
+	maxTimezoneLength = 50
+	maxLanguageLength = 10
 )
 
+var weekdays = map[string]bool{
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true,
+	"friday": true, "saturday": true, "sunday": true,
+}
+
 var (
 	emailRegex = regexp.MustCompile(`^[^@]+@[^@]+\.[^@]+$`)
 )
@@ -43,8 +64,9 @@ func (v *Validator) ValidateRegisterRequest(req *dto.RegisterRequest) error {
 	} else if len(req.Password) < minPasswordLength {
 		errs = append(errs, fmt.Sprintf("password must be at least %d characters", minPasswordLength))
 	}
-	if req.Role != "" && req.Role != constants.RoleClient && req.Role != constants.RoleOperator && req.Role != constants.RoleAdmin {
-		errs = append(errs, "role must be one of: client, operator, admin")
+	// Публичная регистрация: admin назначает только другой admin (CreateUser, SetUserRole) или CLI.
+	if req.Role != "" && req.Role != constants.RoleClient && req.Role != constants.RoleOperator {
+		errs = append(errs, "role must be one of: client, operator")
 	}
 	if len(req.Username) > maxUsernameLength {
 		errs = append(errs, "username too long")
@@ -74,6 +96,51 @@ func (v *Validator) ValidateRefreshRequest(req *dto.RefreshRequest) error {
 	return nil
 }
 
+// ValidatePasswordResetRequest проверяет PasswordResetRequest (POST /api/v1/auth/password/reset).
+func (v *Validator) ValidatePasswordResetRequest(req *dto.PasswordResetRequest) error {
+	return validateEmailOnly(req.Email)
+}
+
+// ValidateResendVerificationRequest проверяет ResendVerificationRequest (POST /api/v1/auth/email/verify/resend).
+func (v *Validator) ValidateResendVerificationRequest(req *dto.ResendVerificationRequest) error {
+	return validateEmailOnly(req.Email)
+}
+
+func validateEmailOnly(email string) error {
+	if strings.TrimSpace(email) == "" {
+		return errors.New("validation: email is required")
+	}
+	if len(email) > maxEmailLength || !emailRegex.MatchString(email) {
+		return errors.New("validation: email format is invalid")
+	}
+	return nil
+}
+
+// ValidatePassword проверяет новый пароль, заданный администратором (users reset-password).
+func (v *Validator) ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("validation: password must be at least %d characters", minPasswordLength)
+	}
+	return nil
+}
+
+// ValidatePasswordResetConfirm проверяет PasswordResetConfirm (POST /api/v1/auth/password/reset/confirm).
+func (v *Validator) ValidatePasswordResetConfirm(req *dto.PasswordResetConfirm) error {
+	var errs []string
+	if strings.TrimSpace(req.Token) == "" {
+		errs = append(errs, "token is required")
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		errs = append(errs, "new_password is required")
+	} else if len(req.NewPassword) < minPasswordLength {
+		errs = append(errs, fmt.Sprintf("new_password must be at least %d characters", minPasswordLength))
+	}
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 // ValidateCreateUserRequest проверяет CreateUserRequest.
 func (v *Validator) ValidateCreateUserRequest(req *dto.CreateUserRequest) error {
 	var errs []string
@@ -89,8 +156,8 @@ func (v *Validator) ValidateCreateUserRequest(req *dto.CreateUserRequest) error
 	} else if len(req.Password) < minPasswordLength {
 		errs = append(errs, fmt.Sprintf("password must be at least %d characters", minPasswordLength))
 	}
-	if req.Role != "" && req.Role != constants.RoleClient && req.Role != constants.RoleOperator && req.Role != constants.RoleAdmin {
-		errs = append(errs, "role must be one of: client, operator, admin")
+	if req.Role != "" && !validRole(req.Role) {
+		errs = append(errs, "role must be one of: client, operator, admin, service")
 	}
 	if len(req.Username) > maxUsernameLength {
 		errs = append(errs, "username too long")
@@ -102,24 +169,99 @@ func (v *Validator) ValidateCreateUserRequest(req *dto.CreateUserRequest) error
 }
 
 // ValidateUpdateUserRequest проверяет UpdateUserRequest (ID и опциональные поля).
-func (v *Validator) ValidateUpdateUserRequest(req *dto.UpdateUserRequest) error {
+// allowed — поля, которые вызывающему разрешено менять (dto.AdminUpdatableFields, dto.SelfUpdatableFields).
+func (v *Validator) ValidateUpdateUserRequest(req *dto.UpdateUserRequest, allowed []string) error {
 	if strings.TrimSpace(req.ID) == "" {
 		return errors.New("validation: id is required")
 	}
 	if _, err := uuid.Parse(req.ID); err != nil {
 		return errors.New("validation: id must be a valid UUID")
 	}
-	if req.Email != "" && !emailRegex.MatchString(req.Email) {
-		return errors.New("validation: email format is invalid")
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, f := range allowed {
+		allowedSet[f] = true
+	}
+	var errs []string
+	for _, field := range req.UpdateMask {
+		if !allowedSet[field] {
+			errs = append(errs, fmt.Sprintf("field %q cannot be updated", field))
+			continue
+		}
+		switch field {
+		case dto.UserFieldUsername:
+			if strings.TrimSpace(req.Username) == "" {
+				errs = append(errs, "username must not be empty")
+			} else if len(req.Username) > maxUsernameLength {
+				errs = append(errs, "username too long")
+			}
+		case dto.UserFieldEmail:
+			if !emailRegex.MatchString(req.Email) || len(req.Email) > maxEmailLength {
+				errs = append(errs, "email format is invalid")
+			}
+		case dto.UserFieldPassword:
+			if len(req.Password) < minPasswordLength {
+				errs = append(errs, fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+			}
+			if req.RequireCurrentPassword && req.CurrentPassword == "" {
+				errs = append(errs, "current_password is required to change password")
+			}
+		case dto.UserFieldStatus:
+			if req.Status != constants.UserStatusActive && req.Status != constants.UserStatusInactive && req.Status != constants.UserStatusBlocked {
+				errs = append(errs, "status must be one of: active, inactive, blocked")
+			}
+		case dto.UserFieldPhone:
+			if len(req.Phone) > maxPhoneLength {
+				errs = append(errs, "phone too long")
+			}
+		case dto.UserFieldFullName, dto.UserFieldCompany, dto.UserFieldSpecialization:
+			if len(profileField(req, field)) > maxProfileField {
+				errs = append(errs, field+" too long")
+			}
+		case dto.UserFieldAvatarURL:
+			if len(req.AvatarURL) > maxURLLength {
+				errs = append(errs, "avatar_url too long")
+			}
+		case dto.UserFieldTimezone:
+			if len(req.Timezone) > maxTimezoneLength {
+				errs = append(errs, "timezone too long")
+			}
+		case dto.UserFieldLanguage:
+			if len(req.Language) > maxLanguageLength {
+				errs = append(errs, "language too long")
+			}
+		}
 	}
-	if req.Status != "" && req.Status != constants.UserStatusActive && req.Status != constants.UserStatusInactive && req.Status != constants.UserStatusBlocked {
-		return errors.New("validation: status must be one of: active, inactive, blocked")
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
 	}
-	if len(req.Username) > maxUsernameLength {
-		return errors.New("validation: username too long")
+	return nil
+}
+
+func profileField(req *dto.UpdateUserRequest, field string) string {
+	switch field {
+	case dto.UserFieldFullName:
+		return req.FullName
+	case dto.UserFieldCompany:
+		return req.Company
+	default:
+		return req.Specialization
 	}
-	if req.Password != "" && len(req.Password) < minPasswordLength {
-		return fmt.Errorf("validation: password must be at least %d characters", minPasswordLength)
+}
+
+func validRole(role string) bool {
+	return role == constants.RoleClient || role == constants.RoleOperator || role == constants.RoleAdmin || role == constants.RoleService
+}
+
+// ValidateSetUserRoleRequest проверяет SetUserRoleRequest (POST /api/v1/users/{id}/role).
+func (v *Validator) ValidateSetUserRoleRequest(req *dto.SetUserRoleRequest) error {
+	if _, err := uuid.Parse(req.ID); err != nil {
+		return errors.New("validation: id must be a valid UUID")
+	}
+	if !validRole(req.Role) {
+		return errors.New("validation: role must be one of: client, operator, admin, service")
+	}
+	if len(req.Reason) > maxReasonLength {
+		return errors.New("validation: reason too long")
 	}
 	return nil
 }
@@ -156,3 +298,241 @@ func (v *Validator) ValidateSessionValidateRequest(userID, sessionExternalID str
 	}
 	return nil
 }
+
+// ValidateEndSessionRequest проверяет запрос на завершение сессии (user_id, session_id).
+func (v *Validator) ValidateEndSessionRequest(userID, sessionID string) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return errors.New("validation: id must be a valid UUID")
+	}
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return errors.New("validation: session_id must be a valid UUID")
+	}
+	return nil
+}
+
+// ValidateRateConsultationRequest проверяет оценку консультации (session_id, rating 1..5, feedback).
+func (v *Validator) ValidateRateConsultationRequest(req *dto.RateConsultationRequest) error {
+	if _, err := uuid.Parse(req.SessionID); err != nil {
+		return errors.New("validation: session_id must be a valid UUID")
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		return errors.New("validation: rating must be between 1 and 5")
+	}
+	if utf8.RuneCountInString(req.Feedback) > maxFeedbackLength {
+		return errors.New("validation: feedback too long")
+	}
+	return nil
+}
+
+// ValidateRegisterDeviceRequest проверяет RegisterDeviceRequest (POST /api/v1/users/me/devices).
+func (v *Validator) ValidateRegisterDeviceRequest(req *dto.RegisterDeviceRequest) error {
+	var errs []string
+	if strings.TrimSpace(req.DeviceID) == "" {
+		errs = append(errs, "device_id is required")
+	} else if len(req.DeviceID) > maxDeviceIDLength {
+		errs = append(errs, "device_id too long")
+	}
+	if strings.TrimSpace(req.DeviceType) == "" {
+		errs = append(errs, "device_type is required")
+	} else if len(req.DeviceType) > maxDeviceType {
+		errs = append(errs, "device_type too long")
+	}
+	if req.IPAddress != "" && net.ParseIP(req.IPAddress) == nil {
+		errs = append(errs, "ip_address format is invalid")
+	}
+	if req.BandwidthLimit < 0 {
+		errs = append(errs, "bandwidth_limit must not be negative")
+	}
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateConnectDeviceRequest проверяет ConnectDeviceRequest (POST /api/v1/devices/connect).
+func (v *Validator) ValidateConnectDeviceRequest(req *dto.ConnectDeviceRequest) error {
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		return errors.New("validation: user_id must be a valid UUID")
+	}
+	if strings.TrimSpace(req.DeviceID) == "" {
+		return errors.New("validation: device_id is required")
+	}
+	if strings.TrimSpace(req.ConnectionID) == "" {
+		return errors.New("validation: connection_id is required")
+	}
+	if req.IPAddress != "" && net.ParseIP(req.IPAddress) == nil {
+		return errors.New("validation: ip_address format is invalid")
+	}
+	return nil
+}
+
+// ValidateServiceRouteRequest проверяет запись user_services: при создании (ID пуст) — все поля,
+// при обновлении — только поля из UpdateMask.
+func (v *Validator) ValidateServiceRouteRequest(req *dto.UserServiceRouteRequest) error {
+	var errs []string
+	if _, err := uuid.Parse(req.UserID); err != nil {
+		errs = append(errs, "user_id must be a valid UUID")
+	}
+	fields := dto.RouteFields
+	if req.ID != "" {
+		fields = req.UpdateMask
+	}
+	for _, field := range fields {
+		if msg := routeFieldError(req, field); msg != "" {
+			errs = append(errs, msg)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// routeFieldError проверяет одно поле записи user_services; пустая строка — поле корректно.
+func routeFieldError(req *dto.UserServiceRouteRequest, field string) string {
+	switch field {
+	case dto.RouteFieldServiceName:
+		if strings.TrimSpace(req.ServiceName) == "" {
+			return "service_name is required"
+		}
+		if len(req.ServiceName) > maxServiceName {
+			return "service_name too long"
+		}
+	case dto.RouteFieldServiceType:
+		for _, t := range constants.ServiceTypes {
+			if req.ServiceType == t {
+				return ""
+			}
+		}
+		return "service_type must be one of: " + strings.Join(constants.ServiceTypes, ", ")
+	case dto.RouteFieldBaseURL:
+		if strings.TrimSpace(req.BaseURL) == "" {
+			return "base_url is required"
+		}
+		if len(req.BaseURL) > maxURLLength {
+			return "base_url too long"
+		}
+		if u, err := url.Parse(req.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return "base_url must be an absolute URL"
+		}
+	case dto.RouteFieldPort:
+		if req.Port < 1 || req.Port > 65535 {
+			return "port must be between 1 and 65535"
+		}
+	case dto.RouteFieldPriority:
+		if req.Priority != 0 && (req.Priority < 1 || req.Priority > 10) {
+			return "priority must be between 1 and 10"
+		}
+	case dto.RouteFieldMaxBitrate:
+		if req.MaxBitrate < 0 {
+			return "max_bitrate must not be negative"
+		}
+	case dto.RouteFieldMaxConnections:
+		if req.MaxConnections < 0 {
+			return "max_connections must not be negative"
+		}
+	case dto.RouteFieldRoutingKey:
+		if len(req.RoutingKey) > maxRoutingField {
+			return "routing_key must be at most 100 characters"
+		}
+	case dto.RouteFieldQueueName:
+		if len(req.QueueName) > maxRoutingField {
+			return "queue_name must be at most 100 characters"
+		}
+	case dto.RouteFieldTopicName:
+		if len(req.TopicName) > maxRoutingField {
+			return "topic_name must be at most 100 characters"
+		}
+	case dto.RouteFieldEnabledButtons:
+		for _, b := range req.EnabledButtons {
+			if strings.TrimSpace(b) == "" {
+				return "enabled_buttons must not contain empty values"
+			}
+		}
+	case dto.RouteFieldSchedule:
+		if req.Schedule == nil {
+			return ""
+		}
+		for _, d := range req.Schedule.Weekdays {
+			if !weekdays[strings.ToLower(d)] {
+				return "schedule.weekdays must contain only monday..sunday"
+			}
+		}
+		for _, t := range []string{req.Schedule.StartTime, req.Schedule.EndTime} {
+			if t == "" {
+				continue
+			}
+			if _, err := time.Parse("15:04", t); err != nil {
+				return "schedule.start_time and schedule.end_time must be HH:MM"
+			}
+		}
+	case dto.RouteFieldParameters:
+		if len(req.Parameters) > 0 {
+			var obj map[string]interface{}
+			if err := json.Unmarshal(req.Parameters, &obj); err != nil {
+				return "parameters must be a JSON object"
+			}
+		}
+	case dto.RouteFieldAPIEndpoint, dto.RouteFieldUseSSL, dto.RouteFieldSSLCertificatePath, dto.RouteFieldIsActive:
+	default:
+		return fmt.Sprintf("field %q cannot be updated", field)
+	}
+	return ""
+}
+
+// ValidateAuditFilters проверяет фильтры журнала аудита (GET /api/v1/audit-events).
+func (v *Validator) ValidateAuditFilters(f *dto.AuditFilters) error {
+	var errs []string
+	if f.ActorID != "" {
+		if _, err := uuid.Parse(f.ActorID); err != nil {
+			errs = append(errs, "actor_id must be a valid UUID")
+		}
+	}
+	if f.TargetID != "" {
+		if _, err := uuid.Parse(f.TargetID); err != nil {
+			errs = append(errs, "target_id must be a valid UUID")
+		}
+	}
+	if len(f.Action) > 64 {
+		errs = append(errs, "action must be at most 64 characters")
+	}
+	if f.Limit < 0 || f.Limit > 100 {
+		errs = append(errs, "limit must be between 0 and 100")
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		errs = append(errs, "from must be before to")
+	}
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateUserFilters проверяет фильтры списка пользователей (GET /api/v1/users).
+func (v *Validator) ValidateUserFilters(f *dto.UserFilters) error {
+	var errs []string
+	if f.Status != "" && f.Status != constants.UserStatusActive && f.Status != constants.UserStatusInactive && f.Status != constants.UserStatusBlocked {
+		errs = append(errs, "status must be one of: active, inactive, blocked")
+	}
+	if f.Role != "" && !validRole(f.Role) {
+		errs = append(errs, "role must be one of: client, operator, admin, service")
+	}
+	if f.OperatorStatus != "" && f.OperatorStatus != constants.OperatorStatusPending && f.OperatorStatus != constants.OperatorStatusVerified && f.OperatorStatus != constants.OperatorStatusBlocked {
+		errs = append(errs, "operator_status must be one of: pending, verified, blocked")
+	}
+	switch f.Sort {
+	case "", "created_at", "-created_at", "username", "-username":
+	default:
+		errs = append(errs, "sort must be one of: created_at, -created_at, username, -username")
+	}
+	if f.Limit < 0 || f.Limit > 100 {
+		errs = append(errs, "limit must be between 0 and 100")
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		errs = append(errs, "created_after must be before created_before")
+	}
+	if len(errs) > 0 {
+		return errors.New("validation: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
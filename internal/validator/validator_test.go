@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/psds-microservice/user-service/internal/dto"
+)
+
+func TestValidateServiceRouteRequest(t *testing.T) {
+	v := New()
+	base := func() *dto.UserServiceRouteRequest {
+		return &dto.UserServiceRouteRequest{
+			UserID:      "4f0c1a52-8d7e-4a6b-9d2a-2f1f5d3c9b10",
+			ServiceName: "stream",
+			ServiceType: "streaming",
+			BaseURL:     "http://stream:8080",
+			Port:        8080,
+		}
+	}
+	if err := v.ValidateServiceRouteRequest(base()); err != nil {
+		t.Fatalf("Expected valid create, got %v", err)
+	}
+
+	req := base()
+	req.ServiceType = "video"
+	if err := v.ValidateServiceRouteRequest(req); err == nil || !strings.Contains(err.Error(), "service_type") {
+		t.Fatalf("Expected service_type error, got %v", err)
+	}
+
+	// Обновление проверяет только поля из маски: пустой base_url вне маски допустим.
+	upd := &dto.UserServiceRouteRequest{ID: "route", UserID: base().UserID, ServiceType: "video", UpdateMask: []string{dto.RouteFieldPriority}}
+	if err := v.ValidateServiceRouteRequest(upd); err != nil {
+		t.Fatalf("Expected valid partial update, got %v", err)
+	}
+	upd.UpdateMask = []string{dto.RouteFieldServiceType}
+	if err := v.ValidateServiceRouteRequest(upd); err == nil || !strings.Contains(err.Error(), "service_type") {
+		t.Fatalf("Expected service_type error on update, got %v", err)
+	}
+	upd.UpdateMask = []string{"user_id"}
+	if err := v.ValidateServiceRouteRequest(upd); err == nil {
+		t.Fatal("Expected error for unknown mask field")
+	}
+}
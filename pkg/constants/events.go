@@ -0,0 +1,19 @@
+package constants
+
+// События session-manager, на которые подписан user-service (routing key в exchange session-manager).
+const (
+	SessionEventStarted           = "session.started"
+	SessionEventParticipantJoined = "participant.joined"
+	SessionEventParticipantLeft   = "participant.left"
+	SessionEventClosed            = "session.closed"
+)
+
+// SessionEvents — routing key, которые привязываются к очереди consumer.
+var SessionEvents = []string{SessionEventStarted, SessionEventParticipantJoined, SessionEventParticipantLeft, SessionEventClosed}
+
+// Роли участника сессии (user_sessions.participant_role, CHECK в миграции 000004).
+const (
+	ParticipantRoleHost     = "host"
+	ParticipantRoleOperator = "operator"
+	ParticipantRoleViewer   = "viewer"
+)
@@ -9,11 +9,15 @@ const (
 	PermConsultationJoin = "consultation:join"
 	PermOperatorVerify   = "operator:verify"
 	PermOperatorStats    = "operator:stats"
+	PermUserManage       = "user:manage"    // чтение и изменение чужих учётных записей
+	PermSessionManage    = "session:manage" // служебные вызовы session-manager и шлюзов
+	PermAuditRead        = "audit:read"     // чтение журнала аудита
 )
 
 // PermissionsByRole — маппинг ролей на списки разрешений (домен user-service).
 var PermissionsByRole = map[string][]string{
 	RoleClient:   {PermStreamCreate, PermStreamJoin, PermChatSend, PermFileUpload},
 	RoleOperator: {PermStreamJoin, PermChatSend, PermFileUpload, PermConsultationJoin},
-	RoleAdmin:    {PermStreamCreate, PermStreamJoin, PermChatSend, PermFileUpload, PermConsultationJoin, PermOperatorVerify, PermOperatorStats},
+	RoleAdmin:    {PermStreamCreate, PermStreamJoin, PermChatSend, PermFileUpload, PermConsultationJoin, PermOperatorVerify, PermOperatorStats, PermUserManage, PermSessionManage, PermAuditRead},
+	RoleService:  {PermSessionManage},
 }
@@ -5,6 +5,15 @@ const (
 	RoleClient   = "client"
 	RoleOperator = "operator"
 	RoleAdmin    = "admin"
+	RoleService  = "service" // учётная запись сервиса (session-manager, WS-шлюз): только session:manage
+)
+
+// Источник назначения роли (audit_events.source у user.create и user.role_change)
+const (
+	RoleSourceRegister  = "register"  // публичная регистрация: только client или operator (pending)
+	RoleSourceAdmin     = "admin"     // CreateUser/SetUserRole администратором
+	RoleSourceBootstrap = "bootstrap" // первый admin из CLI (users create-admin)
+	RoleSourceCLI       = "cli"       // прочие команды CLI (users create-admin --force, users set-role)
 )
 
 // Статусы оператора (operator_status)
@@ -20,3 +29,16 @@ const (
 	UserStatusInactive = "inactive"
 	UserStatusBlocked  = "blocked"
 )
+
+// Назначение одноразовых токенов (user_tokens.purpose)
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+)
+
+// Политика для пользователей с неподтверждённым email (EMAIL_VERIFICATION)
+const (
+	EmailVerificationOff      = "off"      // без ограничений
+	EmailVerificationLimited  = "limited"  // вход разрешён, access-токен ограничен (claim limited)
+	EmailVerificationRequired = "required" // вход и выдача токенов запрещены до подтверждения
+)
@@ -0,0 +1,16 @@
+package constants
+
+// Типы сервисов пользователя (user_services.service_type, CHECK в миграции 000002).
+const (
+	ServiceTypeStreaming  = "streaming"
+	ServiceTypeRecording  = "recording"
+	ServiceTypeMonitoring = "monitoring"
+	ServiceTypeAnalytics  = "analytics"
+	ServiceTypeCustom     = "custom"
+)
+
+// ServiceTypes — допустимые значения service_type.
+var ServiceTypes = []string{ServiceTypeStreaming, ServiceTypeRecording, ServiceTypeMonitoring, ServiceTypeAnalytics, ServiceTypeCustom}
+
+// DefaultEnabledButtons — кнопки по умолчанию (как DEFAULT enabled_buttons в миграции 000002).
+var DefaultEnabledButtons = []string{"button1", "button2", "button3"}
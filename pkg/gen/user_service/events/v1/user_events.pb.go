@@ -0,0 +1,1104 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: user_events.proto
+
+// События жизненного цикла пользователей user-service (outbox → RabbitMQ).
+// Версия схемы — в имени пакета: несовместимые изменения оформляются новым пакетом (v2),
+// в v1 поля только добавляются.
+
+package eventsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope — тело сообщения в брокере. event_type совпадает с routing key и AMQP type,
+// event_id — с AMQP message_id (идемпотентность потребителей).
+type Envelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`              // user.created, user.updated, ...
+	SchemaVersion int32                  `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"` // 1
+	AggregateId   string                 `protobuf:"bytes,4,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`        // user_id
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_UserCreated
+	//	*Envelope_UserUpdated
+	//	*Envelope_UserDeleted
+	//	*Envelope_UserStatusChanged
+	//	*Envelope_OperatorVerified
+	//	*Envelope_OperatorAvailabilityChanged
+	//	*Envelope_UserPresenceChanged
+	//	*Envelope_SessionStarted
+	//	*Envelope_UserRestored
+	//	*Envelope_UserPurged
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_user_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Envelope) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Envelope) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserCreated() *UserCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserCreated); ok {
+			return x.UserCreated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserUpdated() *UserUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserUpdated); ok {
+			return x.UserUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserDeleted() *UserDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserDeleted); ok {
+			return x.UserDeleted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserStatusChanged() *UserStatusChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserStatusChanged); ok {
+			return x.UserStatusChanged
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetOperatorVerified() *OperatorVerified {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_OperatorVerified); ok {
+			return x.OperatorVerified
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetOperatorAvailabilityChanged() *OperatorAvailabilityChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_OperatorAvailabilityChanged); ok {
+			return x.OperatorAvailabilityChanged
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserPresenceChanged() *UserPresenceChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserPresenceChanged); ok {
+			return x.UserPresenceChanged
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetSessionStarted() *SessionStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_SessionStarted); ok {
+			return x.SessionStarted
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserRestored() *UserRestored {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserRestored); ok {
+			return x.UserRestored
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetUserPurged() *UserPurged {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_UserPurged); ok {
+			return x.UserPurged
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_UserCreated struct {
+	UserCreated *UserCreated `protobuf:"bytes,10,opt,name=user_created,json=userCreated,proto3,oneof"`
+}
+
+type Envelope_UserUpdated struct {
+	UserUpdated *UserUpdated `protobuf:"bytes,11,opt,name=user_updated,json=userUpdated,proto3,oneof"`
+}
+
+type Envelope_UserDeleted struct {
+	UserDeleted *UserDeleted `protobuf:"bytes,12,opt,name=user_deleted,json=userDeleted,proto3,oneof"`
+}
+
+type Envelope_UserStatusChanged struct {
+	UserStatusChanged *UserStatusChanged `protobuf:"bytes,13,opt,name=user_status_changed,json=userStatusChanged,proto3,oneof"`
+}
+
+type Envelope_OperatorVerified struct {
+	OperatorVerified *OperatorVerified `protobuf:"bytes,14,opt,name=operator_verified,json=operatorVerified,proto3,oneof"`
+}
+
+type Envelope_OperatorAvailabilityChanged struct {
+	OperatorAvailabilityChanged *OperatorAvailabilityChanged `protobuf:"bytes,15,opt,name=operator_availability_changed,json=operatorAvailabilityChanged,proto3,oneof"`
+}
+
+type Envelope_UserPresenceChanged struct {
+	UserPresenceChanged *UserPresenceChanged `protobuf:"bytes,16,opt,name=user_presence_changed,json=userPresenceChanged,proto3,oneof"`
+}
+
+type Envelope_SessionStarted struct {
+	SessionStarted *SessionStarted `protobuf:"bytes,17,opt,name=session_started,json=sessionStarted,proto3,oneof"`
+}
+
+type Envelope_UserRestored struct {
+	UserRestored *UserRestored `protobuf:"bytes,18,opt,name=user_restored,json=userRestored,proto3,oneof"`
+}
+
+type Envelope_UserPurged struct {
+	UserPurged *UserPurged `protobuf:"bytes,19,opt,name=user_purged,json=userPurged,proto3,oneof"`
+}
+
+func (*Envelope_UserCreated) isEnvelope_Payload() {}
+
+func (*Envelope_UserUpdated) isEnvelope_Payload() {}
+
+func (*Envelope_UserDeleted) isEnvelope_Payload() {}
+
+func (*Envelope_UserStatusChanged) isEnvelope_Payload() {}
+
+func (*Envelope_OperatorVerified) isEnvelope_Payload() {}
+
+func (*Envelope_OperatorAvailabilityChanged) isEnvelope_Payload() {}
+
+func (*Envelope_UserPresenceChanged) isEnvelope_Payload() {}
+
+func (*Envelope_SessionStarted) isEnvelope_Payload() {}
+
+func (*Envelope_UserRestored) isEnvelope_Payload() {}
+
+func (*Envelope_UserPurged) isEnvelope_Payload() {}
+
+type UserCreated struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role           string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	OperatorStatus string                 `protobuf:"bytes,6,opt,name=operator_status,json=operatorStatus,proto3" json:"operator_status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserCreated) Reset() {
+	*x = UserCreated{}
+	mi := &file_user_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCreated) ProtoMessage() {}
+
+func (x *UserCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCreated.ProtoReflect.Descriptor instead.
+func (*UserCreated) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserCreated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserCreated) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserCreated) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserCreated) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserCreated) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserCreated) GetOperatorStatus() string {
+	if x != nil {
+		return x.OperatorStatus
+	}
+	return ""
+}
+
+type UserUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"` // username, email, email_verified, phone, status, role, password
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUpdated) Reset() {
+	*x = UserUpdated{}
+	mi := &file_user_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUpdated) ProtoMessage() {}
+
+func (x *UserUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUpdated.ProtoReflect.Descriptor instead.
+func (*UserUpdated) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserUpdated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserUpdated) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *UserUpdated) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserUpdated) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserUpdated) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserUpdated) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// UserDeleted — мягкое удаление: пользователь скрыт, данные хранятся до UserPurged.
+type UserDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeleted) Reset() {
+	*x = UserDeleted{}
+	mi := &file_user_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeleted) ProtoMessage() {}
+
+func (x *UserDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeleted.ProtoReflect.Descriptor instead.
+func (*UserDeleted) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserDeleted) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UserRestored — отмена мягкого удаления.
+type UserRestored struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRestored) Reset() {
+	*x = UserRestored{}
+	mi := &file_user_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRestored) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRestored) ProtoMessage() {}
+
+func (x *UserRestored) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRestored.ProtoReflect.Descriptor instead.
+func (*UserRestored) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserRestored) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserRestored) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserRestored) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserRestored) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserRestored) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// UserPurged — персональные данные обезличены; потребители удаляют свои копии PII пользователя.
+type UserPurged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPurged) Reset() {
+	*x = UserPurged{}
+	mi := &file_user_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPurged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPurged) ProtoMessage() {}
+
+func (x *UserPurged) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPurged.ProtoReflect.Descriptor instead.
+func (*UserPurged) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserPurged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UserStatusChanged — смена users.status (active/blocked/...), в дополнение к UserUpdated.
+type UserStatusChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldStatus     string                 `protobuf:"bytes,2,opt,name=old_status,json=oldStatus,proto3" json:"old_status,omitempty"`
+	NewStatus     string                 `protobuf:"bytes,3,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStatusChanged) Reset() {
+	*x = UserStatusChanged{}
+	mi := &file_user_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatusChanged) ProtoMessage() {}
+
+func (x *UserStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatusChanged.ProtoReflect.Descriptor instead.
+func (*UserStatusChanged) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserStatusChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserStatusChanged) GetOldStatus() string {
+	if x != nil {
+		return x.OldStatus
+	}
+	return ""
+}
+
+func (x *UserStatusChanged) GetNewStatus() string {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ""
+}
+
+type OperatorVerified struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldStatus     string                 `protobuf:"bytes,2,opt,name=old_status,json=oldStatus,proto3" json:"old_status,omitempty"`
+	NewStatus     string                 `protobuf:"bytes,3,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"` // pending, verified, blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorVerified) Reset() {
+	*x = OperatorVerified{}
+	mi := &file_user_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorVerified) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorVerified) ProtoMessage() {}
+
+func (x *OperatorVerified) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorVerified.ProtoReflect.Descriptor instead.
+func (*OperatorVerified) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OperatorVerified) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OperatorVerified) GetOldStatus() string {
+	if x != nil {
+		return x.OldStatus
+	}
+	return ""
+}
+
+func (x *OperatorVerified) GetNewStatus() string {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ""
+}
+
+type OperatorAvailabilityChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsAvailable   bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	Automatic     bool                   `protobuf:"varint,3,opt,name=automatic,proto3" json:"automatic,omitempty"` // true — снято автоматически при достижении max_sessions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorAvailabilityChanged) Reset() {
+	*x = OperatorAvailabilityChanged{}
+	mi := &file_user_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorAvailabilityChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorAvailabilityChanged) ProtoMessage() {}
+
+func (x *OperatorAvailabilityChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorAvailabilityChanged.ProtoReflect.Descriptor instead.
+func (*OperatorAvailabilityChanged) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OperatorAvailabilityChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OperatorAvailabilityChanged) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *OperatorAvailabilityChanged) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+type UserPresenceChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsOnline      bool                   `protobuf:"varint,2,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPresenceChanged) Reset() {
+	*x = UserPresenceChanged{}
+	mi := &file_user_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPresenceChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPresenceChanged) ProtoMessage() {}
+
+func (x *UserPresenceChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPresenceChanged.ProtoReflect.Descriptor instead.
+func (*UserPresenceChanged) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UserPresenceChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserPresenceChanged) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+func (x *UserPresenceChanged) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+type SessionStarted struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId         string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SessionType       string                 `protobuf:"bytes,3,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`
+	SessionExternalId string                 `protobuf:"bytes,4,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
+	ParticipantRole   string                 `protobuf:"bytes,5,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
+	JoinedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SessionStarted) Reset() {
+	*x = SessionStarted{}
+	mi := &file_user_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStarted) ProtoMessage() {}
+
+func (x *SessionStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_user_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStarted.ProtoReflect.Descriptor instead.
+func (*SessionStarted) Descriptor() ([]byte, []int) {
+	return file_user_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SessionStarted) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetSessionType() string {
+	if x != nil {
+		return x.SessionType
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetSessionExternalId() string {
+	if x != nil {
+		return x.SessionExternalId
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetParticipantRole() string {
+	if x != nil {
+		return x.ParticipantRole
+	}
+	return ""
+}
+
+func (x *SessionStarted) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+var File_user_events_proto protoreflect.FileDescriptor
+
+const file_user_events_proto_rawDesc = "" +
+	"\n" +
+	"\x11user_events.proto\x12\x16user_service.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaf\b\n" +
+	"\bEnvelope\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x12!\n" +
+	"\faggregate_id\x18\x04 \x01(\tR\vaggregateId\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12H\n" +
+	"\fuser_created\x18\n" +
+	" \x01(\v2#.user_service.events.v1.UserCreatedH\x00R\vuserCreated\x12H\n" +
+	"\fuser_updated\x18\v \x01(\v2#.user_service.events.v1.UserUpdatedH\x00R\vuserUpdated\x12H\n" +
+	"\fuser_deleted\x18\f \x01(\v2#.user_service.events.v1.UserDeletedH\x00R\vuserDeleted\x12[\n" +
+	"\x13user_status_changed\x18\r \x01(\v2).user_service.events.v1.UserStatusChangedH\x00R\x11userStatusChanged\x12W\n" +
+	"\x11operator_verified\x18\x0e \x01(\v2(.user_service.events.v1.OperatorVerifiedH\x00R\x10operatorVerified\x12y\n" +
+	"\x1doperator_availability_changed\x18\x0f \x01(\v23.user_service.events.v1.OperatorAvailabilityChangedH\x00R\x1boperatorAvailabilityChanged\x12a\n" +
+	"\x15user_presence_changed\x18\x10 \x01(\v2+.user_service.events.v1.UserPresenceChangedH\x00R\x13userPresenceChanged\x12Q\n" +
+	"\x0fsession_started\x18\x11 \x01(\v2&.user_service.events.v1.SessionStartedH\x00R\x0esessionStarted\x12K\n" +
+	"\ruser_restored\x18\x12 \x01(\v2$.user_service.events.v1.UserRestoredH\x00R\fuserRestored\x12E\n" +
+	"\vuser_purged\x18\x13 \x01(\v2\".user_service.events.v1.UserPurgedH\x00R\n" +
+	"userPurgedB\t\n" +
+	"\apayload\"\xad\x01\n" +
+	"\vUserCreated\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12'\n" +
+	"\x0foperator_status\x18\x06 \x01(\tR\x0eoperatorStatus\"\xab\x01\n" +
+	"\vUserUpdated\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\"&\n" +
+	"\vUserDeleted\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x85\x01\n" +
+	"\fUserRestored\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"%\n" +
+	"\n" +
+	"UserPurged\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"j\n" +
+	"\x11UserStatusChanged\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"old_status\x18\x02 \x01(\tR\toldStatus\x12\x1d\n" +
+	"\n" +
+	"new_status\x18\x03 \x01(\tR\tnewStatus\"i\n" +
+	"\x10OperatorVerified\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"old_status\x18\x02 \x01(\tR\toldStatus\x12\x1d\n" +
+	"\n" +
+	"new_status\x18\x03 \x01(\tR\tnewStatus\"w\n" +
+	"\x1bOperatorAvailabilityChanged\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fis_available\x18\x02 \x01(\bR\visAvailable\x12\x1c\n" +
+	"\tautomatic\x18\x03 \x01(\bR\tautomatic\"\x89\x01\n" +
+	"\x13UserPresenceChanged\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_online\x18\x02 \x01(\bR\bisOnline\x12<\n" +
+	"\flast_seen_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"\xff\x01\n" +
+	"\x0eSessionStarted\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12!\n" +
+	"\fsession_type\x18\x03 \x01(\tR\vsessionType\x12.\n" +
+	"\x13session_external_id\x18\x04 \x01(\tR\x11sessionExternalId\x12)\n" +
+	"\x10participant_role\x18\x05 \x01(\tR\x0fparticipantRole\x127\n" +
+	"\tjoined_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAtBSZQgithub.com/psds-microservice/user-service/pkg/gen/user_service/events/v1;eventsv1b\x06proto3"
+
+var (
+	file_user_events_proto_rawDescOnce sync.Once
+	file_user_events_proto_rawDescData []byte
+)
+
+func file_user_events_proto_rawDescGZIP() []byte {
+	file_user_events_proto_rawDescOnce.Do(func() {
+		file_user_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_user_events_proto_rawDesc), len(file_user_events_proto_rawDesc)))
+	})
+	return file_user_events_proto_rawDescData
+}
+
+var file_user_events_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_user_events_proto_goTypes = []any{
+	(*Envelope)(nil),                    // 0: user_service.events.v1.Envelope
+	(*UserCreated)(nil),                 // 1: user_service.events.v1.UserCreated
+	(*UserUpdated)(nil),                 // 2: user_service.events.v1.UserUpdated
+	(*UserDeleted)(nil),                 // 3: user_service.events.v1.UserDeleted
+	(*UserRestored)(nil),                // 4: user_service.events.v1.UserRestored
+	(*UserPurged)(nil),                  // 5: user_service.events.v1.UserPurged
+	(*UserStatusChanged)(nil),           // 6: user_service.events.v1.UserStatusChanged
+	(*OperatorVerified)(nil),            // 7: user_service.events.v1.OperatorVerified
+	(*OperatorAvailabilityChanged)(nil), // 8: user_service.events.v1.OperatorAvailabilityChanged
+	(*UserPresenceChanged)(nil),         // 9: user_service.events.v1.UserPresenceChanged
+	(*SessionStarted)(nil),              // 10: user_service.events.v1.SessionStarted
+	(*timestamppb.Timestamp)(nil),       // 11: google.protobuf.Timestamp
+}
+var file_user_events_proto_depIdxs = []int32{
+	11, // 0: user_service.events.v1.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: user_service.events.v1.Envelope.user_created:type_name -> user_service.events.v1.UserCreated
+	2,  // 2: user_service.events.v1.Envelope.user_updated:type_name -> user_service.events.v1.UserUpdated
+	3,  // 3: user_service.events.v1.Envelope.user_deleted:type_name -> user_service.events.v1.UserDeleted
+	6,  // 4: user_service.events.v1.Envelope.user_status_changed:type_name -> user_service.events.v1.UserStatusChanged
+	7,  // 5: user_service.events.v1.Envelope.operator_verified:type_name -> user_service.events.v1.OperatorVerified
+	8,  // 6: user_service.events.v1.Envelope.operator_availability_changed:type_name -> user_service.events.v1.OperatorAvailabilityChanged
+	9,  // 7: user_service.events.v1.Envelope.user_presence_changed:type_name -> user_service.events.v1.UserPresenceChanged
+	10, // 8: user_service.events.v1.Envelope.session_started:type_name -> user_service.events.v1.SessionStarted
+	4,  // 9: user_service.events.v1.Envelope.user_restored:type_name -> user_service.events.v1.UserRestored
+	5,  // 10: user_service.events.v1.Envelope.user_purged:type_name -> user_service.events.v1.UserPurged
+	11, // 11: user_service.events.v1.UserPresenceChanged.last_seen_at:type_name -> google.protobuf.Timestamp
+	11, // 12: user_service.events.v1.SessionStarted.joined_at:type_name -> google.protobuf.Timestamp
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_user_events_proto_init() }
+func file_user_events_proto_init() {
+	if File_user_events_proto != nil {
+		return
+	}
+	file_user_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_UserCreated)(nil),
+		(*Envelope_UserUpdated)(nil),
+		(*Envelope_UserDeleted)(nil),
+		(*Envelope_UserStatusChanged)(nil),
+		(*Envelope_OperatorVerified)(nil),
+		(*Envelope_OperatorAvailabilityChanged)(nil),
+		(*Envelope_UserPresenceChanged)(nil),
+		(*Envelope_SessionStarted)(nil),
+		(*Envelope_UserRestored)(nil),
+		(*Envelope_UserPurged)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_events_proto_rawDesc), len(file_user_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_user_events_proto_goTypes,
+		DependencyIndexes: file_user_events_proto_depIdxs,
+		MessageInfos:      file_user_events_proto_msgTypes,
+	}.Build()
+	File_user_events_proto = out.File
+	file_user_events_proto_goTypes = nil
+	file_user_events_proto_depIdxs = nil
+}
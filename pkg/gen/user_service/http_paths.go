@@ -12,18 +12,43 @@ const (
 	PathCreateUser   = "/users"
 	MethodCreateUser = "POST"
 
+	// ListUsers
+	PathListUsers   = "/users"
+	MethodListUsers = "GET"
+
 	// GetUser
 	PathGetUser   = "/users/{id}"
 	MethodGetUser = "GET"
 
+	// GetUserCard
+	PathGetUserCard   = "/users/{id}/card"
+	MethodGetUserCard = "GET"
+
 	// UpdateUser
-	PathUpdateUser   = "/users/{id}"
-	MethodUpdateUser = "PUT"
+	PathUpdateUser        = "/users/{id}"
+	MethodUpdateUser      = "PUT"
+	MethodUpdateUserPatch = "PATCH"
 
 	// DeleteUser
 	PathDeleteUser   = "/users/{id}"
 	MethodDeleteUser = "DELETE"
 
+	// RestoreUser
+	PathRestoreUser   = "/users/{id}/restore"
+	MethodRestoreUser = "POST"
+
+	// PurgeUser
+	PathPurgeUser   = "/users/{id}/purge"
+	MethodPurgeUser = "POST"
+
+	// ListAuditEvents
+	PathListAuditEvents   = "/audit-events"
+	MethodListAuditEvents = "GET"
+
+	// SetUserRole
+	PathSetUserRole   = "/users/{id}/role"
+	MethodSetUserRole = "POST"
+
 	// Login
 	PathLogin   = "/auth/login"
 	MethodLogin = "POST"
@@ -56,13 +81,46 @@ const (
 	PathLogout   = "/auth/logout"
 	MethodLogout = "POST"
 
+	// LoginVerifyMFA
+	PathLoginVerifyMFA   = "/auth/login/mfa"
+	MethodLoginVerifyMFA = "POST"
+
+	// RequestPasswordReset
+	PathRequestPasswordReset   = "/auth/password/reset"
+	MethodRequestPasswordReset = "POST"
+
+	// ConfirmPasswordReset
+	PathConfirmPasswordReset   = "/auth/password/reset/confirm"
+	MethodConfirmPasswordReset = "POST"
+
+	// VerifyEmail
+	PathVerifyEmail   = "/auth/email/verify"
+	MethodVerifyEmail = "POST"
+
+	// ResendVerification
+	PathResendVerification   = "/auth/email/verify/resend"
+	MethodResendVerification = "POST"
+
+	// EnrollMFA
+	PathEnrollMFA   = "/users/me/mfa/enroll"
+	MethodEnrollMFA = "POST"
+
+	// ConfirmMFA
+	PathConfirmMFA   = "/users/me/mfa/confirm"
+	MethodConfirmMFA = "POST"
+
+	// DisableMFA
+	PathDisableMFA   = "/users/me/mfa/disable"
+	MethodDisableMFA = "POST"
+
 	// GetMe
 	PathGetMe   = "/users/me"
 	MethodGetMe = "GET"
 
 	// UpdateMe
-	PathUpdateMe   = "/users/me"
-	MethodUpdateMe = "PUT"
+	PathUpdateMe        = "/users/me"
+	MethodUpdateMe      = "PUT"
+	MethodUpdateMePatch = "PATCH"
 
 	// GetUserSessions
 	PathGetUserSessions   = "/users/{id}/sessions"
@@ -76,6 +134,63 @@ const (
 	PathCreateSession   = "/users/{id}/sessions"
 	MethodCreateSession = "POST"
 
+	// EndSession
+	PathEndSession   = "/users/{id}/sessions/{session_id}/end"
+	MethodEndSession = "POST"
+
+	// EndSessionsByExternalID
+	PathEndSessionsByExternalID   = "/sessions/{session_external_id}/end"
+	MethodEndSessionsByExternalID = "POST"
+
+	// RateConsultation
+	PathRateConsultation   = "/users/me/sessions/{session_id}/rating"
+	MethodRateConsultation = "POST"
+
+	// RegisterDevice
+	PathRegisterDevice   = "/users/me/devices"
+	MethodRegisterDevice = "POST"
+
+	// ListMyDevices
+	PathListMyDevices   = "/users/me/devices"
+	MethodListMyDevices = "GET"
+
+	// RemoveDevice
+	PathRemoveDevice   = "/users/me/devices/{device_id}"
+	MethodRemoveDevice = "DELETE"
+
+	// ConnectDevice
+	PathConnectDevice   = "/devices/connect"
+	MethodConnectDevice = "POST"
+
+	// DisconnectDevice
+	PathDisconnectDevice   = "/devices/{connection_id}/disconnect"
+	MethodDisconnectDevice = "POST"
+
+	// DeviceHeartbeat
+	PathDeviceHeartbeat   = "/devices/{connection_id}/heartbeat"
+	MethodDeviceHeartbeat = "POST"
+
+	// ListUserServiceRoutes
+	PathListUserServiceRoutes   = "/users/{user_id}/services"
+	MethodListUserServiceRoutes = "GET"
+
+	// CreateUserServiceRoute
+	PathCreateUserServiceRoute   = "/users/{user_id}/services"
+	MethodCreateUserServiceRoute = "POST"
+
+	// UpdateUserServiceRoute
+	PathUpdateUserServiceRoute        = "/users/{user_id}/services/{id}"
+	MethodUpdateUserServiceRoute      = "PUT"
+	MethodUpdateUserServiceRoutePatch = "PATCH"
+
+	// DeleteUserServiceRoute
+	PathDeleteUserServiceRoute   = "/users/{user_id}/services/{id}"
+	MethodDeleteUserServiceRoute = "DELETE"
+
+	// ResolveUserServiceRoute
+	PathResolveUserServiceRoute   = "/users/{user_id}/buttons/{button}/service"
+	MethodResolveUserServiceRoute = "GET"
+
 	// UpdateOperatorAvailability
 	PathUpdateOperatorAvailability   = "/operators/availability"
 	MethodUpdateOperatorAvailability = "PUT"
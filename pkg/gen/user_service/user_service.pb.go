@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -121,6 +123,7 @@ type CreateUserRequest struct {
 	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
 	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"` // client (по умолчанию), operator, admin
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -183,6 +186,13 @@ func (x *CreateUserRequest) GetPassword() string {
 	return ""
 }
 
+func (x *CreateUserRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
@@ -227,21 +237,211 @@ func (x *GetUserRequest) GetId() string {
 	return ""
 }
 
-type UpdateUserRequest struct {
+// ListUsersRequest — админский список пользователей с фильтрами и keyset-пагинацией.
+type ListUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Role           string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Search         string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"` // подстрока username/email
+	OperatorStatus string                 `protobuf:"bytes,4,opt,name=operator_status,json=operatorStatus,proto3" json:"operator_status,omitempty"`
+	IsOnline       *bool                  `protobuf:"varint,5,opt,name=is_online,json=isOnline,proto3,oneof" json:"is_online,omitempty"`
+	IsAvailable    *bool                  `protobuf:"varint,6,opt,name=is_available,json=isAvailable,proto3,oneof" json:"is_available,omitempty"`
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Sort           string                 `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`      // created_at, -created_at (по умолчанию), username, -username
+	Limit          int32                  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`  // по умолчанию 20, максимум 100
+	Cursor         string                 `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor из предыдущего ответа
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_user_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListUsersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetOperatorStatus() string {
+	if x != nil {
+		return x.OperatorStatus
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIsOnline() bool {
+	if x != nil && x.IsOnline != nil {
+		return *x.IsOnline
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetIsAvailable() bool {
+	if x != nil && x.IsAvailable != nil {
+		return *x.IsAvailable
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"` // Optional, empty if not changing
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Users         []*UserResponse        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // пусто — страниц больше нет
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_user_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserResponse {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// UpdateUserRequest — частичное обновление. update_mask — изменяемые поля (username, email, phone, password,
+// status, full_name, avatar_url, timezone, language, company, specialization); поле из маски с пустым
+// значением очищается. Без маски меняются только непустые поля. В JSON маска — строка через запятую:
+// {"phone": "", "update_mask": "phone,fullName"}.
+type UpdateUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID; в UpdateMe игнорируется
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone           string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Password        string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // только UpdateUser (admin)
+	FullName        string                 `protobuf:"bytes,7,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	AvatarUrl       string                 `protobuf:"bytes,8,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Timezone        string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Language        string                 `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	Company         string                 `protobuf:"bytes,11,opt,name=company,proto3" json:"company,omitempty"`
+	Specialization  string                 `protobuf:"bytes,12,opt,name=specialization,proto3" json:"specialization,omitempty"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,13,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Etag            string                 `protobuf:"bytes,14,opt,name=etag,proto3" json:"etag,omitempty"`                                              // ETag из UserResponse; пусто — заголовок If-Match, "*" — без проверки
+	CurrentPassword string                 `protobuf:"bytes,15,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"` // только UpdateMe: обязателен при смене password
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_user_service_proto_msgTypes[3]
+	mi := &file_user_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +453,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[3]
+	mi := &file_user_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +466,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{3}
+	return file_user_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateUserRequest) GetId() string {
@@ -311,6 +511,69 @@ func (x *UpdateUserRequest) GetStatus() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetSpecialization() string {
+	if x != nil {
+		return x.Specialization
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
 type DeleteUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
@@ -320,7 +583,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_user_service_proto_msgTypes[4]
+	mi := &file_user_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -332,7 +595,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[4]
+	mi := &file_user_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,7 +608,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{4}
+	return file_user_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteUserRequest) GetId() string {
@@ -364,7 +627,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_user_service_proto_msgTypes[5]
+	mi := &file_user_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -376,7 +639,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[5]
+	mi := &file_user_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +652,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{5}
+	return file_user_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -399,29 +662,28 @@ func (x *DeleteUserResponse) GetSuccess() bool {
 	return false
 }
 
-type LoginRequest struct {
+type RestoreUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
-	mi := &file_user_service_proto_msgTypes[6]
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_user_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginRequest) String() string {
+func (x *RestoreUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*RestoreUserRequest) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[6]
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,54 +694,40 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *LoginRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *LoginRequest) GetPassword() string {
+func (x *RestoreUserRequest) GetId() string {
 	if x != nil {
-		return x.Password
+		return x.Id
 	}
 	return ""
 }
 
-type UserResponse struct {
+type PurgeUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserResponse) Reset() {
-	*x = UserResponse{}
-	mi := &file_user_service_proto_msgTypes[7]
+func (x *PurgeUserRequest) Reset() {
+	*x = PurgeUserRequest{}
+	mi := &file_user_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserResponse) String() string {
+func (x *PurgeUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserResponse) ProtoMessage() {}
+func (*PurgeUserRequest) ProtoMessage() {}
 
-func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[7]
+func (x *PurgeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,91 +738,90 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
-func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use PurgeUserRequest.ProtoReflect.Descriptor instead.
+func (*PurgeUserRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UserResponse) GetId() string {
+func (x *PurgeUserRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UserResponse) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
-}
-
-func (x *UserResponse) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+type PurgeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserResponse) GetPhone() string {
-	if x != nil {
-		return x.Phone
-	}
-	return ""
+func (x *PurgeUserResponse) Reset() {
+	*x = PurgeUserResponse{}
+	mi := &file_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserResponse) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+func (x *PurgeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *UserResponse) GetCreatedAt() *timestamppb.Timestamp {
+func (*PurgeUserResponse) ProtoMessage() {}
+
+func (x *PurgeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[10]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UserResponse) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
+// Deprecated: Use PurgeUserResponse.ProtoReflect.Descriptor instead.
+func (*PurgeUserResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UserResponse) GetError() string {
+func (x *PurgeUserResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Error
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-type ValidateUserSessionRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	SessionExternalId string                 `protobuf:"bytes,2,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
-	ParticipantRole   string                 `protobuf:"bytes,3,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`    // UUID инициатора
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // UUID объекта (пользователя)
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                     // user.update, operator.verify, auth.login, ...
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`                         // включительно
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`                             // не включительно
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                      // по умолчанию 20, максимум 100
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`                     // next_cursor из предыдущего ответа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateUserSessionRequest) Reset() {
-	*x = ValidateUserSessionRequest{}
-	mi := &file_user_service_proto_msgTypes[8]
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_user_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateUserSessionRequest) String() string {
+func (x *ListAuditEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateUserSessionRequest) ProtoMessage() {}
+func (*ListAuditEventsRequest) ProtoMessage() {}
 
-func (x *ValidateUserSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[8]
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,55 +832,83 @@ func (x *ValidateUserSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateUserSessionRequest.ProtoReflect.Descriptor instead.
-func (*ValidateUserSessionRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ValidateUserSessionRequest) GetUserId() string {
+func (x *ListAuditEventsRequest) GetActorId() string {
 	if x != nil {
-		return x.UserId
+		return x.ActorId
 	}
 	return ""
 }
 
-func (x *ValidateUserSessionRequest) GetSessionExternalId() string {
+func (x *ListAuditEventsRequest) GetTargetId() string {
 	if x != nil {
-		return x.SessionExternalId
+		return x.TargetId
 	}
 	return ""
 }
 
-func (x *ValidateUserSessionRequest) GetParticipantRole() string {
+func (x *ListAuditEventsRequest) GetAction() string {
 	if x != nil {
-		return x.ParticipantRole
+		return x.Action
 	}
 	return ""
 }
 
-type ValidateUserSessionResponse struct {
+func (x *ListAuditEventsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // пусто — страниц больше нет
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateUserSessionResponse) Reset() {
-	*x = ValidateUserSessionResponse{}
-	mi := &file_user_service_proto_msgTypes[9]
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_user_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateUserSessionResponse) String() string {
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateUserSessionResponse) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *ValidateUserSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[9]
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,48 +919,59 @@ func (x *ValidateUserSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateUserSessionResponse.ProtoReflect.Descriptor instead.
-func (*ValidateUserSessionResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ValidateUserSessionResponse) GetAllowed() bool {
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
 	if x != nil {
-		return x.Allowed
+		return x.Events
 	}
-	return false
+	return nil
 }
 
-func (x *ValidateUserSessionResponse) GetError() string {
+func (x *ListAuditEventsResponse) GetNextCursor() string {
 	if x != nil {
-		return x.Error
+		return x.NextCursor
 	}
 	return ""
 }
 
-type UpdateUserPresenceRequest struct {
+// AuditEvent — запись журнала аудита. changes: {"поле": {"old": ..., "new": ...}} или {"redacted": true}
+// для персональных данных и пароля.
+type AuditEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	IsOnline      bool                   `protobuf:"varint,2,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`          // пусто — без аутентифицированного инициатора (вход, CLI, фоновая очистка)
+	TargetType    string                 `protobuf:"bytes,4,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // user
+	TargetId      string                 `protobuf:"bytes,5,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Changes       *structpb.Struct       `protobuf:"bytes,6,opt,name=changes,proto3" json:"changes,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,7,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Source        string                 `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"` // назначение роли (user.create, user.role_change): register, admin, bootstrap, cli
+	Reason        string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"` // причина смены роли (SetUserRole)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserPresenceRequest) Reset() {
-	*x = UpdateUserPresenceRequest{}
-	mi := &file_user_service_proto_msgTypes[10]
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_user_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserPresenceRequest) String() string {
+func (x *AuditEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserPresenceRequest) ProtoMessage() {}
+func (*AuditEvent) ProtoMessage() {}
 
-func (x *UpdateUserPresenceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[10]
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,100 +982,112 @@ func (x *UpdateUserPresenceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserPresenceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserPresenceRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *UpdateUserPresenceRequest) GetUserId() string {
+func (x *AuditEvent) GetId() string {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateUserPresenceRequest) GetIsOnline() bool {
+func (x *AuditEvent) GetAction() string {
 	if x != nil {
-		return x.IsOnline
+		return x.Action
 	}
-	return false
+	return ""
 }
 
-type UpdateUserPresenceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
 }
 
-func (x *UpdateUserPresenceResponse) Reset() {
-	*x = UpdateUserPresenceResponse{}
-	mi := &file_user_service_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AuditEvent) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
 }
 
-func (x *UpdateUserPresenceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AuditEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
 }
 
-func (*UpdateUserPresenceResponse) ProtoMessage() {}
+func (x *AuditEvent) GetChanges() *structpb.Struct {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
 
-func (x *UpdateUserPresenceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[11]
+func (x *AuditEvent) GetClientIp() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ClientIp
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateUserPresenceResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserPresenceResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{11}
+func (x *AuditEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
 }
 
-func (x *UpdateUserPresenceResponse) GetSuccess() bool {
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Success
+		return x.CreatedAt
 	}
-	return false
+	return nil
 }
 
-func (x *UpdateUserPresenceResponse) GetError() string {
+func (x *AuditEvent) GetSource() string {
 	if x != nil {
-		return x.Error
+		return x.Source
 	}
 	return ""
 }
 
-type GetAvailableOperatorsRequest struct {
+func (x *AuditEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetUserRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`         // UUID
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`     // client, operator, admin, service
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // в audit_events (user.role_change)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAvailableOperatorsRequest) Reset() {
-	*x = GetAvailableOperatorsRequest{}
-	mi := &file_user_service_proto_msgTypes[12]
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_user_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailableOperatorsRequest) String() string {
+func (x *SetUserRoleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailableOperatorsRequest) ProtoMessage() {}
+func (*SetUserRoleRequest) ProtoMessage() {}
 
-func (x *GetAvailableOperatorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[12]
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,49 +1098,56 @@ func (x *GetAvailableOperatorsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailableOperatorsRequest.ProtoReflect.Descriptor instead.
-func (*GetAvailableOperatorsRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetAvailableOperatorsRequest) GetLimit() int32 {
+func (x *SetUserRoleRequest) GetId() string {
 	if x != nil {
-		return x.Limit
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *GetAvailableOperatorsRequest) GetOffset() int32 {
+func (x *SetUserRoleRequest) GetRole() string {
 	if x != nil {
-		return x.Offset
+		return x.Role
 	}
-	return 0
+	return ""
 }
 
-type GetAvailableOperatorsResponse struct {
+func (x *SetUserRoleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Operators     []*UserResponse        `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"` // опционально: устройство, к которому привязывается семейство refresh-токенов
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAvailableOperatorsResponse) Reset() {
-	*x = GetAvailableOperatorsResponse{}
-	mi := &file_user_service_proto_msgTypes[13]
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_user_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailableOperatorsResponse) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailableOperatorsResponse) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *GetAvailableOperatorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[13]
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,55 +1158,71 @@ func (x *GetAvailableOperatorsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailableOperatorsResponse.ProtoReflect.Descriptor instead.
-func (*GetAvailableOperatorsResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *GetAvailableOperatorsResponse) GetOperators() []*UserResponse {
+func (x *LoginRequest) GetEmail() string {
 	if x != nil {
-		return x.Operators
+		return x.Email
 	}
-	return nil
+	return ""
 }
 
-func (x *GetAvailableOperatorsResponse) GetTotal() int64 {
+func (x *LoginRequest) GetPassword() string {
 	if x != nil {
-		return x.Total
+		return x.Password
 	}
-	return 0
+	return ""
 }
 
-func (x *GetAvailableOperatorsResponse) GetError() string {
+func (x *LoginRequest) GetDeviceId() string {
 	if x != nil {
-		return x.Error
+		return x.DeviceId
 	}
 	return ""
 }
 
-type UpdateOperatorStatusRequest struct {
+type UserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	IsAvailable   bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,9,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	MfaEnabled    bool                   `protobuf:"varint,10,opt,name=mfa_enabled,json=mfaEnabled,proto3" json:"mfa_enabled,omitempty"`
+	Etag          string                 `protobuf:"bytes,11,opt,name=etag,proto3" json:"etag,omitempty"` // версия записи; передаётся в If-Match / etag при изменении
+	Role          string                 `protobuf:"bytes,12,opt,name=role,proto3" json:"role,omitempty"` // client, operator, admin, service
+	IsActive      bool                   `protobuf:"varint,13,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	MaxSessions   int32                  `protobuf:"varint,14,opt,name=max_sessions,json=maxSessions,proto3" json:"max_sessions,omitempty"` // лимит одновременных сессий
+	TotalSessions int32                  `protobuf:"varint,15,opt,name=total_sessions,json=totalSessions,proto3" json:"total_sessions,omitempty"`
+	Profile       *UserProfile           `protobuf:"bytes,16,opt,name=profile,proto3" json:"profile,omitempty"`
+	Operator      *OperatorInfo          `protobuf:"bytes,17,opt,name=operator,proto3" json:"operator,omitempty"` // только для role = operator
+	Presence      *UserPresence          `protobuf:"bytes,18,opt,name=presence,proto3" json:"presence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOperatorStatusRequest) Reset() {
-	*x = UpdateOperatorStatusRequest{}
-	mi := &file_user_service_proto_msgTypes[14]
+func (x *UserResponse) Reset() {
+	*x = UserResponse{}
+	mi := &file_user_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOperatorStatusRequest) String() string {
+func (x *UserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOperatorStatusRequest) ProtoMessage() {}
+func (*UserResponse) ProtoMessage() {}
 
-func (x *UpdateOperatorStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[14]
+func (x *UserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -912,169 +1233,164 @@ func (x *UpdateOperatorStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOperatorStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOperatorStatusRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
+func (*UserResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *UpdateOperatorStatusRequest) GetUserId() string {
+func (x *UserResponse) GetId() string {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateOperatorStatusRequest) GetIsAvailable() bool {
+func (x *UserResponse) GetUsername() string {
 	if x != nil {
-		return x.IsAvailable
+		return x.Username
 	}
-	return false
-}
-
-type UpdateOperatorStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *UpdateOperatorStatusResponse) Reset() {
-	*x = UpdateOperatorStatusResponse{}
-	mi := &file_user_service_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UserResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
 }
 
-func (x *UpdateOperatorStatusResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UserResponse) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
 }
 
-func (*UpdateOperatorStatusResponse) ProtoMessage() {}
-
-func (x *UpdateOperatorStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[15]
+func (x *UserResponse) GetStatus() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Status
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateOperatorStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOperatorStatusResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{15}
+func (x *UserResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *UpdateOperatorStatusResponse) GetSuccess() bool {
+func (x *UserResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Success
+		return x.UpdatedAt
 	}
-	return false
+	return nil
 }
 
-func (x *UpdateOperatorStatusResponse) GetError() string {
+func (x *UserResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type AuthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	ExpiresIn     int32                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
-	User          *UserResponse          `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UserResponse) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
 }
 
-func (x *AuthResponse) Reset() {
-	*x = AuthResponse{}
-	mi := &file_user_service_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UserResponse) GetMfaEnabled() bool {
+	if x != nil {
+		return x.MfaEnabled
+	}
+	return false
 }
 
-func (x *AuthResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UserResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
 }
 
-func (*AuthResponse) ProtoMessage() {}
+func (x *UserResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
 
-func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[16]
+func (x *UserResponse) GetIsActive() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.IsActive
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
-func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{16}
+func (x *UserResponse) GetMaxSessions() int32 {
+	if x != nil {
+		return x.MaxSessions
+	}
+	return 0
 }
 
-func (x *AuthResponse) GetAccessToken() string {
+func (x *UserResponse) GetTotalSessions() int32 {
 	if x != nil {
-		return x.AccessToken
+		return x.TotalSessions
 	}
-	return ""
+	return 0
 }
 
-func (x *AuthResponse) GetRefreshToken() string {
+func (x *UserResponse) GetProfile() *UserProfile {
 	if x != nil {
-		return x.RefreshToken
+		return x.Profile
 	}
-	return ""
+	return nil
 }
 
-func (x *AuthResponse) GetExpiresIn() int32 {
+func (x *UserResponse) GetOperator() *OperatorInfo {
 	if x != nil {
-		return x.ExpiresIn
+		return x.Operator
 	}
-	return 0
+	return nil
 }
 
-func (x *AuthResponse) GetUser() *UserResponse {
+func (x *UserResponse) GetPresence() *UserPresence {
 	if x != nil {
-		return x.User
+		return x.Presence
 	}
 	return nil
 }
 
-type RegisterRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// UserProfile — профиль пользователя (поля UpdateUser/UpdateMe).
+type UserProfile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FullName       string                 `protobuf:"bytes,1,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,2,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Timezone       string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Language       string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	Company        string                 `protobuf:"bytes,5,opt,name=company,proto3" json:"company,omitempty"`
+	Specialization string                 `protobuf:"bytes,6,opt,name=specialization,proto3" json:"specialization,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *RegisterRequest) Reset() {
-	*x = RegisterRequest{}
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
 	mi := &file_user_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterRequest) String() string {
+func (x *UserProfile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterRequest) ProtoMessage() {}
+func (*UserProfile) ProtoMessage() {}
 
-func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1086,60 +1402,77 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
-func (*RegisterRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *RegisterRequest) GetUsername() string {
+func (x *UserProfile) GetFullName() string {
 	if x != nil {
-		return x.Username
+		return x.FullName
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetEmail() string {
+func (x *UserProfile) GetAvatarUrl() string {
 	if x != nil {
-		return x.Email
+		return x.AvatarUrl
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetPassword() string {
+func (x *UserProfile) GetTimezone() string {
 	if x != nil {
-		return x.Password
+		return x.Timezone
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetRole() string {
+func (x *UserProfile) GetLanguage() string {
 	if x != nil {
-		return x.Role
+		return x.Language
 	}
 	return ""
 }
 
-type RefreshRequest struct {
+func (x *UserProfile) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *UserProfile) GetSpecialization() string {
+	if x != nil {
+		return x.Specialization
+	}
+	return ""
+}
+
+// OperatorInfo — статус верификации, доступность и рейтинг оператора.
+type OperatorInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // pending, verified, blocked
+	IsAvailable   bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	Rating        float64                `protobuf:"fixed64,3,opt,name=rating,proto3" json:"rating,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshRequest) Reset() {
-	*x = RefreshRequest{}
+func (x *OperatorInfo) Reset() {
+	*x = OperatorInfo{}
 	mi := &file_user_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshRequest) String() string {
+func (x *OperatorInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshRequest) ProtoMessage() {}
+func (*OperatorInfo) ProtoMessage() {}
 
-func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+func (x *OperatorInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1151,38 +1484,57 @@ func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
-func (*RefreshRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OperatorInfo.ProtoReflect.Descriptor instead.
+func (*OperatorInfo) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *RefreshRequest) GetRefreshToken() string {
+func (x *OperatorInfo) GetStatus() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.Status
 	}
 	return ""
 }
 
-type LogoutRequest struct {
+func (x *OperatorInfo) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *OperatorInfo) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+// UserPresence — присутствие и последняя активность.
+type UserPresence struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsOnline      bool                   `protobuf:"varint,1,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastActivity  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	LastLogin     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
+func (x *UserPresence) Reset() {
+	*x = UserPresence{}
 	mi := &file_user_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutRequest) String() string {
+func (x *UserPresence) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*UserPresence) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+func (x *UserPresence) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1194,67 +1546,129 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserPresence.ProtoReflect.Descriptor instead.
+func (*UserPresence) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{19}
 }
 
-type LogoutResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UserPresence) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
 }
 
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
-	mi := &file_user_service_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UserPresence) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
 }
 
-func (x *LogoutResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UserPresence) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
 }
 
-func (*LogoutResponse) ProtoMessage() {}
-
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[20]
+func (x *UserPresence) GetLastLogin() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LastLogin
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
+type ValidateUserSessionRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionExternalId string                 `protobuf:"bytes,2,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
+	ParticipantRole   string                 `protobuf:"bytes,3,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
+	SessionType       string                 `protobuf:"bytes,4,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"` // как в CreateSession: для client — не больше одной streaming-сессии
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ValidateUserSessionRequest) Reset() {
+	*x = ValidateUserSessionRequest{}
+	mi := &file_user_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateUserSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateUserSessionRequest) ProtoMessage() {}
+
+func (x *ValidateUserSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateUserSessionRequest.ProtoReflect.Descriptor instead.
+func (*ValidateUserSessionRequest) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{20}
 }
 
-type GetMeRequest struct {
+func (x *ValidateUserSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateUserSessionRequest) GetSessionExternalId() string {
+	if x != nil {
+		return x.SessionExternalId
+	}
+	return ""
+}
+
+func (x *ValidateUserSessionRequest) GetParticipantRole() string {
+	if x != nil {
+		return x.ParticipantRole
+	}
+	return ""
+}
+
+func (x *ValidateUserSessionRequest) GetSessionType() string {
+	if x != nil {
+		return x.SessionType
+	}
+	return ""
+}
+
+type ValidateUserSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMeRequest) Reset() {
-	*x = GetMeRequest{}
+func (x *ValidateUserSessionResponse) Reset() {
+	*x = ValidateUserSessionResponse{}
 	mi := &file_user_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMeRequest) String() string {
+func (x *ValidateUserSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMeRequest) ProtoMessage() {}
+func (*ValidateUserSessionResponse) ProtoMessage() {}
 
-func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+func (x *ValidateUserSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1266,34 +1680,47 @@ func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
-func (*GetMeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateUserSessionResponse.ProtoReflect.Descriptor instead.
+func (*ValidateUserSessionResponse) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{21}
 }
 
-type GetUserSessionsRequest struct {
+func (x *ValidateUserSessionResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ValidateUserSessionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateUserPresenceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsOnline      bool                   `protobuf:"varint,2,opt,name=is_online,json=isOnline,proto3" json:"is_online,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserSessionsRequest) Reset() {
-	*x = GetUserSessionsRequest{}
+func (x *UpdateUserPresenceRequest) Reset() {
+	*x = UpdateUserPresenceRequest{}
 	mi := &file_user_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserSessionsRequest) String() string {
+func (x *UpdateUserPresenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSessionsRequest) ProtoMessage() {}
+func (*UpdateUserPresenceRequest) ProtoMessage() {}
 
-func (x *GetUserSessionsRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateUserPresenceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_user_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1305,147 +1732,2879 @@ func (x *GetUserSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSessionsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserSessionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateUserPresenceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserPresenceRequest) Descriptor() ([]byte, []int) {
 	return file_user_service_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetUserSessionsRequest) GetId() string {
+func (x *UpdateUserPresenceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserPresenceRequest) GetIsOnline() bool {
+	if x != nil {
+		return x.IsOnline
+	}
+	return false
+}
+
+type UpdateUserPresenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserPresenceResponse) Reset() {
+	*x = UpdateUserPresenceResponse{}
+	mi := &file_user_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserPresenceResponse) ProtoMessage() {}
+
+func (x *UpdateUserPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserPresenceResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateUserPresenceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateUserPresenceResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetAvailableOperatorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableOperatorsRequest) Reset() {
+	*x = GetAvailableOperatorsRequest{}
+	mi := &file_user_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableOperatorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableOperatorsRequest) ProtoMessage() {}
+
+func (x *GetAvailableOperatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableOperatorsRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableOperatorsRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetAvailableOperatorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAvailableOperatorsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetAvailableOperatorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operators     []*UserResponse        `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableOperatorsResponse) Reset() {
+	*x = GetAvailableOperatorsResponse{}
+	mi := &file_user_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableOperatorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableOperatorsResponse) ProtoMessage() {}
+
+func (x *GetAvailableOperatorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableOperatorsResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableOperatorsResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAvailableOperatorsResponse) GetOperators() []*UserResponse {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *GetAvailableOperatorsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetAvailableOperatorsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateOperatorStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsAvailable   bool                   `protobuf:"varint,2,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOperatorStatusRequest) Reset() {
+	*x = UpdateOperatorStatusRequest{}
+	mi := &file_user_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOperatorStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOperatorStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOperatorStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOperatorStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOperatorStatusRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateOperatorStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateOperatorStatusRequest) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+type UpdateOperatorStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOperatorStatusResponse) Reset() {
+	*x = UpdateOperatorStatusResponse{}
+	mi := &file_user_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOperatorStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOperatorStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOperatorStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOperatorStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOperatorStatusResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateOperatorStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateOperatorStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// AuthResponse — пара токенов либо, при включённой 2FA, mfa_required и mfa_token для LoginVerifyMFA.
+type AuthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int32                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	User          *UserResponse          `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	MfaRequired   bool                   `protobuf:"varint,5,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,6,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_user_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AuthResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetUser() *UserResponse {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"` // client (по умолчанию) или operator (operator_status = pending); admin не допускается
+	DeviceId      string                 `protobuf:"bytes,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_user_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RegisterRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RefreshRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_user_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RefreshRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// LogoutRequest: отзывается семейство refresh-токенов текущего access-токена (claim sid)
+// и, если передан, семейство refresh_token.
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_user_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_user_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{32}
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_user_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_user_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{34}
+}
+
+type ConfirmPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetRequest) Reset() {
+	*x = ConfirmPasswordResetRequest{}
+	mi := &file_user_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetRequest) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ConfirmPasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ConfirmPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetResponse) Reset() {
+	*x = ConfirmPasswordResetResponse{}
+	mi := &file_user_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetResponse) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{36}
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_user_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ResendVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // игнорируется, если передан access-токен
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_user_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ResendVerificationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResendVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationResponse) Reset() {
+	*x = ResendVerificationResponse{}
+	mi := &file_user_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationResponse) ProtoMessage() {}
+
+func (x *ResendVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationResponse.ProtoReflect.Descriptor instead.
+func (*ResendVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{39}
+}
+
+type LoginVerifyMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // 6 цифр TOTP или код восстановления
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginVerifyMFARequest) Reset() {
+	*x = LoginVerifyMFARequest{}
+	mi := &file_user_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginVerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginVerifyMFARequest) ProtoMessage() {}
+
+func (x *LoginVerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginVerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*LoginVerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LoginVerifyMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginVerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type EnrollMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollMFARequest) Reset() {
+	*x = EnrollMFARequest{}
+	mi := &file_user_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFARequest) ProtoMessage() {}
+
+func (x *EnrollMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFARequest.ProtoReflect.Descriptor instead.
+func (*EnrollMFARequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{41}
+}
+
+type EnrollMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // base32, для ручного ввода
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // для QR-кода
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollMFAResponse) Reset() {
+	*x = EnrollMFAResponse{}
+	mi := &file_user_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFAResponse) ProtoMessage() {}
+
+func (x *EnrollMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFAResponse.ProtoReflect.Descriptor instead.
+func (*EnrollMFAResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *EnrollMFAResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollMFAResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+type ConfirmMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFARequest) Reset() {
+	*x = ConfirmMFARequest{}
+	mi := &file_user_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFARequest) ProtoMessage() {}
+
+func (x *ConfirmMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFARequest.ProtoReflect.Descriptor instead.
+func (*ConfirmMFARequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ConfirmMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFAResponse) Reset() {
+	*x = ConfirmMFAResponse{}
+	mi := &file_user_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFAResponse) ProtoMessage() {}
+
+func (x *ConfirmMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFAResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmMFAResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ConfirmMFAResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // TOTP или код восстановления
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFARequest) Reset() {
+	*x = DisableMFARequest{}
+	mi := &file_user_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFARequest) ProtoMessage() {}
+
+func (x *DisableMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFARequest.ProtoReflect.Descriptor instead.
+func (*DisableMFARequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DisableMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFAResponse) Reset() {
+	*x = DisableMFAResponse{}
+	mi := &file_user_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFAResponse) ProtoMessage() {}
+
+func (x *DisableMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFAResponse.ProtoReflect.Descriptor instead.
+func (*DisableMFAResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{46}
+}
+
+type GetMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
+	mi := &file_user_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeRequest) ProtoMessage() {}
+
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{47}
+}
+
+type GetUserSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSessionsRequest) Reset() {
+	*x = GetUserSessionsRequest{}
+	mi := &file_user_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSessionsRequest) ProtoMessage() {}
+
+func (x *GetUserSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSessionsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetUserSessionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetUserSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetUserSessionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type UserSessionResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId               string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionType          string                 `protobuf:"bytes,3,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`
+	SessionExternalId    string                 `protobuf:"bytes,4,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
+	ParticipantRole      string                 `protobuf:"bytes,5,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
+	JoinedAt             *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	LeftAt               *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=left_at,json=leftAt,proto3" json:"left_at,omitempty"`
+	DurationSeconds      int32                  `protobuf:"varint,8,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	ConsultationRating   int32                  `protobuf:"varint,9,opt,name=consultation_rating,json=consultationRating,proto3" json:"consultation_rating,omitempty"` // 0 — оценка не выставлена
+	ConsultationFeedback string                 `protobuf:"bytes,10,opt,name=consultation_feedback,json=consultationFeedback,proto3" json:"consultation_feedback,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UserSessionResponse) Reset() {
+	*x = UserSessionResponse{}
+	mi := &file_user_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSessionResponse) ProtoMessage() {}
+
+func (x *UserSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSessionResponse.ProtoReflect.Descriptor instead.
+func (*UserSessionResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UserSessionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserSessionResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserSessionResponse) GetSessionType() string {
+	if x != nil {
+		return x.SessionType
+	}
+	return ""
+}
+
+func (x *UserSessionResponse) GetSessionExternalId() string {
+	if x != nil {
+		return x.SessionExternalId
+	}
+	return ""
+}
+
+func (x *UserSessionResponse) GetParticipantRole() string {
+	if x != nil {
+		return x.ParticipantRole
+	}
+	return ""
+}
+
+func (x *UserSessionResponse) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *UserSessionResponse) GetLeftAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeftAt
+	}
+	return nil
+}
+
+func (x *UserSessionResponse) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *UserSessionResponse) GetConsultationRating() int32 {
+	if x != nil {
+		return x.ConsultationRating
+	}
+	return 0
+}
+
+func (x *UserSessionResponse) GetConsultationFeedback() string {
+	if x != nil {
+		return x.ConsultationFeedback
+	}
+	return ""
+}
+
+type GetUserSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*UserSessionResponse `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSessionsResponse) Reset() {
+	*x = GetUserSessionsResponse{}
+	mi := &file_user_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSessionsResponse) ProtoMessage() {}
+
+func (x *GetUserSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSessionsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetUserSessionsResponse) GetSessions() []*UserSessionResponse {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *GetUserSessionsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetActiveSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsRequest) Reset() {
+	*x = GetActiveSessionsRequest{}
+	mi := &file_user_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsRequest) ProtoMessage() {}
+
+func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetActiveSessionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetActiveSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*UserSessionResponse `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsResponse) Reset() {
+	*x = GetActiveSessionsResponse{}
+	mi := &file_user_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsResponse) ProtoMessage() {}
+
+func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsResponse.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetActiveSessionsResponse) GetSessions() []*UserSessionResponse {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type CreateSessionRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"` // user_id from path /api/v1/users/{id}/sessions
+	SessionType       string                 `protobuf:"bytes,1,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`
+	SessionExternalId string                 `protobuf:"bytes,2,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
+	ParticipantRole   string                 `protobuf:"bytes,3,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_user_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetSessionType() string {
+	if x != nil {
+		return x.SessionType
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetSessionExternalId() string {
+	if x != nil {
+		return x.SessionExternalId
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetParticipantRole() string {
+	if x != nil {
+		return x.ParticipantRole
+	}
+	return ""
+}
+
+type EndSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                // user_id from path /api/v1/users/{id}/sessions/{session_id}/end
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // user_sessions.id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndSessionRequest) Reset() {
+	*x = EndSessionRequest{}
+	mi := &file_user_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndSessionRequest) ProtoMessage() {}
+
+func (x *EndSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndSessionRequest.ProtoReflect.Descriptor instead.
+func (*EndSessionRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EndSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EndSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// EndSessionsByExternalIDRequest — закрытие всех активных участий в комнате session-manager.
+type EndSessionsByExternalIDRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionExternalId string                 `protobuf:"bytes,1,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EndSessionsByExternalIDRequest) Reset() {
+	*x = EndSessionsByExternalIDRequest{}
+	mi := &file_user_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndSessionsByExternalIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndSessionsByExternalIDRequest) ProtoMessage() {}
+
+func (x *EndSessionsByExternalIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndSessionsByExternalIDRequest.ProtoReflect.Descriptor instead.
+func (*EndSessionsByExternalIDRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *EndSessionsByExternalIDRequest) GetSessionExternalId() string {
+	if x != nil {
+		return x.SessionExternalId
+	}
+	return ""
+}
+
+type EndSessionsByExternalIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ended         int64                  `protobuf:"varint,1,opt,name=ended,proto3" json:"ended,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndSessionsByExternalIDResponse) Reset() {
+	*x = EndSessionsByExternalIDResponse{}
+	mi := &file_user_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndSessionsByExternalIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndSessionsByExternalIDResponse) ProtoMessage() {}
+
+func (x *EndSessionsByExternalIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndSessionsByExternalIDResponse.ProtoReflect.Descriptor instead.
+func (*EndSessionsByExternalIDResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *EndSessionsByExternalIDResponse) GetEnded() int64 {
+	if x != nil {
+		return x.Ended
+	}
+	return 0
+}
+
+func (x *EndSessionsByExternalIDResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// RateConsultationRequest — оценка оператора клиентом после завершённой консультации (один раз).
+type RateConsultationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // user_sessions.id участия клиента
+	Rating        int32                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"`                       // 1..5
+	Feedback      string                 `protobuf:"bytes,3,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateConsultationRequest) Reset() {
+	*x = RateConsultationRequest{}
+	mi := &file_user_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateConsultationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateConsultationRequest) ProtoMessage() {}
+
+func (x *RateConsultationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateConsultationRequest.ProtoReflect.Descriptor instead.
+func (*RateConsultationRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RateConsultationRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RateConsultationRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RateConsultationRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+type DeviceResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId          string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceType        string                 `protobuf:"bytes,4,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	UserAgent         string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress         string                 `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	ConnectionId      string                 `protobuf:"bytes,7,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	IsConnected       bool                   `protobuf:"varint,8,opt,name=is_connected,json=isConnected,proto3" json:"is_connected,omitempty"`
+	LastHeartbeat     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
+	SupportsWebrtc    bool                   `protobuf:"varint,10,opt,name=supports_webrtc,json=supportsWebrtc,proto3" json:"supports_webrtc,omitempty"`
+	SupportsWebsocket bool                   `protobuf:"varint,11,opt,name=supports_websocket,json=supportsWebsocket,proto3" json:"supports_websocket,omitempty"`
+	BandwidthLimit    int32                  `protobuf:"varint,12,opt,name=bandwidth_limit,json=bandwidthLimit,proto3" json:"bandwidth_limit,omitempty"` // 0 — без ограничения
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeviceResponse) Reset() {
+	*x = DeviceResponse{}
+	mi := &file_user_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceResponse) ProtoMessage() {}
+
+func (x *DeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceResponse.ProtoReflect.Descriptor instead.
+func (*DeviceResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DeviceResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *DeviceResponse) GetIsConnected() bool {
+	if x != nil {
+		return x.IsConnected
+	}
+	return false
+}
+
+func (x *DeviceResponse) GetLastHeartbeat() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeat
+	}
+	return nil
+}
+
+func (x *DeviceResponse) GetSupportsWebrtc() bool {
+	if x != nil {
+		return x.SupportsWebrtc
+	}
+	return false
+}
+
+func (x *DeviceResponse) GetSupportsWebsocket() bool {
+	if x != nil {
+		return x.SupportsWebsocket
+	}
+	return false
+}
+
+func (x *DeviceResponse) GetBandwidthLimit() int32 {
+	if x != nil {
+		return x.BandwidthLimit
+	}
+	return 0
+}
+
+func (x *DeviceResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// RegisterDeviceRequest — регистрация/обновление устройства текущего пользователя (уникально по device_id).
+type RegisterDeviceRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId          string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeviceType        string                 `protobuf:"bytes,2,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	UserAgent         string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress         string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	SupportsWebrtc    bool                   `protobuf:"varint,5,opt,name=supports_webrtc,json=supportsWebrtc,proto3" json:"supports_webrtc,omitempty"`
+	SupportsWebsocket bool                   `protobuf:"varint,6,opt,name=supports_websocket,json=supportsWebsocket,proto3" json:"supports_websocket,omitempty"`
+	BandwidthLimit    int32                  `protobuf:"varint,7,opt,name=bandwidth_limit,json=bandwidthLimit,proto3" json:"bandwidth_limit,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegisterDeviceRequest) Reset() {
+	*x = RegisterDeviceRequest{}
+	mi := &file_user_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDeviceRequest) ProtoMessage() {}
+
+func (x *RegisterDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RegisterDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetSupportsWebrtc() bool {
+	if x != nil {
+		return x.SupportsWebrtc
+	}
+	return false
+}
+
+func (x *RegisterDeviceRequest) GetSupportsWebsocket() bool {
+	if x != nil {
+		return x.SupportsWebsocket
+	}
+	return false
+}
+
+func (x *RegisterDeviceRequest) GetBandwidthLimit() int32 {
+	if x != nil {
+		return x.BandwidthLimit
+	}
+	return 0
+}
+
+type ListMyDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyDevicesRequest) Reset() {
+	*x = ListMyDevicesRequest{}
+	mi := &file_user_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDevicesRequest) ProtoMessage() {}
+
+func (x *ListMyDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListMyDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{60}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*DeviceResponse      `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_user_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*DeviceResponse {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type RemoveDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceRequest) Reset() {
+	*x = RemoveDeviceRequest{}
+	mi := &file_user_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceRequest) ProtoMessage() {}
+
+func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RemoveDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RemoveDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceResponse) Reset() {
+	*x = RemoveDeviceResponse{}
+	mi := &file_user_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceResponse) ProtoMessage() {}
+
+func (x *RemoveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RemoveDeviceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ConnectDeviceRequest — WebSocket-шлюз сообщает о подключении устройства.
+type ConnectDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectDeviceRequest) Reset() {
+	*x = ConnectDeviceRequest{}
+	mi := &file_user_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectDeviceRequest) ProtoMessage() {}
+
+func (x *ConnectDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ConnectDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ConnectDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConnectDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ConnectDeviceRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *ConnectDeviceRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *ConnectDeviceRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+type DisconnectDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectDeviceRequest) Reset() {
+	*x = DisconnectDeviceRequest{}
+	mi := &file_user_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectDeviceRequest) ProtoMessage() {}
+
+func (x *DisconnectDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectDeviceRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DisconnectDeviceRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type DeviceHeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceHeartbeatRequest) Reset() {
+	*x = DeviceHeartbeatRequest{}
+	mi := &file_user_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceHeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceHeartbeatRequest) ProtoMessage() {}
+
+func (x *DeviceHeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*DeviceHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DeviceHeartbeatRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+// ServiceSchedule — окно работы сервиса (user_services.schedule), время в часовом поясе пользователя.
+type ServiceSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Always        bool                   `protobuf:"varint,1,opt,name=always,proto3" json:"always,omitempty"`
+	Weekdays      []string               `protobuf:"bytes,2,rep,name=weekdays,proto3" json:"weekdays,omitempty"`                    // monday..sunday
+	StartTime     string                 `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // HH:MM
+	EndTime       string                 `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // HH:MM
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceSchedule) Reset() {
+	*x = ServiceSchedule{}
+	mi := &file_user_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSchedule) ProtoMessage() {}
+
+func (x *ServiceSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSchedule.ProtoReflect.Descriptor instead.
+func (*ServiceSchedule) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ServiceSchedule) GetAlways() bool {
+	if x != nil {
+		return x.Always
+	}
+	return false
+}
+
+func (x *ServiceSchedule) GetWeekdays() []string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return nil
+}
+
+func (x *ServiceSchedule) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *ServiceSchedule) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// UserServiceRoute — конфигурация маршрутизации сервиса пользователя (таблица user_services).
+type UserServiceRoute struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServiceName        string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	ServiceType        string                 `protobuf:"bytes,4,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"` // streaming, recording, monitoring, analytics, custom
+	BaseUrl            string                 `protobuf:"bytes,5,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	ApiEndpoint        string                 `protobuf:"bytes,6,opt,name=api_endpoint,json=apiEndpoint,proto3" json:"api_endpoint,omitempty"`
+	Port               int32                  `protobuf:"varint,7,opt,name=port,proto3" json:"port,omitempty"`
+	UseSsl             bool                   `protobuf:"varint,8,opt,name=use_ssl,json=useSsl,proto3" json:"use_ssl,omitempty"`
+	SslCertificatePath string                 `protobuf:"bytes,9,opt,name=ssl_certificate_path,json=sslCertificatePath,proto3" json:"ssl_certificate_path,omitempty"`
+	RoutingKey         string                 `protobuf:"bytes,10,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`
+	QueueName          string                 `protobuf:"bytes,11,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	TopicName          string                 `protobuf:"bytes,12,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	MaxBitrate         int32                  `protobuf:"varint,13,opt,name=max_bitrate,json=maxBitrate,proto3" json:"max_bitrate,omitempty"`
+	MaxConnections     int32                  `protobuf:"varint,14,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	Priority           int32                  `protobuf:"varint,15,opt,name=priority,proto3" json:"priority,omitempty"` // 1..10, меньше — приоритетнее
+	IsActive           bool                   `protobuf:"varint,16,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	EnabledButtons     []string               `protobuf:"bytes,17,rep,name=enabled_buttons,json=enabledButtons,proto3" json:"enabled_buttons,omitempty"`
+	Schedule           *ServiceSchedule       `protobuf:"bytes,18,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Parameters         string                 `protobuf:"bytes,19,opt,name=parameters,proto3" json:"parameters,omitempty"` // JSON-объект
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UserServiceRoute) Reset() {
+	*x = UserServiceRoute{}
+	mi := &file_user_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserServiceRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserServiceRoute) ProtoMessage() {}
+
+func (x *UserServiceRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserServiceRoute.ProtoReflect.Descriptor instead.
+func (*UserServiceRoute) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UserServiceRoute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetApiEndpoint() string {
+	if x != nil {
+		return x.ApiEndpoint
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *UserServiceRoute) GetUseSsl() bool {
+	if x != nil {
+		return x.UseSsl
+	}
+	return false
+}
+
+func (x *UserServiceRoute) GetSslCertificatePath() string {
+	if x != nil {
+		return x.SslCertificatePath
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetRoutingKey() string {
+	if x != nil {
+		return x.RoutingKey
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetMaxBitrate() int32 {
+	if x != nil {
+		return x.MaxBitrate
+	}
+	return 0
+}
+
+func (x *UserServiceRoute) GetMaxConnections() int32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *UserServiceRoute) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UserServiceRoute) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *UserServiceRoute) GetEnabledButtons() []string {
+	if x != nil {
+		return x.EnabledButtons
+	}
+	return nil
+}
+
+func (x *UserServiceRoute) GetSchedule() *ServiceSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *UserServiceRoute) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+func (x *UserServiceRoute) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserServiceRoute) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// UserServiceRouteRequest — создание (POST) и обновление (PUT/PATCH) записи user_services. Обновление меняет
+// только поля из update_mask (без маски — переданные непустые поля); поле из маски с пустым значением
+// получает значение по умолчанию.
+type UserServiceRouteRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id                 string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"` // только для обновления
+	ServiceName        string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	ServiceType        string                 `protobuf:"bytes,4,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	BaseUrl            string                 `protobuf:"bytes,5,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	ApiEndpoint        string                 `protobuf:"bytes,6,opt,name=api_endpoint,json=apiEndpoint,proto3" json:"api_endpoint,omitempty"`
+	Port               int32                  `protobuf:"varint,7,opt,name=port,proto3" json:"port,omitempty"`
+	UseSsl             bool                   `protobuf:"varint,8,opt,name=use_ssl,json=useSsl,proto3" json:"use_ssl,omitempty"`
+	SslCertificatePath string                 `protobuf:"bytes,9,opt,name=ssl_certificate_path,json=sslCertificatePath,proto3" json:"ssl_certificate_path,omitempty"`
+	RoutingKey         string                 `protobuf:"bytes,10,opt,name=routing_key,json=routingKey,proto3" json:"routing_key,omitempty"`
+	QueueName          string                 `protobuf:"bytes,11,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	TopicName          string                 `protobuf:"bytes,12,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	MaxBitrate         int32                  `protobuf:"varint,13,opt,name=max_bitrate,json=maxBitrate,proto3" json:"max_bitrate,omitempty"`
+	MaxConnections     int32                  `protobuf:"varint,14,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	Priority           int32                  `protobuf:"varint,15,opt,name=priority,proto3" json:"priority,omitempty"`
+	IsActive           *bool                  `protobuf:"varint,16,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"` // по умолчанию true
+	EnabledButtons     []string               `protobuf:"bytes,17,rep,name=enabled_buttons,json=enabledButtons,proto3" json:"enabled_buttons,omitempty"`
+	Schedule           *ServiceSchedule       `protobuf:"bytes,18,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Parameters         string                 `protobuf:"bytes,19,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	UpdateMask         *fieldmaskpb.FieldMask `protobuf:"bytes,20,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UserServiceRouteRequest) Reset() {
+	*x = UserServiceRouteRequest{}
+	mi := &file_user_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserServiceRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserServiceRouteRequest) ProtoMessage() {}
+
+func (x *UserServiceRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserServiceRouteRequest.ProtoReflect.Descriptor instead.
+func (*UserServiceRouteRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UserServiceRouteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserServiceRouteRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *GetUserSessionsRequest) GetLimit() int32 {
+func (x *UserServiceRouteRequest) GetServiceName() string {
 	if x != nil {
-		return x.Limit
+		return x.ServiceName
 	}
-	return 0
+	return ""
 }
 
-func (x *GetUserSessionsRequest) GetOffset() int32 {
+func (x *UserServiceRouteRequest) GetServiceType() string {
 	if x != nil {
-		return x.Offset
+		return x.ServiceType
 	}
-	return 0
+	return ""
 }
 
-type UserSessionResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	SessionType       string                 `protobuf:"bytes,3,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`
-	SessionExternalId string                 `protobuf:"bytes,4,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
-	ParticipantRole   string                 `protobuf:"bytes,5,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
-	JoinedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
-	LeftAt            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=left_at,json=leftAt,proto3" json:"left_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *UserServiceRouteRequest) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
 }
 
-func (x *UserSessionResponse) Reset() {
-	*x = UserSessionResponse{}
-	mi := &file_user_service_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UserServiceRouteRequest) GetApiEndpoint() string {
+	if x != nil {
+		return x.ApiEndpoint
+	}
+	return ""
 }
 
-func (x *UserSessionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UserServiceRouteRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
 }
 
-func (*UserSessionResponse) ProtoMessage() {}
-
-func (x *UserSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[23]
+func (x *UserServiceRouteRequest) GetUseSsl() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.UseSsl
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use UserSessionResponse.ProtoReflect.Descriptor instead.
-func (*UserSessionResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{23}
+func (x *UserServiceRouteRequest) GetSslCertificatePath() string {
+	if x != nil {
+		return x.SslCertificatePath
+	}
+	return ""
 }
 
-func (x *UserSessionResponse) GetId() string {
+func (x *UserServiceRouteRequest) GetRoutingKey() string {
 	if x != nil {
-		return x.Id
+		return x.RoutingKey
 	}
 	return ""
 }
 
-func (x *UserSessionResponse) GetUserId() string {
+func (x *UserServiceRouteRequest) GetQueueName() string {
 	if x != nil {
-		return x.UserId
+		return x.QueueName
 	}
 	return ""
 }
 
-func (x *UserSessionResponse) GetSessionType() string {
+func (x *UserServiceRouteRequest) GetTopicName() string {
 	if x != nil {
-		return x.SessionType
+		return x.TopicName
 	}
 	return ""
 }
 
-func (x *UserSessionResponse) GetSessionExternalId() string {
+func (x *UserServiceRouteRequest) GetMaxBitrate() int32 {
 	if x != nil {
-		return x.SessionExternalId
+		return x.MaxBitrate
 	}
-	return ""
+	return 0
 }
 
-func (x *UserSessionResponse) GetParticipantRole() string {
+func (x *UserServiceRouteRequest) GetMaxConnections() int32 {
 	if x != nil {
-		return x.ParticipantRole
+		return x.MaxConnections
 	}
-	return ""
+	return 0
 }
 
-func (x *UserSessionResponse) GetJoinedAt() *timestamppb.Timestamp {
+func (x *UserServiceRouteRequest) GetPriority() int32 {
 	if x != nil {
-		return x.JoinedAt
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UserServiceRouteRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UserServiceRouteRequest) GetEnabledButtons() []string {
+	if x != nil {
+		return x.EnabledButtons
 	}
 	return nil
 }
 
-func (x *UserSessionResponse) GetLeftAt() *timestamppb.Timestamp {
+func (x *UserServiceRouteRequest) GetSchedule() *ServiceSchedule {
 	if x != nil {
-		return x.LeftAt
+		return x.Schedule
 	}
 	return nil
 }
 
-type GetUserSessionsResponse struct {
+func (x *UserServiceRouteRequest) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+func (x *UserServiceRouteRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type ListUserServiceRoutesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*UserSessionResponse `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserSessionsResponse) Reset() {
-	*x = GetUserSessionsResponse{}
-	mi := &file_user_service_proto_msgTypes[24]
+func (x *ListUserServiceRoutesRequest) Reset() {
+	*x = ListUserServiceRoutesRequest{}
+	mi := &file_user_service_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserSessionsResponse) String() string {
+func (x *ListUserServiceRoutesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSessionsResponse) ProtoMessage() {}
+func (*ListUserServiceRoutesRequest) ProtoMessage() {}
 
-func (x *GetUserSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[24]
+func (x *ListUserServiceRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1456,47 +4615,40 @@ func (x *GetUserSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSessionsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *GetUserSessionsResponse) GetSessions() []*UserSessionResponse {
-	if x != nil {
-		return x.Sessions
-	}
-	return nil
+// Deprecated: Use ListUserServiceRoutesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserServiceRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetUserSessionsResponse) GetTotal() int64 {
+func (x *ListUserServiceRoutesRequest) GetUserId() string {
 	if x != nil {
-		return x.Total
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-type GetActiveSessionsRequest struct {
+type ListUserServiceRoutesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Services      []*UserServiceRoute    `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveSessionsRequest) Reset() {
-	*x = GetActiveSessionsRequest{}
-	mi := &file_user_service_proto_msgTypes[25]
+func (x *ListUserServiceRoutesResponse) Reset() {
+	*x = ListUserServiceRoutesResponse{}
+	mi := &file_user_service_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveSessionsRequest) String() string {
+func (x *ListUserServiceRoutesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveSessionsRequest) ProtoMessage() {}
+func (*ListUserServiceRoutesResponse) ProtoMessage() {}
 
-func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[25]
+func (x *ListUserServiceRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1507,40 +4659,41 @@ func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveSessionsRequest.ProtoReflect.Descriptor instead.
-func (*GetActiveSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListUserServiceRoutesResponse.ProtoReflect.Descriptor instead.
+func (*ListUserServiceRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *GetActiveSessionsRequest) GetId() string {
+func (x *ListUserServiceRoutesResponse) GetServices() []*UserServiceRoute {
 	if x != nil {
-		return x.Id
+		return x.Services
 	}
-	return ""
+	return nil
 }
 
-type GetActiveSessionsResponse struct {
+type DeleteUserServiceRouteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*UserSessionResponse `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveSessionsResponse) Reset() {
-	*x = GetActiveSessionsResponse{}
-	mi := &file_user_service_proto_msgTypes[26]
+func (x *DeleteUserServiceRouteRequest) Reset() {
+	*x = DeleteUserServiceRouteRequest{}
+	mi := &file_user_service_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveSessionsResponse) String() string {
+func (x *DeleteUserServiceRouteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveSessionsResponse) ProtoMessage() {}
+func (*DeleteUserServiceRouteRequest) ProtoMessage() {}
 
-func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[26]
+func (x *DeleteUserServiceRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1551,43 +4704,47 @@ func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveSessionsResponse.ProtoReflect.Descriptor instead.
-func (*GetActiveSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use DeleteUserServiceRouteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserServiceRouteRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *GetActiveSessionsResponse) GetSessions() []*UserSessionResponse {
+func (x *DeleteUserServiceRouteRequest) GetUserId() string {
 	if x != nil {
-		return x.Sessions
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type CreateSessionRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"` // user_id from path /api/v1/users/{id}/sessions
-	SessionType       string                 `protobuf:"bytes,1,opt,name=session_type,json=sessionType,proto3" json:"session_type,omitempty"`
-	SessionExternalId string                 `protobuf:"bytes,2,opt,name=session_external_id,json=sessionExternalId,proto3" json:"session_external_id,omitempty"`
-	ParticipantRole   string                 `protobuf:"bytes,3,opt,name=participant_role,json=participantRole,proto3" json:"participant_role,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *DeleteUserServiceRouteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (x *CreateSessionRequest) Reset() {
-	*x = CreateSessionRequest{}
-	mi := &file_user_service_proto_msgTypes[27]
+type DeleteUserServiceRouteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserServiceRouteResponse) Reset() {
+	*x = DeleteUserServiceRouteResponse{}
+	mi := &file_user_service_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSessionRequest) String() string {
+func (x *DeleteUserServiceRouteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSessionRequest) ProtoMessage() {}
+func (*DeleteUserServiceRouteResponse) ProtoMessage() {}
 
-func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[27]
+func (x *DeleteUserServiceRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1598,35 +4755,66 @@ func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
-func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use DeleteUserServiceRouteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserServiceRouteResponse) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *CreateSessionRequest) GetId() string {
+func (x *DeleteUserServiceRouteResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *CreateSessionRequest) GetSessionType() string {
+type ResolveUserServiceRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Button        string                 `protobuf:"bytes,2,opt,name=button,proto3" json:"button,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveUserServiceRouteRequest) Reset() {
+	*x = ResolveUserServiceRouteRequest{}
+	mi := &file_user_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveUserServiceRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveUserServiceRouteRequest) ProtoMessage() {}
+
+func (x *ResolveUserServiceRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_service_proto_msgTypes[74]
 	if x != nil {
-		return x.SessionType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateSessionRequest) GetSessionExternalId() string {
+// Deprecated: Use ResolveUserServiceRouteRequest.ProtoReflect.Descriptor instead.
+func (*ResolveUserServiceRouteRequest) Descriptor() ([]byte, []int) {
+	return file_user_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ResolveUserServiceRouteRequest) GetUserId() string {
 	if x != nil {
-		return x.SessionExternalId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CreateSessionRequest) GetParticipantRole() string {
+func (x *ResolveUserServiceRouteRequest) GetButton() string {
 	if x != nil {
-		return x.ParticipantRole
+		return x.Button
 	}
 	return ""
 }
@@ -1641,7 +4829,7 @@ type VerifyOperatorRequest struct {
 
 func (x *VerifyOperatorRequest) Reset() {
 	*x = VerifyOperatorRequest{}
-	mi := &file_user_service_proto_msgTypes[28]
+	mi := &file_user_service_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1653,7 +4841,7 @@ func (x *VerifyOperatorRequest) String() string {
 func (*VerifyOperatorRequest) ProtoMessage() {}
 
 func (x *VerifyOperatorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[28]
+	mi := &file_user_service_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1666,7 +4854,7 @@ func (x *VerifyOperatorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyOperatorRequest.ProtoReflect.Descriptor instead.
 func (*VerifyOperatorRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{28}
+	return file_user_service_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *VerifyOperatorRequest) GetId() string {
@@ -1691,7 +4879,7 @@ type GetOperatorStatsRequest struct {
 
 func (x *GetOperatorStatsRequest) Reset() {
 	*x = GetOperatorStatsRequest{}
-	mi := &file_user_service_proto_msgTypes[29]
+	mi := &file_user_service_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1703,7 +4891,7 @@ func (x *GetOperatorStatsRequest) String() string {
 func (*GetOperatorStatsRequest) ProtoMessage() {}
 
 func (x *GetOperatorStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[29]
+	mi := &file_user_service_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1716,7 +4904,7 @@ func (x *GetOperatorStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOperatorStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetOperatorStatsRequest) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{29}
+	return file_user_service_proto_rawDescGZIP(), []int{76}
 }
 
 type GetOperatorStatsResponse struct {
@@ -1730,7 +4918,7 @@ type GetOperatorStatsResponse struct {
 
 func (x *GetOperatorStatsResponse) Reset() {
 	*x = GetOperatorStatsResponse{}
-	mi := &file_user_service_proto_msgTypes[30]
+	mi := &file_user_service_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +4930,7 @@ func (x *GetOperatorStatsResponse) String() string {
 func (*GetOperatorStatsResponse) ProtoMessage() {}
 
 func (x *GetOperatorStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_service_proto_msgTypes[30]
+	mi := &file_user_service_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +4943,7 @@ func (x *GetOperatorStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOperatorStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetOperatorStatsResponse) Descriptor() ([]byte, []int) {
-	return file_user_service_proto_rawDescGZIP(), []int{30}
+	return file_user_service_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *GetOperatorStatsResponse) GetTotalSessions() int64 {
@@ -1783,7 +4971,7 @@ var File_user_service_proto protoreflect.FileDescriptor
 
 const file_user_service_proto_rawDesc = "" +
 	"\n" +
-	"\x12user_service.proto\x12\fuser_service\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xec\x01\n" +
+	"\x12user_service.proto\x12\fuser_service\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xec\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -1793,28 +4981,101 @@ const file_user_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"w\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8b\x01\n" +
 	"\x11CreateUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
 	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpassword\" \n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9f\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xae\x03\n" +
+	"\x10ListUsersRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12'\n" +
+	"\x0foperator_status\x18\x04 \x01(\tR\x0eoperatorStatus\x12 \n" +
+	"\tis_online\x18\x05 \x01(\bH\x00R\bisOnline\x88\x01\x01\x12&\n" +
+	"\fis_available\x18\x06 \x01(\bH\x01R\visAvailable\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_is_onlineB\x0f\n" +
+	"\r_is_available\"f\n" +
+	"\x11ListUsersResponse\x120\n" +
+	"\x05users\x18\x01 \x03(\v2\x1a.user_service.UserResponseR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xd1\x03\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
 	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1a\n" +
 	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"#\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1b\n" +
+	"\tfull_name\x18\a \x01(\tR\bfullName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\b \x01(\tR\tavatarUrl\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12\x18\n" +
+	"\acompany\x18\v \x01(\tR\acompany\x12&\n" +
+	"\x0especialization\x18\f \x01(\tR\x0especialization\x12;\n" +
+	"\vupdate_mask\x18\r \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x0e \x01(\tR\x04etag\x12)\n" +
+	"\x10current_password\x18\x0f \x01(\tR\x0fcurrentPassword\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
 	"\x12DeleteUserResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"@\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"$\n" +
+	"\x12RestoreUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
+	"\x10PurgeUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x11PurgeUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf2\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"l\n" +
+	"\x17ListAuditEventsResponse\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.user_service.AuditEventR\x06events\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xe7\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x1f\n" +
+	"\vtarget_type\x18\x04 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x05 \x01(\tR\btargetId\x121\n" +
+	"\achanges\x18\x06 \x01(\v2\x17.google.protobuf.StructR\achanges\x12\x1b\n" +
+	"\tclient_ip\x18\a \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06source\x18\n" +
+	" \x01(\tR\x06source\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\"P\n" +
+	"\x12SetUserRoleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"]\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x8a\x02\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"\x86\x05\n" +
 	"\fUserResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -1825,11 +5086,43 @@ const file_user_service_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
-	"\x05error\x18\b \x01(\tR\x05error\"\x90\x01\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12%\n" +
+	"\x0eemail_verified\x18\t \x01(\bR\remailVerified\x12\x1f\n" +
+	"\vmfa_enabled\x18\n" +
+	" \x01(\bR\n" +
+	"mfaEnabled\x12\x12\n" +
+	"\x04etag\x18\v \x01(\tR\x04etag\x12\x12\n" +
+	"\x04role\x18\f \x01(\tR\x04role\x12\x1b\n" +
+	"\tis_active\x18\r \x01(\bR\bisActive\x12!\n" +
+	"\fmax_sessions\x18\x0e \x01(\x05R\vmaxSessions\x12%\n" +
+	"\x0etotal_sessions\x18\x0f \x01(\x05R\rtotalSessions\x123\n" +
+	"\aprofile\x18\x10 \x01(\v2\x19.user_service.UserProfileR\aprofile\x126\n" +
+	"\boperator\x18\x11 \x01(\v2\x1a.user_service.OperatorInfoR\boperator\x126\n" +
+	"\bpresence\x18\x12 \x01(\v2\x1a.user_service.UserPresenceR\bpresence\"\xc3\x01\n" +
+	"\vUserProfile\x12\x1b\n" +
+	"\tfull_name\x18\x01 \x01(\tR\bfullName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x02 \x01(\tR\tavatarUrl\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x18\n" +
+	"\acompany\x18\x05 \x01(\tR\acompany\x12&\n" +
+	"\x0especialization\x18\x06 \x01(\tR\x0especialization\"a\n" +
+	"\fOperatorInfo\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
+	"\fis_available\x18\x02 \x01(\bR\visAvailable\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x01R\x06rating\"\xe5\x01\n" +
+	"\fUserPresence\x12\x1b\n" +
+	"\tis_online\x18\x01 \x01(\bR\bisOnline\x12<\n" +
+	"\flast_seen_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x12?\n" +
+	"\rlast_activity\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x129\n" +
+	"\n" +
+	"last_login\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tlastLogin\"\xb3\x01\n" +
 	"\x1aValidateUserSessionRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
 	"\x13session_external_id\x18\x02 \x01(\tR\x11sessionExternalId\x12)\n" +
-	"\x10participant_role\x18\x03 \x01(\tR\x0fparticipantRole\"M\n" +
+	"\x10participant_role\x18\x03 \x01(\tR\x0fparticipantRole\x12!\n" +
+	"\fsession_type\x18\x04 \x01(\tR\vsessionType\"M\n" +
 	"\x1bValidateUserSessionResponse\x12\x18\n" +
 	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"Q\n" +
@@ -1851,27 +5144,58 @@ const file_user_service_proto_rawDesc = "" +
 	"\fis_available\x18\x02 \x01(\bR\visAvailable\"N\n" +
 	"\x1cUpdateOperatorStatusResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xa5\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xe5\x01\n" +
 	"\fAuthResponse\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
 	"\n" +
 	"expires_in\x18\x03 \x01(\x05R\texpiresIn\x12.\n" +
-	"\x04user\x18\x04 \x01(\v2\x1a.user_service.UserResponseR\x04user\"s\n" +
+	"\x04user\x18\x04 \x01(\v2\x1a.user_service.UserResponseR\x04user\x12!\n" +
+	"\fmfa_required\x18\x05 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x06 \x01(\tR\bmfaToken\"\x90\x01\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
 	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x12\n" +
-	"\x04role\x18\x04 \x01(\tR\x04role\"5\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x1b\n" +
+	"\tdevice_id\x18\x05 \x01(\tR\bdeviceId\"5\n" +
 	"\x0eRefreshRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x0f\n" +
-	"\rLogoutRequest\"\x10\n" +
-	"\x0eLogoutResponse\"\x0e\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"4\n" +
+	"\rLogoutRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x10\n" +
+	"\x0eLogoutResponse\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1e\n" +
+	"\x1cRequestPasswordResetResponse\"V\n" +
+	"\x1bConfirmPasswordResetRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x1e\n" +
+	"\x1cConfirmPasswordResetResponse\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"1\n" +
+	"\x19ResendVerificationRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1c\n" +
+	"\x1aResendVerificationResponse\"H\n" +
+	"\x15LoginVerifyMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x12\n" +
+	"\x10EnrollMFARequest\"L\n" +
+	"\x11EnrollMFAResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"'\n" +
+	"\x11ConfirmMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\";\n" +
+	"\x12ConfirmMFAResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"'\n" +
+	"\x11DisableMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x14\n" +
+	"\x12DisableMFAResponse\"\x0e\n" +
 	"\fGetMeRequest\"V\n" +
 	"\x16GetUserSessionsRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xaa\x02\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xbb\x03\n" +
 	"\x13UserSessionResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
@@ -1879,7 +5203,11 @@ const file_user_service_proto_rawDesc = "" +
 	"\x13session_external_id\x18\x04 \x01(\tR\x11sessionExternalId\x12)\n" +
 	"\x10participant_role\x18\x05 \x01(\tR\x0fparticipantRole\x127\n" +
 	"\tjoined_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\x123\n" +
-	"\aleft_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06leftAt\"n\n" +
+	"\aleft_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06leftAt\x12)\n" +
+	"\x10duration_seconds\x18\b \x01(\x05R\x0fdurationSeconds\x12/\n" +
+	"\x13consultation_rating\x18\t \x01(\x05R\x12consultationRating\x123\n" +
+	"\x15consultation_feedback\x18\n" +
+	" \x01(\tR\x14consultationFeedback\"n\n" +
 	"\x17GetUserSessionsResponse\x12=\n" +
 	"\bsessions\x18\x01 \x03(\v2!.user_service.UserSessionResponseR\bsessions\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\"*\n" +
@@ -1891,7 +5219,152 @@ const file_user_service_proto_rawDesc = "" +
 	"\x02id\x18\x04 \x01(\tR\x02id\x12!\n" +
 	"\fsession_type\x18\x01 \x01(\tR\vsessionType\x12.\n" +
 	"\x13session_external_id\x18\x02 \x01(\tR\x11sessionExternalId\x12)\n" +
-	"\x10participant_role\x18\x03 \x01(\tR\x0fparticipantRole\"?\n" +
+	"\x10participant_role\x18\x03 \x01(\tR\x0fparticipantRole\"B\n" +
+	"\x11EndSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"P\n" +
+	"\x1eEndSessionsByExternalIDRequest\x12.\n" +
+	"\x13session_external_id\x18\x01 \x01(\tR\x11sessionExternalId\"M\n" +
+	"\x1fEndSessionsByExternalIDResponse\x12\x14\n" +
+	"\x05ended\x18\x01 \x01(\x03R\x05ended\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"l\n" +
+	"\x17RateConsultationRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x05R\x06rating\x12\x1a\n" +
+	"\bfeedback\x18\x03 \x01(\tR\bfeedback\"\xb7\x04\n" +
+	"\x0eDeviceResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vdevice_type\x18\x04 \x01(\tR\n" +
+	"deviceType\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x06 \x01(\tR\tipAddress\x12#\n" +
+	"\rconnection_id\x18\a \x01(\tR\fconnectionId\x12!\n" +
+	"\fis_connected\x18\b \x01(\bR\visConnected\x12A\n" +
+	"\x0elast_heartbeat\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rlastHeartbeat\x12'\n" +
+	"\x0fsupports_webrtc\x18\n" +
+	" \x01(\bR\x0esupportsWebrtc\x12-\n" +
+	"\x12supports_websocket\x18\v \x01(\bR\x11supportsWebsocket\x12'\n" +
+	"\x0fbandwidth_limit\x18\f \x01(\x05R\x0ebandwidthLimit\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x94\x02\n" +
+	"\x15RegisterDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vdevice_type\x18\x02 \x01(\tR\n" +
+	"deviceType\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12'\n" +
+	"\x0fsupports_webrtc\x18\x05 \x01(\bR\x0esupportsWebrtc\x12-\n" +
+	"\x12supports_websocket\x18\x06 \x01(\bR\x11supportsWebsocket\x12'\n" +
+	"\x0fbandwidth_limit\x18\a \x01(\x05R\x0ebandwidthLimit\"\x16\n" +
+	"\x14ListMyDevicesRequest\"M\n" +
+	"\x13ListDevicesResponse\x126\n" +
+	"\adevices\x18\x01 \x03(\v2\x1c.user_service.DeviceResponseR\adevices\"2\n" +
+	"\x13RemoveDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"0\n" +
+	"\x14RemoveDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xaf\x01\n" +
+	"\x14ConnectDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12#\n" +
+	"\rconnection_id\x18\x03 \x01(\tR\fconnectionId\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\">\n" +
+	"\x17DisconnectDeviceRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"=\n" +
+	"\x16DeviceHeartbeatRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\"\x7f\n" +
+	"\x0fServiceSchedule\x12\x16\n" +
+	"\x06always\x18\x01 \x01(\bR\x06always\x12\x1a\n" +
+	"\bweekdays\x18\x02 \x03(\tR\bweekdays\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\tR\aendTime\"\xfa\x05\n" +
+	"\x10UserServiceRoute\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12!\n" +
+	"\fservice_type\x18\x04 \x01(\tR\vserviceType\x12\x19\n" +
+	"\bbase_url\x18\x05 \x01(\tR\abaseUrl\x12!\n" +
+	"\fapi_endpoint\x18\x06 \x01(\tR\vapiEndpoint\x12\x12\n" +
+	"\x04port\x18\a \x01(\x05R\x04port\x12\x17\n" +
+	"\ause_ssl\x18\b \x01(\bR\x06useSsl\x120\n" +
+	"\x14ssl_certificate_path\x18\t \x01(\tR\x12sslCertificatePath\x12\x1f\n" +
+	"\vrouting_key\x18\n" +
+	" \x01(\tR\n" +
+	"routingKey\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\v \x01(\tR\tqueueName\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\f \x01(\tR\ttopicName\x12\x1f\n" +
+	"\vmax_bitrate\x18\r \x01(\x05R\n" +
+	"maxBitrate\x12'\n" +
+	"\x0fmax_connections\x18\x0e \x01(\x05R\x0emaxConnections\x12\x1a\n" +
+	"\bpriority\x18\x0f \x01(\x05R\bpriority\x12\x1b\n" +
+	"\tis_active\x18\x10 \x01(\bR\bisActive\x12'\n" +
+	"\x0fenabled_buttons\x18\x11 \x03(\tR\x0eenabledButtons\x129\n" +
+	"\bschedule\x18\x12 \x01(\v2\x1d.user_service.ServiceScheduleR\bschedule\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x13 \x01(\tR\n" +
+	"parameters\x129\n" +
+	"\n" +
+	"created_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdb\x05\n" +
+	"\x17UserServiceRouteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12!\n" +
+	"\fservice_type\x18\x04 \x01(\tR\vserviceType\x12\x19\n" +
+	"\bbase_url\x18\x05 \x01(\tR\abaseUrl\x12!\n" +
+	"\fapi_endpoint\x18\x06 \x01(\tR\vapiEndpoint\x12\x12\n" +
+	"\x04port\x18\a \x01(\x05R\x04port\x12\x17\n" +
+	"\ause_ssl\x18\b \x01(\bR\x06useSsl\x120\n" +
+	"\x14ssl_certificate_path\x18\t \x01(\tR\x12sslCertificatePath\x12\x1f\n" +
+	"\vrouting_key\x18\n" +
+	" \x01(\tR\n" +
+	"routingKey\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\v \x01(\tR\tqueueName\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\f \x01(\tR\ttopicName\x12\x1f\n" +
+	"\vmax_bitrate\x18\r \x01(\x05R\n" +
+	"maxBitrate\x12'\n" +
+	"\x0fmax_connections\x18\x0e \x01(\x05R\x0emaxConnections\x12\x1a\n" +
+	"\bpriority\x18\x0f \x01(\x05R\bpriority\x12 \n" +
+	"\tis_active\x18\x10 \x01(\bH\x00R\bisActive\x88\x01\x01\x12'\n" +
+	"\x0fenabled_buttons\x18\x11 \x03(\tR\x0eenabledButtons\x129\n" +
+	"\bschedule\x18\x12 \x01(\v2\x1d.user_service.ServiceScheduleR\bschedule\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x13 \x01(\tR\n" +
+	"parameters\x12;\n" +
+	"\vupdate_mask\x18\x14 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\f\n" +
+	"\n" +
+	"_is_active\"7\n" +
+	"\x1cListUserServiceRoutesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"[\n" +
+	"\x1dListUserServiceRoutesResponse\x12:\n" +
+	"\bservices\x18\x01 \x03(\v2\x1e.user_service.UserServiceRouteR\bservices\"H\n" +
+	"\x1dDeleteUserServiceRouteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\":\n" +
+	"\x1eDeleteUserServiceRouteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Q\n" +
+	"\x1eResolveUserServiceRouteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06button\x18\x02 \x01(\tR\x06button\"?\n" +
 	"\x15VerifyOperatorRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\"\x19\n" +
@@ -1899,24 +5372,55 @@ const file_user_service_proto_rawDesc = "" +
 	"\x18GetOperatorStatsResponse\x12%\n" +
 	"\x0etotal_sessions\x18\x01 \x01(\x03R\rtotalSessions\x12\x16\n" +
 	"\x06rating\x18\x02 \x01(\x01R\x06rating\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error2\xa2\x13\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error2\xb41\n" +
 	"\vUserService\x12c\n" +
 	"\n" +
-	"CreateUser\x12\x1f.user_service.CreateUserRequest\x1a\x1a.user_service.UserResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12_\n" +
+	"CreateUser\x12\x1f.user_service.CreateUserRequest\x1a\x1a.user_service.UserResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12c\n" +
+	"\tListUsers\x12\x1e.user_service.ListUsersRequest\x1a\x1f.user_service.ListUsersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/users\x12_\n" +
 	"\aGetUser\x12\x1c.user_service.GetUserRequest\x1a\x1a.user_service.UserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/users/{id}\x12h\n" +
+	"\vGetUserCard\x12\x1c.user_service.GetUserRequest\x1a\x1a.user_service.UserResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/users/{id}/card\x12\x81\x01\n" +
 	"\n" +
-	"UpdateUser\x12\x1f.user_service.UpdateUserRequest\x1a\x1a.user_service.UserResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/api/v1/users/{id}\x12k\n" +
+	"UpdateUser\x12\x1f.user_service.UpdateUserRequest\x1a\x1a.user_service.UserResponse\"6\x82\xd3\xe4\x93\x020:\x01*Z\x17:\x01*2\x12/api/v1/users/{id}\x1a\x12/api/v1/users/{id}\x12k\n" +
 	"\n" +
-	"DeleteUser\x12\x1f.user_service.DeleteUserRequest\x1a .user_service.DeleteUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/users/{id}\x12^\n" +
+	"DeleteUser\x12\x1f.user_service.DeleteUserRequest\x1a .user_service.DeleteUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/users/{id}\x12r\n" +
+	"\vRestoreUser\x12 .user_service.RestoreUserRequest\x1a\x1a.user_service.UserResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/users/{id}/restore\x12q\n" +
+	"\tPurgeUser\x12\x1e.user_service.PurgeUserRequest\x1a\x1f.user_service.PurgeUserResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/users/{id}/purge\x12|\n" +
+	"\x0fListAuditEvents\x12$.user_service.ListAuditEventsRequest\x1a%.user_service.ListAuditEventsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/audit-events\x12o\n" +
+	"\vSetUserRole\x12 .user_service.SetUserRoleRequest\x1a\x1a.user_service.UserResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/users/{id}/role\x12^\n" +
 	"\x05Login\x12\x1a.user_service.LoginRequest\x1a\x1a.user_service.AuthResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12g\n" +
 	"\bRegister\x12\x1d.user_service.RegisterRequest\x1a\x1a.user_service.AuthResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/register\x12d\n" +
 	"\aRefresh\x12\x1c.user_service.RefreshRequest\x1a\x1a.user_service.AuthResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/auth/refresh\x12c\n" +
-	"\x06Logout\x12\x1b.user_service.LogoutRequest\x1a\x1c.user_service.LogoutResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/auth/logout\x12Y\n" +
-	"\x05GetMe\x12\x1a.user_service.GetMeRequest\x1a\x1a.user_service.UserResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/users/me\x12d\n" +
-	"\bUpdateMe\x12\x1f.user_service.UpdateUserRequest\x1a\x1a.user_service.UserResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\x1a\x10/api/v1/users/me\x12\x83\x01\n" +
+	"\x06Logout\x12\x1b.user_service.LogoutRequest\x1a\x1c.user_service.LogoutResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/auth/logout\x12t\n" +
+	"\x0eLoginVerifyMFA\x12#.user_service.LoginVerifyMFARequest\x1a\x1a.user_service.AuthResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/auth/login/mfa\x12\x95\x01\n" +
+	"\x14RequestPasswordReset\x12).user_service.RequestPasswordResetRequest\x1a*.user_service.RequestPasswordResetResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/auth/password/reset\x12\x9d\x01\n" +
+	"\x14ConfirmPasswordReset\x12).user_service.ConfirmPasswordResetRequest\x1a*.user_service.ConfirmPasswordResetResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/auth/password/reset/confirm\x12q\n" +
+	"\vVerifyEmail\x12 .user_service.VerifyEmailRequest\x1a\x1a.user_service.UserResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/auth/email/verify\x12\x94\x01\n" +
+	"\x12ResendVerification\x12'.user_service.ResendVerificationRequest\x1a(.user_service.ResendVerificationResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/auth/email/verify/resend\x12t\n" +
+	"\tEnrollMFA\x12\x1e.user_service.EnrollMFARequest\x1a\x1f.user_service.EnrollMFAResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/users/me/mfa/enroll\x12x\n" +
+	"\n" +
+	"ConfirmMFA\x12\x1f.user_service.ConfirmMFARequest\x1a .user_service.ConfirmMFAResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/users/me/mfa/confirm\x12x\n" +
+	"\n" +
+	"DisableMFA\x12\x1f.user_service.DisableMFARequest\x1a .user_service.DisableMFAResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/users/me/mfa/disable\x12Y\n" +
+	"\x05GetMe\x12\x1a.user_service.GetMeRequest\x1a\x1a.user_service.UserResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/users/me\x12{\n" +
+	"\bUpdateMe\x12\x1f.user_service.UpdateUserRequest\x1a\x1a.user_service.UserResponse\"2\x82\xd3\xe4\x93\x02,:\x01*Z\x15:\x01*2\x10/api/v1/users/me\x1a\x10/api/v1/users/me\x12\x83\x01\n" +
 	"\x0fGetUserSessions\x12$.user_service.GetUserSessionsRequest\x1a%.user_service.GetUserSessionsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/users/{id}/sessions\x12\x90\x01\n" +
 	"\x11GetActiveSessions\x12&.user_service.GetActiveSessionsRequest\x1a'.user_service.GetActiveSessionsResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1/users/{id}/active-sessions\x12~\n" +
-	"\rCreateSession\x12\".user_service.CreateSessionRequest\x1a!.user_service.UserSessionResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/users/{id}/sessions\x12\x9e\x01\n" +
+	"\rCreateSession\x12\".user_service.CreateSessionRequest\x1a!.user_service.UserSessionResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/users/{id}/sessions\x12\x89\x01\n" +
+	"\n" +
+	"EndSession\x12\x1f.user_service.EndSessionRequest\x1a!.user_service.UserSessionResponse\"7\x82\xd3\xe4\x93\x021:\x01*\",/api/v1/users/{id}/sessions/{session_id}/end\x12\xad\x01\n" +
+	"\x17EndSessionsByExternalID\x12,.user_service.EndSessionsByExternalIDRequest\x1a-.user_service.EndSessionsByExternalIDResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/api/v1/sessions/{session_external_id}/end\x12\x96\x01\n" +
+	"\x10RateConsultation\x12%.user_service.RateConsultationRequest\x1a!.user_service.UserSessionResponse\"8\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/users/me/sessions/{session_id}/rating\x12x\n" +
+	"\x0eRegisterDevice\x12#.user_service.RegisterDeviceRequest\x1a\x1c.user_service.DeviceResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/users/me/devices\x12x\n" +
+	"\rListMyDevices\x12\".user_service.ListMyDevicesRequest\x1a!.user_service.ListDevicesResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/users/me/devices\x12\x83\x01\n" +
+	"\fRemoveDevice\x12!.user_service.RemoveDeviceRequest\x1a\".user_service.RemoveDeviceResponse\",\x82\xd3\xe4\x93\x02&*$/api/v1/users/me/devices/{device_id}\x12u\n" +
+	"\rConnectDevice\x12\".user_service.ConnectDeviceRequest\x1a\x1c.user_service.DeviceResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/devices/connect\x12\x8e\x01\n" +
+	"\x10DisconnectDevice\x12%.user_service.DisconnectDeviceRequest\x1a\x1c.user_service.DeviceResponse\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/api/v1/devices/{connection_id}/disconnect\x12\x8b\x01\n" +
+	"\x0fDeviceHeartbeat\x12$.user_service.DeviceHeartbeatRequest\x1a\x1c.user_service.DeviceResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/api/v1/devices/{connection_id}/heartbeat\x12\x9a\x01\n" +
+	"\x15ListUserServiceRoutes\x12*.user_service.ListUserServiceRoutesRequest\x1a+.user_service.ListUserServiceRoutesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/users/{user_id}/services\x12\x8c\x01\n" +
+	"\x16CreateUserServiceRoute\x12%.user_service.UserServiceRouteRequest\x1a\x1e.user_service.UserServiceRoute\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/users/{user_id}/services\x12\xbd\x01\n" +
+	"\x16UpdateUserServiceRoute\x12%.user_service.UserServiceRouteRequest\x1a\x1e.user_service.UserServiceRoute\"\\\x82\xd3\xe4\x93\x02V:\x01*Z*:\x01*2%/api/v1/users/{user_id}/services/{id}\x1a%/api/v1/users/{user_id}/services/{id}\x12\xa2\x01\n" +
+	"\x16DeleteUserServiceRoute\x12+.user_service.DeleteUserServiceRouteRequest\x1a,.user_service.DeleteUserServiceRouteResponse\"-\x82\xd3\xe4\x93\x02'*%/api/v1/users/{user_id}/services/{id}\x12\xa1\x01\n" +
+	"\x17ResolveUserServiceRoute\x12,.user_service.ResolveUserServiceRouteRequest\x1a\x1e.user_service.UserServiceRoute\"8\x82\xd3\xe4\x93\x022\x120/api/v1/users/{user_id}/buttons/{button}/service\x12\x9e\x01\n" +
 	"\x1aUpdateOperatorAvailability\x12).user_service.UpdateOperatorStatusRequest\x1a*.user_service.UpdateOperatorStatusResponse\")\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/api/v1/operators/availability\x12{\n" +
 	"\x0eVerifyOperator\x12#.user_service.VerifyOperatorRequest\x1a\x1a.user_service.UserResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/operators/{id}/verify\x12\x82\x01\n" +
 	"\x10GetOperatorStats\x12%.user_service.GetOperatorStatsRequest\x1a&.user_service.GetOperatorStatsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/operators/stats\x12\x90\x01\n" +
@@ -1937,97 +5441,227 @@ func file_user_service_proto_rawDescGZIP() []byte {
 	return file_user_service_proto_rawDescData
 }
 
-var file_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
 var file_user_service_proto_goTypes = []any{
-	(*User)(nil),                          // 0: user_service.User
-	(*CreateUserRequest)(nil),             // 1: user_service.CreateUserRequest
-	(*GetUserRequest)(nil),                // 2: user_service.GetUserRequest
-	(*UpdateUserRequest)(nil),             // 3: user_service.UpdateUserRequest
-	(*DeleteUserRequest)(nil),             // 4: user_service.DeleteUserRequest
-	(*DeleteUserResponse)(nil),            // 5: user_service.DeleteUserResponse
-	(*LoginRequest)(nil),                  // 6: user_service.LoginRequest
-	(*UserResponse)(nil),                  // 7: user_service.UserResponse
-	(*ValidateUserSessionRequest)(nil),    // 8: user_service.ValidateUserSessionRequest
-	(*ValidateUserSessionResponse)(nil),   // 9: user_service.ValidateUserSessionResponse
-	(*UpdateUserPresenceRequest)(nil),     // 10: user_service.UpdateUserPresenceRequest
-	(*UpdateUserPresenceResponse)(nil),    // 11: user_service.UpdateUserPresenceResponse
-	(*GetAvailableOperatorsRequest)(nil),  // 12: user_service.GetAvailableOperatorsRequest
-	(*GetAvailableOperatorsResponse)(nil), // 13: user_service.GetAvailableOperatorsResponse
-	(*UpdateOperatorStatusRequest)(nil),   // 14: user_service.UpdateOperatorStatusRequest
-	(*UpdateOperatorStatusResponse)(nil),  // 15: user_service.UpdateOperatorStatusResponse
-	(*AuthResponse)(nil),                  // 16: user_service.AuthResponse
-	(*RegisterRequest)(nil),               // 17: user_service.RegisterRequest
-	(*RefreshRequest)(nil),                // 18: user_service.RefreshRequest
-	(*LogoutRequest)(nil),                 // 19: user_service.LogoutRequest
-	(*LogoutResponse)(nil),                // 20: user_service.LogoutResponse
-	(*GetMeRequest)(nil),                  // 21: user_service.GetMeRequest
-	(*GetUserSessionsRequest)(nil),        // 22: user_service.GetUserSessionsRequest
-	(*UserSessionResponse)(nil),           // 23: user_service.UserSessionResponse
-	(*GetUserSessionsResponse)(nil),       // 24: user_service.GetUserSessionsResponse
-	(*GetActiveSessionsRequest)(nil),      // 25: user_service.GetActiveSessionsRequest
-	(*GetActiveSessionsResponse)(nil),     // 26: user_service.GetActiveSessionsResponse
-	(*CreateSessionRequest)(nil),          // 27: user_service.CreateSessionRequest
-	(*VerifyOperatorRequest)(nil),         // 28: user_service.VerifyOperatorRequest
-	(*GetOperatorStatsRequest)(nil),       // 29: user_service.GetOperatorStatsRequest
-	(*GetOperatorStatsResponse)(nil),      // 30: user_service.GetOperatorStatsResponse
-	(*timestamppb.Timestamp)(nil),         // 31: google.protobuf.Timestamp
+	(*User)(nil),                            // 0: user_service.User
+	(*CreateUserRequest)(nil),               // 1: user_service.CreateUserRequest
+	(*GetUserRequest)(nil),                  // 2: user_service.GetUserRequest
+	(*ListUsersRequest)(nil),                // 3: user_service.ListUsersRequest
+	(*ListUsersResponse)(nil),               // 4: user_service.ListUsersResponse
+	(*UpdateUserRequest)(nil),               // 5: user_service.UpdateUserRequest
+	(*DeleteUserRequest)(nil),               // 6: user_service.DeleteUserRequest
+	(*DeleteUserResponse)(nil),              // 7: user_service.DeleteUserResponse
+	(*RestoreUserRequest)(nil),              // 8: user_service.RestoreUserRequest
+	(*PurgeUserRequest)(nil),                // 9: user_service.PurgeUserRequest
+	(*PurgeUserResponse)(nil),               // 10: user_service.PurgeUserResponse
+	(*ListAuditEventsRequest)(nil),          // 11: user_service.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),         // 12: user_service.ListAuditEventsResponse
+	(*AuditEvent)(nil),                      // 13: user_service.AuditEvent
+	(*SetUserRoleRequest)(nil),              // 14: user_service.SetUserRoleRequest
+	(*LoginRequest)(nil),                    // 15: user_service.LoginRequest
+	(*UserResponse)(nil),                    // 16: user_service.UserResponse
+	(*UserProfile)(nil),                     // 17: user_service.UserProfile
+	(*OperatorInfo)(nil),                    // 18: user_service.OperatorInfo
+	(*UserPresence)(nil),                    // 19: user_service.UserPresence
+	(*ValidateUserSessionRequest)(nil),      // 20: user_service.ValidateUserSessionRequest
+	(*ValidateUserSessionResponse)(nil),     // 21: user_service.ValidateUserSessionResponse
+	(*UpdateUserPresenceRequest)(nil),       // 22: user_service.UpdateUserPresenceRequest
+	(*UpdateUserPresenceResponse)(nil),      // 23: user_service.UpdateUserPresenceResponse
+	(*GetAvailableOperatorsRequest)(nil),    // 24: user_service.GetAvailableOperatorsRequest
+	(*GetAvailableOperatorsResponse)(nil),   // 25: user_service.GetAvailableOperatorsResponse
+	(*UpdateOperatorStatusRequest)(nil),     // 26: user_service.UpdateOperatorStatusRequest
+	(*UpdateOperatorStatusResponse)(nil),    // 27: user_service.UpdateOperatorStatusResponse
+	(*AuthResponse)(nil),                    // 28: user_service.AuthResponse
+	(*RegisterRequest)(nil),                 // 29: user_service.RegisterRequest
+	(*RefreshRequest)(nil),                  // 30: user_service.RefreshRequest
+	(*LogoutRequest)(nil),                   // 31: user_service.LogoutRequest
+	(*LogoutResponse)(nil),                  // 32: user_service.LogoutResponse
+	(*RequestPasswordResetRequest)(nil),     // 33: user_service.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),    // 34: user_service.RequestPasswordResetResponse
+	(*ConfirmPasswordResetRequest)(nil),     // 35: user_service.ConfirmPasswordResetRequest
+	(*ConfirmPasswordResetResponse)(nil),    // 36: user_service.ConfirmPasswordResetResponse
+	(*VerifyEmailRequest)(nil),              // 37: user_service.VerifyEmailRequest
+	(*ResendVerificationRequest)(nil),       // 38: user_service.ResendVerificationRequest
+	(*ResendVerificationResponse)(nil),      // 39: user_service.ResendVerificationResponse
+	(*LoginVerifyMFARequest)(nil),           // 40: user_service.LoginVerifyMFARequest
+	(*EnrollMFARequest)(nil),                // 41: user_service.EnrollMFARequest
+	(*EnrollMFAResponse)(nil),               // 42: user_service.EnrollMFAResponse
+	(*ConfirmMFARequest)(nil),               // 43: user_service.ConfirmMFARequest
+	(*ConfirmMFAResponse)(nil),              // 44: user_service.ConfirmMFAResponse
+	(*DisableMFARequest)(nil),               // 45: user_service.DisableMFARequest
+	(*DisableMFAResponse)(nil),              // 46: user_service.DisableMFAResponse
+	(*GetMeRequest)(nil),                    // 47: user_service.GetMeRequest
+	(*GetUserSessionsRequest)(nil),          // 48: user_service.GetUserSessionsRequest
+	(*UserSessionResponse)(nil),             // 49: user_service.UserSessionResponse
+	(*GetUserSessionsResponse)(nil),         // 50: user_service.GetUserSessionsResponse
+	(*GetActiveSessionsRequest)(nil),        // 51: user_service.GetActiveSessionsRequest
+	(*GetActiveSessionsResponse)(nil),       // 52: user_service.GetActiveSessionsResponse
+	(*CreateSessionRequest)(nil),            // 53: user_service.CreateSessionRequest
+	(*EndSessionRequest)(nil),               // 54: user_service.EndSessionRequest
+	(*EndSessionsByExternalIDRequest)(nil),  // 55: user_service.EndSessionsByExternalIDRequest
+	(*EndSessionsByExternalIDResponse)(nil), // 56: user_service.EndSessionsByExternalIDResponse
+	(*RateConsultationRequest)(nil),         // 57: user_service.RateConsultationRequest
+	(*DeviceResponse)(nil),                  // 58: user_service.DeviceResponse
+	(*RegisterDeviceRequest)(nil),           // 59: user_service.RegisterDeviceRequest
+	(*ListMyDevicesRequest)(nil),            // 60: user_service.ListMyDevicesRequest
+	(*ListDevicesResponse)(nil),             // 61: user_service.ListDevicesResponse
+	(*RemoveDeviceRequest)(nil),             // 62: user_service.RemoveDeviceRequest
+	(*RemoveDeviceResponse)(nil),            // 63: user_service.RemoveDeviceResponse
+	(*ConnectDeviceRequest)(nil),            // 64: user_service.ConnectDeviceRequest
+	(*DisconnectDeviceRequest)(nil),         // 65: user_service.DisconnectDeviceRequest
+	(*DeviceHeartbeatRequest)(nil),          // 66: user_service.DeviceHeartbeatRequest
+	(*ServiceSchedule)(nil),                 // 67: user_service.ServiceSchedule
+	(*UserServiceRoute)(nil),                // 68: user_service.UserServiceRoute
+	(*UserServiceRouteRequest)(nil),         // 69: user_service.UserServiceRouteRequest
+	(*ListUserServiceRoutesRequest)(nil),    // 70: user_service.ListUserServiceRoutesRequest
+	(*ListUserServiceRoutesResponse)(nil),   // 71: user_service.ListUserServiceRoutesResponse
+	(*DeleteUserServiceRouteRequest)(nil),   // 72: user_service.DeleteUserServiceRouteRequest
+	(*DeleteUserServiceRouteResponse)(nil),  // 73: user_service.DeleteUserServiceRouteResponse
+	(*ResolveUserServiceRouteRequest)(nil),  // 74: user_service.ResolveUserServiceRouteRequest
+	(*VerifyOperatorRequest)(nil),           // 75: user_service.VerifyOperatorRequest
+	(*GetOperatorStatsRequest)(nil),         // 76: user_service.GetOperatorStatsRequest
+	(*GetOperatorStatsResponse)(nil),        // 77: user_service.GetOperatorStatsResponse
+	(*timestamppb.Timestamp)(nil),           // 78: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),           // 79: google.protobuf.FieldMask
+	(*structpb.Struct)(nil),                 // 80: google.protobuf.Struct
 }
 var file_user_service_proto_depIdxs = []int32{
-	31, // 0: user_service.User.created_at:type_name -> google.protobuf.Timestamp
-	31, // 1: user_service.User.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 2: user_service.UserResponse.created_at:type_name -> google.protobuf.Timestamp
-	31, // 3: user_service.UserResponse.updated_at:type_name -> google.protobuf.Timestamp
-	7,  // 4: user_service.GetAvailableOperatorsResponse.operators:type_name -> user_service.UserResponse
-	7,  // 5: user_service.AuthResponse.user:type_name -> user_service.UserResponse
-	31, // 6: user_service.UserSessionResponse.joined_at:type_name -> google.protobuf.Timestamp
-	31, // 7: user_service.UserSessionResponse.left_at:type_name -> google.protobuf.Timestamp
-	23, // 8: user_service.GetUserSessionsResponse.sessions:type_name -> user_service.UserSessionResponse
-	23, // 9: user_service.GetActiveSessionsResponse.sessions:type_name -> user_service.UserSessionResponse
-	1,  // 10: user_service.UserService.CreateUser:input_type -> user_service.CreateUserRequest
-	2,  // 11: user_service.UserService.GetUser:input_type -> user_service.GetUserRequest
-	3,  // 12: user_service.UserService.UpdateUser:input_type -> user_service.UpdateUserRequest
-	4,  // 13: user_service.UserService.DeleteUser:input_type -> user_service.DeleteUserRequest
-	6,  // 14: user_service.UserService.Login:input_type -> user_service.LoginRequest
-	17, // 15: user_service.UserService.Register:input_type -> user_service.RegisterRequest
-	18, // 16: user_service.UserService.Refresh:input_type -> user_service.RefreshRequest
-	19, // 17: user_service.UserService.Logout:input_type -> user_service.LogoutRequest
-	21, // 18: user_service.UserService.GetMe:input_type -> user_service.GetMeRequest
-	3,  // 19: user_service.UserService.UpdateMe:input_type -> user_service.UpdateUserRequest
-	22, // 20: user_service.UserService.GetUserSessions:input_type -> user_service.GetUserSessionsRequest
-	25, // 21: user_service.UserService.GetActiveSessions:input_type -> user_service.GetActiveSessionsRequest
-	27, // 22: user_service.UserService.CreateSession:input_type -> user_service.CreateSessionRequest
-	14, // 23: user_service.UserService.UpdateOperatorAvailability:input_type -> user_service.UpdateOperatorStatusRequest
-	28, // 24: user_service.UserService.VerifyOperator:input_type -> user_service.VerifyOperatorRequest
-	29, // 25: user_service.UserService.GetOperatorStats:input_type -> user_service.GetOperatorStatsRequest
-	8,  // 26: user_service.UserService.ValidateUserSession:input_type -> user_service.ValidateUserSessionRequest
-	10, // 27: user_service.UserService.UpdateUserPresence:input_type -> user_service.UpdateUserPresenceRequest
-	12, // 28: user_service.UserService.GetAvailableOperators:input_type -> user_service.GetAvailableOperatorsRequest
-	14, // 29: user_service.UserService.UpdateOperatorStatus:input_type -> user_service.UpdateOperatorStatusRequest
-	7,  // 30: user_service.UserService.CreateUser:output_type -> user_service.UserResponse
-	7,  // 31: user_service.UserService.GetUser:output_type -> user_service.UserResponse
-	7,  // 32: user_service.UserService.UpdateUser:output_type -> user_service.UserResponse
-	5,  // 33: user_service.UserService.DeleteUser:output_type -> user_service.DeleteUserResponse
-	16, // 34: user_service.UserService.Login:output_type -> user_service.AuthResponse
-	16, // 35: user_service.UserService.Register:output_type -> user_service.AuthResponse
-	16, // 36: user_service.UserService.Refresh:output_type -> user_service.AuthResponse
-	20, // 37: user_service.UserService.Logout:output_type -> user_service.LogoutResponse
-	7,  // 38: user_service.UserService.GetMe:output_type -> user_service.UserResponse
-	7,  // 39: user_service.UserService.UpdateMe:output_type -> user_service.UserResponse
-	24, // 40: user_service.UserService.GetUserSessions:output_type -> user_service.GetUserSessionsResponse
-	26, // 41: user_service.UserService.GetActiveSessions:output_type -> user_service.GetActiveSessionsResponse
-	23, // 42: user_service.UserService.CreateSession:output_type -> user_service.UserSessionResponse
-	15, // 43: user_service.UserService.UpdateOperatorAvailability:output_type -> user_service.UpdateOperatorStatusResponse
-	7,  // 44: user_service.UserService.VerifyOperator:output_type -> user_service.UserResponse
-	30, // 45: user_service.UserService.GetOperatorStats:output_type -> user_service.GetOperatorStatsResponse
-	9,  // 46: user_service.UserService.ValidateUserSession:output_type -> user_service.ValidateUserSessionResponse
-	11, // 47: user_service.UserService.UpdateUserPresence:output_type -> user_service.UpdateUserPresenceResponse
-	13, // 48: user_service.UserService.GetAvailableOperators:output_type -> user_service.GetAvailableOperatorsResponse
-	15, // 49: user_service.UserService.UpdateOperatorStatus:output_type -> user_service.UpdateOperatorStatusResponse
-	30, // [30:50] is the sub-list for method output_type
-	10, // [10:30] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	78, // 0: user_service.User.created_at:type_name -> google.protobuf.Timestamp
+	78, // 1: user_service.User.updated_at:type_name -> google.protobuf.Timestamp
+	78, // 2: user_service.ListUsersRequest.created_after:type_name -> google.protobuf.Timestamp
+	78, // 3: user_service.ListUsersRequest.created_before:type_name -> google.protobuf.Timestamp
+	16, // 4: user_service.ListUsersResponse.users:type_name -> user_service.UserResponse
+	79, // 5: user_service.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	78, // 6: user_service.ListAuditEventsRequest.from:type_name -> google.protobuf.Timestamp
+	78, // 7: user_service.ListAuditEventsRequest.to:type_name -> google.protobuf.Timestamp
+	13, // 8: user_service.ListAuditEventsResponse.events:type_name -> user_service.AuditEvent
+	80, // 9: user_service.AuditEvent.changes:type_name -> google.protobuf.Struct
+	78, // 10: user_service.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	78, // 11: user_service.UserResponse.created_at:type_name -> google.protobuf.Timestamp
+	78, // 12: user_service.UserResponse.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 13: user_service.UserResponse.profile:type_name -> user_service.UserProfile
+	18, // 14: user_service.UserResponse.operator:type_name -> user_service.OperatorInfo
+	19, // 15: user_service.UserResponse.presence:type_name -> user_service.UserPresence
+	78, // 16: user_service.UserPresence.last_seen_at:type_name -> google.protobuf.Timestamp
+	78, // 17: user_service.UserPresence.last_activity:type_name -> google.protobuf.Timestamp
+	78, // 18: user_service.UserPresence.last_login:type_name -> google.protobuf.Timestamp
+	16, // 19: user_service.GetAvailableOperatorsResponse.operators:type_name -> user_service.UserResponse
+	16, // 20: user_service.AuthResponse.user:type_name -> user_service.UserResponse
+	78, // 21: user_service.UserSessionResponse.joined_at:type_name -> google.protobuf.Timestamp
+	78, // 22: user_service.UserSessionResponse.left_at:type_name -> google.protobuf.Timestamp
+	49, // 23: user_service.GetUserSessionsResponse.sessions:type_name -> user_service.UserSessionResponse
+	49, // 24: user_service.GetActiveSessionsResponse.sessions:type_name -> user_service.UserSessionResponse
+	78, // 25: user_service.DeviceResponse.last_heartbeat:type_name -> google.protobuf.Timestamp
+	78, // 26: user_service.DeviceResponse.created_at:type_name -> google.protobuf.Timestamp
+	78, // 27: user_service.DeviceResponse.updated_at:type_name -> google.protobuf.Timestamp
+	58, // 28: user_service.ListDevicesResponse.devices:type_name -> user_service.DeviceResponse
+	67, // 29: user_service.UserServiceRoute.schedule:type_name -> user_service.ServiceSchedule
+	78, // 30: user_service.UserServiceRoute.created_at:type_name -> google.protobuf.Timestamp
+	78, // 31: user_service.UserServiceRoute.updated_at:type_name -> google.protobuf.Timestamp
+	67, // 32: user_service.UserServiceRouteRequest.schedule:type_name -> user_service.ServiceSchedule
+	79, // 33: user_service.UserServiceRouteRequest.update_mask:type_name -> google.protobuf.FieldMask
+	68, // 34: user_service.ListUserServiceRoutesResponse.services:type_name -> user_service.UserServiceRoute
+	1,  // 35: user_service.UserService.CreateUser:input_type -> user_service.CreateUserRequest
+	3,  // 36: user_service.UserService.ListUsers:input_type -> user_service.ListUsersRequest
+	2,  // 37: user_service.UserService.GetUser:input_type -> user_service.GetUserRequest
+	2,  // 38: user_service.UserService.GetUserCard:input_type -> user_service.GetUserRequest
+	5,  // 39: user_service.UserService.UpdateUser:input_type -> user_service.UpdateUserRequest
+	6,  // 40: user_service.UserService.DeleteUser:input_type -> user_service.DeleteUserRequest
+	8,  // 41: user_service.UserService.RestoreUser:input_type -> user_service.RestoreUserRequest
+	9,  // 42: user_service.UserService.PurgeUser:input_type -> user_service.PurgeUserRequest
+	11, // 43: user_service.UserService.ListAuditEvents:input_type -> user_service.ListAuditEventsRequest
+	14, // 44: user_service.UserService.SetUserRole:input_type -> user_service.SetUserRoleRequest
+	15, // 45: user_service.UserService.Login:input_type -> user_service.LoginRequest
+	29, // 46: user_service.UserService.Register:input_type -> user_service.RegisterRequest
+	30, // 47: user_service.UserService.Refresh:input_type -> user_service.RefreshRequest
+	31, // 48: user_service.UserService.Logout:input_type -> user_service.LogoutRequest
+	40, // 49: user_service.UserService.LoginVerifyMFA:input_type -> user_service.LoginVerifyMFARequest
+	33, // 50: user_service.UserService.RequestPasswordReset:input_type -> user_service.RequestPasswordResetRequest
+	35, // 51: user_service.UserService.ConfirmPasswordReset:input_type -> user_service.ConfirmPasswordResetRequest
+	37, // 52: user_service.UserService.VerifyEmail:input_type -> user_service.VerifyEmailRequest
+	38, // 53: user_service.UserService.ResendVerification:input_type -> user_service.ResendVerificationRequest
+	41, // 54: user_service.UserService.EnrollMFA:input_type -> user_service.EnrollMFARequest
+	43, // 55: user_service.UserService.ConfirmMFA:input_type -> user_service.ConfirmMFARequest
+	45, // 56: user_service.UserService.DisableMFA:input_type -> user_service.DisableMFARequest
+	47, // 57: user_service.UserService.GetMe:input_type -> user_service.GetMeRequest
+	5,  // 58: user_service.UserService.UpdateMe:input_type -> user_service.UpdateUserRequest
+	48, // 59: user_service.UserService.GetUserSessions:input_type -> user_service.GetUserSessionsRequest
+	51, // 60: user_service.UserService.GetActiveSessions:input_type -> user_service.GetActiveSessionsRequest
+	53, // 61: user_service.UserService.CreateSession:input_type -> user_service.CreateSessionRequest
+	54, // 62: user_service.UserService.EndSession:input_type -> user_service.EndSessionRequest
+	55, // 63: user_service.UserService.EndSessionsByExternalID:input_type -> user_service.EndSessionsByExternalIDRequest
+	57, // 64: user_service.UserService.RateConsultation:input_type -> user_service.RateConsultationRequest
+	59, // 65: user_service.UserService.RegisterDevice:input_type -> user_service.RegisterDeviceRequest
+	60, // 66: user_service.UserService.ListMyDevices:input_type -> user_service.ListMyDevicesRequest
+	62, // 67: user_service.UserService.RemoveDevice:input_type -> user_service.RemoveDeviceRequest
+	64, // 68: user_service.UserService.ConnectDevice:input_type -> user_service.ConnectDeviceRequest
+	65, // 69: user_service.UserService.DisconnectDevice:input_type -> user_service.DisconnectDeviceRequest
+	66, // 70: user_service.UserService.DeviceHeartbeat:input_type -> user_service.DeviceHeartbeatRequest
+	70, // 71: user_service.UserService.ListUserServiceRoutes:input_type -> user_service.ListUserServiceRoutesRequest
+	69, // 72: user_service.UserService.CreateUserServiceRoute:input_type -> user_service.UserServiceRouteRequest
+	69, // 73: user_service.UserService.UpdateUserServiceRoute:input_type -> user_service.UserServiceRouteRequest
+	72, // 74: user_service.UserService.DeleteUserServiceRoute:input_type -> user_service.DeleteUserServiceRouteRequest
+	74, // 75: user_service.UserService.ResolveUserServiceRoute:input_type -> user_service.ResolveUserServiceRouteRequest
+	26, // 76: user_service.UserService.UpdateOperatorAvailability:input_type -> user_service.UpdateOperatorStatusRequest
+	75, // 77: user_service.UserService.VerifyOperator:input_type -> user_service.VerifyOperatorRequest
+	76, // 78: user_service.UserService.GetOperatorStats:input_type -> user_service.GetOperatorStatsRequest
+	20, // 79: user_service.UserService.ValidateUserSession:input_type -> user_service.ValidateUserSessionRequest
+	22, // 80: user_service.UserService.UpdateUserPresence:input_type -> user_service.UpdateUserPresenceRequest
+	24, // 81: user_service.UserService.GetAvailableOperators:input_type -> user_service.GetAvailableOperatorsRequest
+	26, // 82: user_service.UserService.UpdateOperatorStatus:input_type -> user_service.UpdateOperatorStatusRequest
+	16, // 83: user_service.UserService.CreateUser:output_type -> user_service.UserResponse
+	4,  // 84: user_service.UserService.ListUsers:output_type -> user_service.ListUsersResponse
+	16, // 85: user_service.UserService.GetUser:output_type -> user_service.UserResponse
+	16, // 86: user_service.UserService.GetUserCard:output_type -> user_service.UserResponse
+	16, // 87: user_service.UserService.UpdateUser:output_type -> user_service.UserResponse
+	7,  // 88: user_service.UserService.DeleteUser:output_type -> user_service.DeleteUserResponse
+	16, // 89: user_service.UserService.RestoreUser:output_type -> user_service.UserResponse
+	10, // 90: user_service.UserService.PurgeUser:output_type -> user_service.PurgeUserResponse
+	12, // 91: user_service.UserService.ListAuditEvents:output_type -> user_service.ListAuditEventsResponse
+	16, // 92: user_service.UserService.SetUserRole:output_type -> user_service.UserResponse
+	28, // 93: user_service.UserService.Login:output_type -> user_service.AuthResponse
+	28, // 94: user_service.UserService.Register:output_type -> user_service.AuthResponse
+	28, // 95: user_service.UserService.Refresh:output_type -> user_service.AuthResponse
+	32, // 96: user_service.UserService.Logout:output_type -> user_service.LogoutResponse
+	28, // 97: user_service.UserService.LoginVerifyMFA:output_type -> user_service.AuthResponse
+	34, // 98: user_service.UserService.RequestPasswordReset:output_type -> user_service.RequestPasswordResetResponse
+	36, // 99: user_service.UserService.ConfirmPasswordReset:output_type -> user_service.ConfirmPasswordResetResponse
+	16, // 100: user_service.UserService.VerifyEmail:output_type -> user_service.UserResponse
+	39, // 101: user_service.UserService.ResendVerification:output_type -> user_service.ResendVerificationResponse
+	42, // 102: user_service.UserService.EnrollMFA:output_type -> user_service.EnrollMFAResponse
+	44, // 103: user_service.UserService.ConfirmMFA:output_type -> user_service.ConfirmMFAResponse
+	46, // 104: user_service.UserService.DisableMFA:output_type -> user_service.DisableMFAResponse
+	16, // 105: user_service.UserService.GetMe:output_type -> user_service.UserResponse
+	16, // 106: user_service.UserService.UpdateMe:output_type -> user_service.UserResponse
+	50, // 107: user_service.UserService.GetUserSessions:output_type -> user_service.GetUserSessionsResponse
+	52, // 108: user_service.UserService.GetActiveSessions:output_type -> user_service.GetActiveSessionsResponse
+	49, // 109: user_service.UserService.CreateSession:output_type -> user_service.UserSessionResponse
+	49, // 110: user_service.UserService.EndSession:output_type -> user_service.UserSessionResponse
+	56, // 111: user_service.UserService.EndSessionsByExternalID:output_type -> user_service.EndSessionsByExternalIDResponse
+	49, // 112: user_service.UserService.RateConsultation:output_type -> user_service.UserSessionResponse
+	58, // 113: user_service.UserService.RegisterDevice:output_type -> user_service.DeviceResponse
+	61, // 114: user_service.UserService.ListMyDevices:output_type -> user_service.ListDevicesResponse
+	63, // 115: user_service.UserService.RemoveDevice:output_type -> user_service.RemoveDeviceResponse
+	58, // 116: user_service.UserService.ConnectDevice:output_type -> user_service.DeviceResponse
+	58, // 117: user_service.UserService.DisconnectDevice:output_type -> user_service.DeviceResponse
+	58, // 118: user_service.UserService.DeviceHeartbeat:output_type -> user_service.DeviceResponse
+	71, // 119: user_service.UserService.ListUserServiceRoutes:output_type -> user_service.ListUserServiceRoutesResponse
+	68, // 120: user_service.UserService.CreateUserServiceRoute:output_type -> user_service.UserServiceRoute
+	68, // 121: user_service.UserService.UpdateUserServiceRoute:output_type -> user_service.UserServiceRoute
+	73, // 122: user_service.UserService.DeleteUserServiceRoute:output_type -> user_service.DeleteUserServiceRouteResponse
+	68, // 123: user_service.UserService.ResolveUserServiceRoute:output_type -> user_service.UserServiceRoute
+	27, // 124: user_service.UserService.UpdateOperatorAvailability:output_type -> user_service.UpdateOperatorStatusResponse
+	16, // 125: user_service.UserService.VerifyOperator:output_type -> user_service.UserResponse
+	77, // 126: user_service.UserService.GetOperatorStats:output_type -> user_service.GetOperatorStatsResponse
+	21, // 127: user_service.UserService.ValidateUserSession:output_type -> user_service.ValidateUserSessionResponse
+	23, // 128: user_service.UserService.UpdateUserPresence:output_type -> user_service.UpdateUserPresenceResponse
+	25, // 129: user_service.UserService.GetAvailableOperators:output_type -> user_service.GetAvailableOperatorsResponse
+	27, // 130: user_service.UserService.UpdateOperatorStatus:output_type -> user_service.UpdateOperatorStatusResponse
+	83, // [83:131] is the sub-list for method output_type
+	35, // [35:83] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_user_service_proto_init() }
@@ -2035,13 +5669,15 @@ func file_user_service_proto_init() {
 	if File_user_service_proto != nil {
 		return
 	}
+	file_user_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_user_service_proto_msgTypes[69].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_service_proto_rawDesc), len(file_user_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   78,
 			NumExtensions: 0,
 			NumServices:   1,
 		},